@@ -0,0 +1,130 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Command cragspider-arena plays the AI personalities against each other without a window and reports how each
+// did, so that a new strategy or scoring change can be shown to be stronger before it ships.
+package main
+
+import (
+	"context"
+	"cragspider-go/internal/ai"
+	"cragspider-go/internal/arena"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/rating"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+func main() {
+	var (
+		mode       = flag.String("mode", string(arena.RoundRobin), "how to pair players: roundrobin or gauntlet")
+		players    = flag.String("players", "", "comma-separated AI personalities to play (default all)")
+		challenger = flag.String("challenger", "", "the personality that faces everyone else in a gauntlet")
+		games      = flag.Int("games", 10, "games per pairing; colors alternate")
+		workers    = flag.Int("workers", runtime.NumCPU(), "games to play at once")
+		maxMoves   = flag.Int("max-moves", 0, "moves before a game is drawn (default from the game configuration)")
+		budget     = flag.Duration("budget", 0, "time per move (default each personality's own think time)")
+		untimed    = flag.Bool("untimed", false, "no think times and one search thread, so a seed always plays the same games")
+		seed       = flag.Int64("seed", 1, "seed for the bots' random choices")
+		out        = flag.String("out", "arena_results.json", "file to write every game's result to")
+		ratings    = flag.String("ratings", defaultRatingsPath(), "ratings file to update with every game (empty to skip)")
+	)
+	flag.Parse()
+
+	if err := run(arena.Config{
+		Players:    playerList(*players),
+		Mode:       arena.Mode(*mode),
+		Challenger: *challenger,
+		Games:      *games,
+		Workers:    *workers,
+		MaxMoves:   *maxMoves,
+		MoveBudget: *budget,
+		Untimed:    *untimed,
+		Seed:       *seed,
+	}, *out, *ratings); err != nil {
+		fmt.Fprintf(os.Stderr, "cragspider-arena: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run plays the tournament, prints its tables, writes the results file and updates the ratings file.
+func run(cfg arena.Config, out, ratingsPath string) error {
+	if len(cfg.Players) == 0 {
+		aiConfig, err := ai.GetAIConfig()
+		if err != nil {
+			return err
+		}
+		cfg.Players = lo.Map(aiConfig.Players, func(p ai.AIPlayerConfig, _ int) string { return p.Name })
+	}
+	gameConfig, err := core.GetConfig()
+	if err != nil {
+		return err
+	}
+	cfg.Progress = func(done, total int, game arena.GameResult) {
+		fmt.Fprintf(os.Stderr, "[%d/%d] %s vs %s: %s in %d moves (%s)\n",
+			done, total, game.White, game.Black, game.Result, game.Length(), game.Reason)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	start := time.Now()
+	results, err := arena.Run(ctx, cfg, gameConfig)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d games in %s\n\n", len(results.Games), time.Since(start).Round(time.Second))
+	if err := results.WriteTables(os.Stdout); err != nil {
+		return err
+	}
+	if out != "" {
+		if err := results.WriteFile(out); err != nil {
+			return err
+		}
+		fmt.Printf("\nResults written to %s\n", out)
+	}
+	if ratingsPath != "" {
+		if err := updateRatings(ratingsPath, results); err != nil {
+			return err
+		}
+		fmt.Printf("Ratings updated in %s\n", ratingsPath)
+	}
+	return nil
+}
+
+// updateRatings records every game of the tournament, in the order they were scheduled, in the ratings file.
+func updateRatings(path string, results *arena.Results) error {
+	table, err := rating.Load(path)
+	if err != nil {
+		return err
+	}
+	for _, game := range results.Games {
+		if _, err := table.Record(rating.AIKey(game.White), rating.AIKey(game.Black), game.Result); err != nil {
+			return err
+		}
+	}
+	return table.Save(path)
+}
+
+// defaultRatingsPath returns the user's ratings file, or nothing if there's nowhere to keep one.
+func defaultRatingsPath() string {
+	path, err := rating.DefaultPath()
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// playerList splits a comma-separated list of personality names.
+func playerList(list string) []string {
+	if list == "" {
+		return nil
+	}
+	return lo.Map(strings.Split(list, ","), func(name string, _ int) string { return strings.TrimSpace(name) })
+}
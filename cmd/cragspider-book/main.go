@@ -0,0 +1,67 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Command cragspider-book builds an opening book from the games recorded by cragspider-arena, keeping the opening
+// moves of the players who did well with them.
+//
+// Usage:
+//
+//	cragspider-book [flags] results.json...
+package main
+
+import (
+	"cragspider-go/internal/arena"
+	"cragspider-go/internal/book"
+	"cragspider-go/internal/core"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	var (
+		out       = flag.String("out", "book.json", "file to write the book to")
+		plies     = flag.Int("plies", 8, "moves from the start of each game to put in the book")
+		minWeight = flag.Float64("min-weight", 2, "leave out moves with less weight than this")
+		filter    = flag.String("results", string(book.Wins), "whose moves to keep: wins, draws (wins and draws) or all")
+	)
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: cragspider-book [flags] results.json...")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	opts := book.BuildOptions{Plies: *plies, MinWeight: *minWeight, Filter: book.Filter(*filter)}
+	if err := run(flag.Args(), opts, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "cragspider-book: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run reads the results files, builds the book and writes it out.
+func run(paths []string, opts book.BuildOptions, out string) error {
+	var games []book.Game
+	for _, path := range paths {
+		results, err := arena.ReadResults(path)
+		if err != nil {
+			return err
+		}
+		for _, game := range results.Games {
+			games = append(games, book.Game{Moves: game.Moves, Result: game.Result})
+		}
+	}
+	gameConfig, err := core.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	b, err := book.Build(gameConfig, games, opts)
+	if err != nil {
+		return err
+	}
+	if err := b.Save(out); err != nil {
+		return err
+	}
+	fmt.Printf("%d positions from %d games written to %s\n", b.Len(), len(games), out)
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Command cragspider-engine plays one of our AI personalities over the engine protocol on stdin and stdout, so that
+// other programs can play against it or analyze with it.
+package main
+
+import (
+	"context"
+	"cragspider-go/internal/ai"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/engine"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+)
+
+func main() {
+	var (
+		personality = flag.String("personality", "strategist", "the AI personality to play")
+		seed        = flag.Int64("seed", 0, "seed for the bot's random choices (default from the clock)")
+	)
+	flag.Parse()
+
+	if err := run(*personality, *seed); err != nil {
+		fmt.Fprintf(os.Stderr, "cragspider-engine: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run serves the personality until the game quits or stdin closes.
+func run(personality string, seed int64) error {
+	aiConfig, err := ai.GetAIConfig()
+	if err != nil {
+		return err
+	}
+	playerConfig, err := aiConfig.GetPlayerConfig(personality)
+	if err != nil {
+		return err
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	var players []*ai.Personality
+	defer func() {
+		for _, p := range players {
+			_ = p.Close()
+		}
+	}()
+	server := &engine.Server{
+		Name:   fmt.Sprintf("Cragspider %s", playerConfig),
+		Author: "Ideograph LLC",
+		NewStrategy: func(color core.Color) (core.TimedAgentStrategy, error) {
+			p, err := ai.NewStrategyWithConfig(playerConfig, color, seed+int64(len(players)))
+			if err != nil {
+				return nil, err
+			}
+			players = append(players, p)
+			return p, nil
+		},
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return server.Serve(ctx, os.Stdin, os.Stdout)
+}
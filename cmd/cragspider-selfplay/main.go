@@ -0,0 +1,150 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Command cragspider-selfplay records every position from games between AI personalities as training examples for
+// fitting evaluation functions offline. It plays untimed games without a window, or reads the games recorded by
+// cragspider-arena, and writes the examples as CSV or in a compact binary format.
+//
+// Usage:
+//
+//	cragspider-selfplay [flags] [results.json...]
+package main
+
+import (
+	"context"
+	"cragspider-go/internal/arena"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/training"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+)
+
+// settings are the command's flags.
+type settings struct {
+	players  []string
+	games    int
+	workers  int
+	maxMoves int
+	skip     int
+	seed     int64
+	format   training.Format
+	out      string
+}
+
+func main() {
+	var (
+		s       settings
+		players string
+		format  string
+	)
+	flag.StringVar(&players, "players", "strategist", "comma-separated personalities to play; one plays itself")
+	flag.IntVar(&s.games, "games", 20, "games per pairing")
+	flag.IntVar(&s.workers, "workers", runtime.NumCPU(), "games to play at once")
+	flag.IntVar(&s.maxMoves, "max-moves", 0, "moves before a game is drawn (default from the game configuration)")
+	flag.IntVar(&s.skip, "skip", 0, "opening moves of each game to leave out")
+	flag.Int64Var(&s.seed, "seed", 1, "seed for the games")
+	flag.StringVar(&format, "format", string(training.Binary), "format to write: csv or bin")
+	flag.StringVar(&s.out, "out", "", "file to write the examples to (default selfplay.<format>)")
+	flag.Parse()
+	for _, player := range strings.Split(players, ",") {
+		s.players = append(s.players, strings.TrimSpace(player))
+	}
+	if len(s.players) == 1 {
+		s.players = append(s.players, s.players[0])
+	}
+	s.format = training.Format(format)
+	if s.out == "" {
+		s.out = "selfplay." + format
+	}
+
+	if err := run(s, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "cragspider-selfplay: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run plays or reads the games and writes out their positions.
+func run(s settings, paths []string) error {
+	gameConfig, err := core.GetConfig()
+	if err != nil {
+		return err
+	}
+	encoder := training.NewEncoder(gameConfig)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var results []*arena.Results
+	if len(paths) == 0 {
+		played, err := play(ctx, s, gameConfig)
+		if err != nil {
+			return err
+		}
+		results = append(results, played)
+	}
+	for _, path := range paths {
+		read, err := arena.ReadResults(path)
+		if err != nil {
+			return err
+		}
+		results = append(results, read)
+	}
+
+	f, err := os.OpenFile(s.out, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to create training data: %w", err)
+	}
+	defer f.Close()
+	w, err := training.NewWriter(s.format, f, encoder)
+	if err != nil {
+		return err
+	}
+	var games []training.Game
+	for _, r := range results {
+		for _, game := range r.Games {
+			games = append(games, training.Game{Moves: game.Moves, Result: game.Result, Scores: game.Scores})
+		}
+	}
+	examples, err := training.Examples(games, gameConfig, encoder, s.skip)
+	if err != nil {
+		return err
+	}
+	var scored int
+	for _, example := range examples {
+		if err := w.Write(example); err != nil {
+			return err
+		}
+		if example.HasScore {
+			scored++
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write training data: %w", err)
+	}
+	fmt.Printf("%d positions (%d with search scores) from %d games written to %s\n",
+		len(examples), scored, len(games), s.out)
+	return nil
+}
+
+// play plays an untimed round robin between the players.
+func play(ctx context.Context, s settings, gameConfig *core.GameConfig) (*arena.Results, error) {
+	return arena.Run(ctx, arena.Config{
+		Players:  s.players,
+		Mode:     arena.RoundRobin,
+		Games:    s.games,
+		Workers:  s.workers,
+		MaxMoves: s.maxMoves,
+		Untimed:  true,
+		Seed:     s.seed,
+		Progress: func(done, total int, game arena.GameResult) {
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s vs %s: %s in %d moves\n",
+				done, total, game.White, game.Black, game.Result, game.Length())
+		},
+	}, gameConfig)
+}
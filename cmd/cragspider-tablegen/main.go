@@ -0,0 +1,96 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Command cragspider-tablegen solves an ending with a few pieces, and every ending it can turn into by captures,
+// and writes the tablebase that search bots probe at the leaves of their search.
+//
+// Usage:
+//
+//	cragspider-tablegen [flags] color:piece...
+//
+// For example, "cragspider-tablegen white:warrior white:padwar black:warrior".
+package main
+
+import (
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/tablebase"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	var (
+		out     = flag.String("out", "endings.tb", "file to write the tablebase to")
+		rows    = flag.Int("rows", 0, "rows on the board (default from the game configuration)")
+		columns = flag.Int("columns", 0, "columns on the board (default from the game configuration)")
+	)
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: cragspider-tablegen [flags] color:piece...")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Args(), *rows, *columns, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "cragspider-tablegen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run solves the ending with the listed pieces and writes the tablebase out.
+func run(args []string, rows, columns int, out string) error {
+	material, err := parsePieces(args)
+	if err != nil {
+		return err
+	}
+	gameConfig, err := core.GetConfig()
+	if err != nil {
+		return err
+	}
+	cfg := *gameConfig
+	if rows > 0 {
+		cfg.Board.Rows = rows
+	}
+	if columns > 0 {
+		cfg.Board.Columns = columns
+	}
+
+	start := time.Now()
+	tb, err := tablebase.Generate(&cfg, material, func(ending string, positions int) {
+		fmt.Fprintf(os.Stderr, "solved %s (%d positions) after %s\n",
+			endingName(ending), positions, time.Since(start).Round(time.Second))
+	})
+	if err != nil {
+		return err
+	}
+	if err := tb.Save(out); err != nil {
+		return err
+	}
+	fmt.Printf("%d endings on a %dx%d board written to %s\n", len(tb.Tables()), tb.Rows, tb.Columns, out)
+	return nil
+}
+
+// endingName names the ending with no pieces, which the tablebase solves along with the rest.
+func endingName(ending string) string {
+	if ending == "" {
+		return "no pieces"
+	}
+	return ending
+}
+
+// parsePieces parses pieces written as color:name, such as white:warrior.
+func parsePieces(args []string) ([]tablebase.Piece, error) {
+	var pieces []tablebase.Piece
+	for _, arg := range args {
+		for _, s := range strings.Split(arg, ",") {
+			color, name, ok := strings.Cut(strings.TrimSpace(s), ":")
+			if !ok || name == "" {
+				return nil, fmt.Errorf("piece '%s' should be written as color:name", s)
+			}
+			pieces = append(pieces, tablebase.Piece{Name: name, Color: core.Color(color)})
+		}
+	}
+	return pieces, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Command cragspider-train fits a learned evaluation function to the training data written by cragspider-selfplay
+// in its binary format, and writes the network out for an AI personality's evaluator setting.
+//
+// Usage:
+//
+//	cragspider-train [flags] selfplay.bin...
+package main
+
+import (
+	"cragspider-go/internal/training"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+)
+
+func main() {
+	var (
+		out          = flag.String("out", "network.json", "file to write the network to")
+		hidden       = flag.Int("hidden", 0, "hidden units; 0 fits a linear evaluator")
+		epochs       = flag.Int("epochs", training.DefaultEpochs, "passes over the examples")
+		batchSize    = flag.Int("batch", training.DefaultBatchSize, "examples averaged over for each step")
+		rate         = flag.Float64("rate", training.DefaultLearningRate, "learning rate")
+		scale        = flag.Float64("scale", training.DefaultScale, "score that gives White 0.73 expected points")
+		resultWeight = flag.Float64("result-weight", 0.5, "how much game results count against search scores, 0 to 1")
+		seed         = flag.Int64("seed", 1, "seed for the starting weights and the order of the examples")
+	)
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: cragspider-train [flags] selfplay.bin...")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	opts := training.TrainOptions{
+		Hidden:       *hidden,
+		Epochs:       *epochs,
+		BatchSize:    *batchSize,
+		LearningRate: float32(*rate),
+		Scale:        float32(*scale),
+		ResultWeight: float32(*resultWeight),
+		Seed:         *seed,
+		Progress: func(epoch int, loss float64) {
+			fmt.Fprintf(os.Stderr, "epoch %d: loss %.6f\n", epoch, loss)
+		},
+	}
+	if err := run(flag.Args(), opts, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "cragspider-train: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run reads the training data, fits the network and writes it out.
+func run(paths []string, opts training.TrainOptions, out string) error {
+	var (
+		encoder  *training.Encoder
+		examples []training.Example
+	)
+	for _, path := range paths {
+		read, fileEncoder, err := readExamples(path)
+		if err != nil {
+			return err
+		}
+		if encoder == nil {
+			encoder = fileEncoder
+		} else if fileEncoder.Rows != encoder.Rows || fileEncoder.Columns != encoder.Columns ||
+			!slices.Equal(fileEncoder.Pieces, encoder.Pieces) {
+			return fmt.Errorf("%s has different tensors from %s", path, paths[0])
+		}
+		examples = append(examples, read...)
+	}
+	fmt.Fprintf(os.Stderr, "Training on %d examples\n", len(examples))
+
+	network, report, err := training.Train(examples, encoder, opts)
+	if err != nil {
+		return err
+	}
+	if err := network.Save(out); err != nil {
+		return err
+	}
+	fmt.Printf("Loss %.6f -> %.6f over %d epochs; network written to %s\n",
+		report.InitialLoss, report.FinalLoss, report.Epochs, out)
+	return nil
+}
+
+// readExamples returns every example in the named file, with the encoder that describes them.
+func readExamples(path string) ([]training.Example, *training.Encoder, error) {
+	f, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open training data: %w", err)
+	}
+	defer f.Close()
+	r, err := training.NewReader(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	defer r.Close()
+	examples, err := r.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return examples, r.Encoder, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Command cragspider-tune tunes an AI personality's piece values and evaluation weights from games played without a
+// window, and writes the tuned personality out as YAML ready to paste into ai_config.yml. Games are played without
+// think times, so a run with the same flags always gives the same personality.
+package main
+
+import (
+	"context"
+	"cragspider-go/internal/ai"
+	"cragspider-go/internal/arena"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/tuning"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+)
+
+// settings are the command's flags.
+type settings struct {
+	personality string
+	name        string
+	opponents   []string
+	games       int
+	workers     int
+	maxMoves    int
+	skip        int
+	seed        int64
+	rounds      int
+	step        float64
+	results     string
+	out         string
+}
+
+func main() {
+	var (
+		s         settings
+		opponents string
+	)
+	flag.StringVar(&s.personality, "personality", "strategist", "the AI personality to tune")
+	flag.StringVar(&s.name, "name", "", "name for the tuned personality (default the personality's name with -tuned)")
+	flag.StringVar(&opponents, "opponents", "", "comma-separated personalities to play against (default itself)")
+	flag.IntVar(&s.games, "games", 20, "games against each opponent")
+	flag.IntVar(&s.workers, "workers", runtime.NumCPU(), "games to play and positions to score at once")
+	flag.IntVar(&s.maxMoves, "max-moves", 0, "moves before a game is drawn (default from the game configuration)")
+	flag.IntVar(&s.skip, "skip", 8, "opening moves of each game to leave out")
+	flag.Int64Var(&s.seed, "seed", 1, "seed for the games")
+	flag.IntVar(&s.rounds, "rounds", tuning.DefaultRounds, "passes over the parameters")
+	flag.Float64Var(&s.step, "step", tuning.DefaultStep, "first nudge to each parameter")
+	flag.StringVar(&s.results, "results", "", "tune from this cragspider-arena results file instead of playing games")
+	flag.StringVar(&s.out, "out", "", "file to write the tuned personality to (default tuned_<name>.yml)")
+	flag.Parse()
+	if opponents != "" {
+		for _, opponent := range strings.Split(opponents, ",") {
+			s.opponents = append(s.opponents, strings.TrimSpace(opponent))
+		}
+	}
+
+	if err := run(s, os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "cragspider-tune: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run plays or reads the games, tunes the personality and writes it out.
+func run(s settings, args []string) error {
+	aiConfig, err := ai.GetAIConfig()
+	if err != nil {
+		return err
+	}
+	base, err := aiConfig.GetPlayerConfig(s.personality)
+	if err != nil {
+		return err
+	}
+	gameConfig, err := core.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	results, err := games(ctx, s, gameConfig)
+	if err != nil {
+		return err
+	}
+	positions, err := tuning.Positions(results, gameConfig, s.skip)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Tuning %s with %d positions from %d games\n", s.personality, len(positions),
+		len(results.Games))
+
+	tuned, report, err := tuning.Tune(ctx, positions, base, tuning.Options{
+		Rounds:  s.rounds,
+		Step:    float32(s.step),
+		Workers: s.workers,
+		Progress: func(round int, err float64, step float32) {
+			fmt.Fprintf(os.Stderr, "round %d: error %.6f, step %.4f\n", round, err, step)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	tuned.Name = s.name
+	if tuned.Name == "" {
+		tuned.Name = base.Name + "-tuned"
+	}
+	if base.DisplayName != "" {
+		tuned.DisplayName = base.DisplayName + " (tuned)"
+	}
+	out := s.out
+	if out == "" {
+		out = "tuned_" + tuned.Name + ".yml"
+	}
+	header := fmt.Sprintf("Tuned from %s by: cragspider-tune %s\nK %.4f, error %.6f -> %.6f over %d rounds",
+		base.Name, strings.Join(args, " "), report.K, report.InitialError, report.FinalError, report.Rounds)
+	if err := tuning.WriteConfig(out, tuned, header); err != nil {
+		return err
+	}
+	fmt.Printf("Error %.6f -> %.6f; %s written to %s\n", report.InitialError, report.FinalError, tuned.Name, out)
+	return nil
+}
+
+// games returns the games to tune from: the results file if there is one, otherwise a fresh set of untimed games
+// between the personality and its opponents.
+func games(ctx context.Context, s settings, gameConfig *core.GameConfig) (*arena.Results, error) {
+	if s.results != "" {
+		return arena.ReadResults(s.results)
+	}
+	cfg := arena.Config{
+		Players:  []string{s.personality, s.personality},
+		Mode:     arena.RoundRobin,
+		Games:    s.games,
+		Workers:  s.workers,
+		MaxMoves: s.maxMoves,
+		Untimed:  true,
+		Seed:     s.seed,
+		Progress: func(done, total int, game arena.GameResult) {
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s vs %s: %s in %d moves\n",
+				done, total, game.White, game.Black, game.Result, game.Length())
+		},
+	}
+	if len(s.opponents) > 0 {
+		cfg.Players = append([]string{s.personality}, s.opponents...)
+		cfg.Mode = arena.Gauntlet
+		cfg.Challenger = s.personality
+	}
+	return arena.Run(ctx, cfg, gameConfig)
+}
@@ -3,6 +3,8 @@
 package main
 
 import (
+	"cragspider-go/internal/audio"
+	"cragspider-go/internal/input"
 	"cragspider-go/internal/scenes"
 	"os"
 
@@ -10,46 +12,78 @@ import (
 )
 
 const (
-	screenWidth  = 1920
-	screenHeight = 1080
+	// defaultWidth and defaultHeight are the window's size when the screen's size isn't known.
+	defaultWidth  = 1280
+	defaultHeight = 720
+	// minWidth and minHeight are the smallest the window can be made.
+	minWidth  = 800
+	minHeight = 600
+	// screenFraction is how much of the screen the window takes up to start with.
+	screenFraction = 0.8
 )
 
-// main is the entry point for the Cragspider game. Spawns a window and runs the game inside.
+// main is the entry point for the Cragspider game. Spawns a resizable window filling most of the screen and runs the
+// game inside.
 func main() {
-	rl.InitWindow(screenWidth, screenHeight, "Cragspider")
+	rl.SetConfigFlags(rl.FlagWindowResizable)
+	rl.InitWindow(defaultWidth, defaultHeight, "Cragspider")
 	defer rl.CloseWindow()
+	rl.SetWindowMinSize(minWidth, minHeight)
+	fitToMonitor()
 	rl.InitAudioDevice()
 	defer rl.CloseAudioDevice()
 
 	rl.SetTargetFPS(60)
+	// Escape can be bound like any other key, so it mustn't close the window as raylib has it do by default
+	rl.SetExitKey(rl.KeyNull)
 
 	if os.Getenv("DEBUG") != "" {
 		rl.SetTraceLogLevel(rl.LogDebug)
 	}
 
-	// TODO: Start with AttractModeScene when implemented
-	sceneCode := scenes.GameplayScene
-	for sceneCode != scenes.Quit {
-		rl.TraceLog(rl.LogInfo, "Starting scene code %v", sceneCode)
-		scene := initScene(sceneCode)
-		sceneCode = scene.Loop()
-		scene.Close()
+	audioConfig, err := audio.GetAudioConfig()
+	if err != nil {
+		rl.TraceLog(rl.LogFatal, "error loading audio configuration: %v", err)
 	}
+	warn := func(format string, args ...any) { rl.TraceLog(rl.LogWarning, format, args...) }
+	sounds := audio.NewManager(audio.NewRaylibBackend(), audioConfig, audio.DefaultDir, warn)
+	defer sounds.Close()
+
+	controls := input.NewControls(input.RaylibDevice{}, loadBindings(warn))
+
+	manager := scenes.NewManager(rl.GetScreenWidth(), rl.GetScreenHeight(), sounds, controls,
+		scenes.NewAttractMode())
+	defer manager.Close()
+	manager.Run()
+}
+
+// fitToMonitor sizes the window to most of the monitor it's on, keeping the default size's shape, and centers it.
+func fitToMonitor() {
+	monitor := rl.GetCurrentMonitor()
+	monitorWidth, monitorHeight := rl.GetMonitorWidth(monitor), rl.GetMonitorHeight(monitor)
+	if monitorWidth <= 0 || monitorHeight <= 0 {
+		return
+	}
+	scale := min(float64(monitorWidth)/defaultWidth, float64(monitorHeight)/defaultHeight) * screenFraction
+	width := max(int(defaultWidth*scale), minWidth)
+	height := max(int(defaultHeight*scale), minHeight)
+	rl.SetWindowSize(width, height)
+	rl.SetWindowPosition((monitorWidth-width)/2, (monitorHeight-height)/2)
 }
 
-// initScene initializes and returns the scene corresponding to the given scene code.
-func initScene(code scenes.SceneCode) scenes.Scene {
-	switch code {
-	case scenes.AttractModeScene:
-		// TODO
-	case scenes.GameplayScene:
-		gm := &scenes.Playfield{}
-		gm.Init(screenWidth, screenHeight)
-		return gm
-	case scenes.GameOverScene:
-		// TODO
-	default:
-		rl.TraceLog(rl.LogError, "Unknown or unimplemented scene code %v", code)
+// loadBindings returns the player's controls, or the standard controls if theirs can't be read.
+func loadBindings(warn func(format string, args ...any)) input.Bindings {
+	path, err := input.DefaultPath()
+	if err == nil {
+		var bindings input.Bindings
+		if bindings, err = input.LoadBindings(path); err == nil {
+			return bindings
+		}
+	}
+	warn("using the standard controls: %v", err)
+	bindings, err := input.DefaultBindings()
+	if err != nil {
+		rl.TraceLog(rl.LogFatal, "error loading controls: %v", err)
 	}
-	return nil
+	return bindings
 }
@@ -5,15 +5,101 @@ package ai
 import (
 	_ "embed"
 	"fmt"
+	"math/rand"
 	"sync"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+// StrategyType is the kind of bot that an AI player uses to pick its moves.
+type StrategyType string
+
+const (
+	// RandomStrategy makes random valid moves.
+	RandomStrategy StrategyType = "random"
+	// GreedyStrategy grabs the most valuable capture it can and otherwise moves at random, but not into harm's way.
+	GreedyStrategy StrategyType = "greedy"
+	// AlphaBetaStrategy searches ahead with alpha-beta pruning.
+	AlphaBetaStrategy StrategyType = "alphabeta"
+	// MCTSStrategy searches with Monte Carlo tree search.
+	MCTSStrategy StrategyType = "mcts"
+	// EngineStrategy asks another program for its moves over the engine protocol.
+	EngineStrategy StrategyType = "engine"
+)
+
+// SearchLimits bounds how much work a search bot does for each move.
+type SearchLimits struct {
+	MaxDepth     int `yaml:"max_depth,omitempty"`     // Deepest ply an alpha-beta search will reach
+	Iterations   int `yaml:"iterations,omitempty"`    // Number of playouts an MCTS search will run
+	PlayoutDepth int `yaml:"playout_depth,omitempty"` // Moves per MCTS playout before the board is scored
+	TableSize    int `yaml:"table_size,omitempty"`    // Transposition table entries for an alpha-beta search
+	Threads      int `yaml:"threads,omitempty"`       // Goroutines an alpha-beta search uses; zero means one
+}
+
+// ThinkTime is the range of time an AI player takes to think about each move.
+type ThinkTime struct {
+	Min time.Duration `yaml:"min,omitempty"`
+	Max time.Duration `yaml:"max,omitempty"`
+}
+
+// Budget returns a random time budget within the range. Zero means that there is no time limit, which is the case
+// when no think time is configured.
+func (tt ThinkTime) Budget(rng *rand.Rand) time.Duration {
+	if tt.Max <= tt.Min {
+		return tt.Min
+	}
+	return tt.Min + time.Duration(rng.Int63n(int64(tt.Max-tt.Min)))
+}
+
+// BookConfig says whether an AI player plays its opening moves from a book, and which.
+type BookConfig struct {
+	Enabled bool    `yaml:"enabled,omitempty"`
+	File    string  `yaml:"file,omitempty"`    // Book to play from instead of the one built into the game
+	Variety float64 `yaml:"variety,omitempty"` // Zero always plays the book's favorite; one picks in proportion to weight
+}
+
+// EngineConfig is the program an engine player runs.
+type EngineConfig struct {
+	Command string   `yaml:"command,omitempty"`
+	Args    []string `yaml:"args,omitempty"`
+}
+
 // AIPlayerConfig represents the configuration for an AI player.
 type AIPlayerConfig struct {
-	Name    string             `yaml:"name"`
-	Scoring map[string]float32 `yaml:"scoring"`
+	Name         string                 `yaml:"name"`
+	DisplayName  string                 `yaml:"display_name,omitempty"`
+	Strategy     StrategyType           `yaml:"strategy"`
+	Search       SearchLimits           `yaml:"search,omitempty"`
+	Temperature  float32                `yaml:"temperature,omitempty"`
+	ThinkTime    ThinkTime              `yaml:"think_time,omitempty"`
+	Book         BookConfig             `yaml:"book,omitempty"`
+	Tablebase    string                 `yaml:"tablebase,omitempty"` // Tablebase file an alpha-beta search probes
+	Evaluator    string                 `yaml:"evaluator,omitempty"` // Network file that scores boards for search
+	Engine       EngineConfig           `yaml:"engine,omitempty"`
+	Scoring      map[string]float32     `yaml:"scoring,omitempty"`
+	Weights      map[string]float32     `yaml:"weights,omitempty"`
+	SquareTables map[string][][]float32 `yaml:"square_tables,omitempty"`
+}
+
+// String returns the name to show for the AI player, falling back to its configuration name.
+func (p *AIPlayerConfig) String() string {
+	if p.DisplayName != "" {
+		return p.DisplayName
+	}
+	return p.Name
+}
+
+// Weight returns the weight this player gives to the named evaluation term. Terms that aren't listed have no
+// weight, except for material, which counts fully unless it's given a weight of its own.
+func (p *AIPlayerConfig) Weight(term string) float32 {
+	if weight, ok := p.Weights[term]; ok {
+		return weight
+	}
+	if term == TermMaterial {
+		return 1
+	}
+	return 0
 }
 
 // AIConfig holds all AI player configurations.
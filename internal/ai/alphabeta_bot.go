@@ -0,0 +1,345 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"context"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/tablebase"
+	"cragspider-go/pkg/random"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// AlphaBetaBot is a search bot that looks ahead with negamax alpha-beta pruning, scoring the positions at the end
+// of its search with a BoardScorer. It deepens its search one ply at a time so that it always has a move ready
+// when it runs out of time. A transposition table lets it reuse the results of positions it has already searched,
+// and ordering the most promising moves first lets it cut off the rest sooner.
+//
+// With more than one thread, the bot searches with lazy SMP: helper goroutines search the same position alongside
+// the main search, each in a slightly different order, and share what they find through the transposition table.
+// The main search still picks the move, but finds more of its answers in the table. Which helper gets where first
+// is down to the scheduler, so only a single-threaded search always plays the same move.
+//
+// With an Evaluator, such as a learned network, it scores the boards at the end of its search with that instead of
+// the BoardScorer, which still values the pieces for move ordering.
+//
+// With a tablebase, endings it has solved are scored at the leaves of the search by their known result rather than
+// by the BoardScorer, so the bot plays them perfectly once it can see them.
+type AlphaBetaBot struct {
+	Color       core.Color
+	MaxDepth    int
+	Temperature float32              // Zero always plays the best move; higher picks among near-equal moves
+	TableSize   int                  // Entries in the transposition table; zero searches without one
+	Ordering    bool                 // Search captures, killer moves and moves with a good history first
+	Threads     int                  // Goroutines searching at once; zero or one searches on just one
+	Tablebase   *tablebase.Tablebase // Solved endings to probe at the leaves; nil to always use the scorer
+	Evaluator   Evaluator            // Scores the boards at the leaves; nil means the scorer
+	scorer      *BoardScorer
+	rng         *rand.Rand // Nil means the shared generator
+	table       *transpositionTable
+	stats       SearchStats
+}
+
+// searcher is one thread of an AlphaBetaBot's search, with the move ordering it has learned along the way.
+type searcher struct {
+	bot         *AlphaBetaBot
+	orderer     *moveOrderer
+	temperature float32    // Only the main search picks among near-equal moves
+	shuffle     *rand.Rand // Helpers shuffle the root moves so that they don't all search the same line first
+	nodes       int64
+	score       float32 // Score of the move picked by the last fully searched root
+}
+
+// SearchStats describes the work a search bot did to find its last move.
+type SearchStats struct {
+	Depth int     // Deepest ply fully searched
+	Nodes int64   // Positions visited
+	Score float32 // Score of the move played from the bot's point of view, as of the deepest ply fully searched
+}
+
+var (
+	_ core.AgentStrategy      = (*AlphaBetaBot)(nil)
+	_ core.TimedAgentStrategy = (*AlphaBetaBot)(nil)
+)
+
+// winScore is the score of a won game. It's far beyond anything the BoardScorer gives a position, so that search
+// bots always prefer winning to being ahead.
+const winScore float32 = 100000
+
+// NewAlphaBetaBot returns a new AlphaBetaBot for the specified color that scores positions with the given scorer
+// and searches no deeper than maxDepth plies, with move ordering and a transposition table of the default size.
+func NewAlphaBetaBot(color core.Color, scorer *BoardScorer, maxDepth int) *AlphaBetaBot {
+	return &AlphaBetaBot{
+		Color:     color,
+		MaxDepth:  maxDepth,
+		TableSize: defaultTableSize,
+		Ordering:  true,
+		scorer:    scorer,
+	}
+}
+
+// NextMove returns the best move the bot finds searching to its full depth, with no time limit.
+func (ab *AlphaBetaBot) NextMove(board *core.Board) (*core.Action, error) {
+	return ab.NextMoveContext(context.Background(), board, 0)
+}
+
+// NextMoveContext returns the best move the bot finds within the budget. A budget of zero means no time limit, so
+// the search is bounded only by MaxDepth. If the context is cancelled, the best move found so far is returned.
+// The transposition table is kept from one move to the next, since the positions a game reaches next are often
+// ones the last search already looked at.
+func (ab *AlphaBetaBot) NextMoveContext(ctx context.Context, board *core.Board, budget time.Duration) (*core.Action, error) {
+	if budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+	switch {
+	case ab.TableSize <= 0:
+		ab.table = nil
+	case ab.table == nil || ab.table.size() != tableSize(ab.TableSize):
+		ab.table = newTranspositionTable(ab.TableSize)
+	}
+
+	main := &searcher{bot: ab, orderer: newMoveOrderer(ab.scorer, board), temperature: ab.Temperature}
+	helpers := make([]*searcher, max(ab.Threads, 1)-1)
+	helperCtx, stopHelpers := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	for i := range helpers {
+		helpers[i] = &searcher{bot: ab, orderer: newMoveOrderer(ab.scorer, board), shuffle: random.New(int64(i))}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			helpers[i].help(helperCtx, board, i)
+		}()
+	}
+
+	var previousBest *core.Action
+	action, depth, err := iterativeDeepening(ctx, ab.MaxDepth, func(ctx context.Context, depth int) (*core.Action, error) {
+		best, err := main.searchRoot(ctx, board, depth, previousBest)
+		if err == nil {
+			previousBest = best
+		}
+		return best, err
+	})
+	stopHelpers()
+	wg.Wait()
+
+	ab.stats = SearchStats{Depth: depth, Nodes: main.nodes, Score: main.score}
+	for _, h := range helpers {
+		ab.stats.Nodes += h.nodes
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s alpha-beta bot: %w", ab.Color, err)
+	}
+	return action, nil
+}
+
+// Stats returns the work the bot did to find its last move.
+func (ab *AlphaBetaBot) Stats() SearchStats {
+	return ab.stats
+}
+
+// help searches the board deeper and deeper until the context ends or there's nothing deeper to search, filling
+// the transposition table for the main search. Every other helper starts a ply deeper, so that they spread out
+// over the depths the main search is about to reach.
+func (s *searcher) help(ctx context.Context, board *core.Board, id int) {
+	for depth := 1 + id%2; depth <= s.bot.MaxDepth; depth++ {
+		if _, err := s.searchRoot(ctx, board, depth, nil); err != nil {
+			return
+		}
+	}
+}
+
+// searchRoot searches every action available at the root to the given depth and returns the best one. The best
+// action from the previous iteration is searched first, since it is the most likely to still be best and so
+// tightens the window for everything after it. With a temperature, every root action is searched with a full
+// window so that near-equal moves have exact scores to choose between. If the context ends, the best fully
+// searched action is returned along with the context's error.
+func (s *searcher) searchRoot(ctx context.Context, board *core.Board, depth int, previousBest *core.Action) (*core.Action, error) {
+	ab := s.bot
+	moves := generateMoves(board, ab.Color)
+	if len(moves) == 0 {
+		return nil, nil
+	}
+	s.order(board, moves, 0, previousBest)
+	if s.shuffle != nil {
+		s.shuffle.Shuffle(len(moves), func(i, j int) { moves[i], moves[j] = moves[j], moves[i] })
+	}
+
+	var best *core.Action
+	scores := make([]float32, 0, len(moves))
+	alpha := float32(math.Inf(-1))
+	beta := float32(math.Inf(1))
+	for i := range moves {
+		child, err := moves[i].play(board)
+		if err != nil {
+			return nil, err
+		}
+		window := lo.Ternary(s.temperature > 0, float32(math.Inf(-1)), alpha)
+		score, err := s.negamax(ctx, child, ab.Color.Opponent(), depth-1, 1, -beta, -window)
+		if err != nil {
+			return best, err
+		}
+		score = -score
+		scores = append(scores, score)
+		if best == nil || score > alpha {
+			alpha = score
+			best = &moves[i].Action
+		}
+	}
+	if s.temperature > 0 {
+		picked := pickWithTemperature(ab.rng, scores, s.temperature)
+		s.score = scores[picked]
+		return &moves[picked].Action, nil
+	}
+	s.score = alpha
+	return best, nil
+}
+
+// negamax returns the score of the board from the point of view of color, the side to move, searching depth more
+// plies; ply is how far the board is from the root. Branches that can't beat alpha or that the opponent would avoid
+// (beta) are cut off.
+func (s *searcher) negamax(ctx context.Context, board *core.Board, color core.Color, depth, ply int, alpha, beta float32) (float32, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	ab := s.bot
+	s.nodes++
+	if depth <= 0 {
+		if score, ok := ab.probe(board, color, depth); ok {
+			return score, nil
+		}
+		if !board.HasValidActions(color) {
+			return -winScore, nil
+		}
+		return ab.evaluate(board, color)
+	}
+
+	// A position searched at least this deep before may already have an answer, or at least a narrower window
+	var key uint64
+	var tableMove *core.Action
+	originalAlpha := alpha
+	if ab.table != nil {
+		key = board.Hash(color)
+		if entry, ok := ab.table.probe(key); ok {
+			if entry.best.Piece != nil {
+				tableMove = &entry.best
+			}
+			if entry.depth >= depth {
+				switch entry.bound {
+				case exactBound:
+					return entry.score, nil
+				case lowerBound:
+					alpha = max(alpha, entry.score)
+				case upperBound:
+					beta = min(beta, entry.score)
+				}
+				if alpha >= beta {
+					return entry.score, nil
+				}
+			}
+		}
+	}
+
+	moves := generateMoves(board, color)
+	if len(moves) == 0 {
+		// No moves on your turn loses the game. Losing sooner, with more depth left to search, is worse.
+		return -winScore - float32(depth), nil
+	}
+	s.order(board, moves, ply, tableMove)
+
+	best := float32(math.Inf(-1))
+	var bestMove *searchMove
+	for i := range moves {
+		child, err := moves[i].play(board)
+		if err != nil {
+			return 0, err
+		}
+		score, err := s.negamax(ctx, child, color.Opponent(), depth-1, ply+1, -beta, -alpha)
+		if err != nil {
+			return 0, err
+		}
+		score = -score
+		if score > best {
+			best, bestMove = score, &moves[i]
+		}
+		alpha = max(alpha, score)
+		if alpha >= beta {
+			if ab.Ordering {
+				s.orderer.cutoff(board, &moves[i], ply, depth)
+			}
+			break
+		}
+	}
+
+	if ab.table != nil {
+		b := exactBound
+		switch {
+		case best <= originalAlpha:
+			b = upperBound
+		case best >= beta:
+			b = lowerBound
+		}
+		ab.table.store(key, depth, b, best, &bestMove.Action)
+	}
+	return best, nil
+}
+
+// order sorts the moves into the order to search them, starting with first if it's given. Without move ordering,
+// only first is moved ahead of the rest.
+func (s *searcher) order(board *core.Board, moves []searchMove, ply int, first *core.Action) {
+	if s.bot.Ordering {
+		s.orderer.order(board, moves, ply, first)
+		return
+	}
+	if first == nil {
+		return
+	}
+	for i := range moves {
+		if sameAction(moves[i].Action, *first) {
+			moves[0], moves[i] = moves[i], moves[0]
+			return
+		}
+	}
+}
+
+// probe returns the score of the board from the point of view of color, the side to move, if the tablebase has
+// solved it, searching depth more plies. Its scores are on the same scale as games that end in the search: a game
+// the tablebase has lost in Distance plies scores like a board with no moves Distance plies deeper, at depth minus
+// Distance, so a win sooner scores higher than a win later wherever it's found.
+func (ab *AlphaBetaBot) probe(board *core.Board, color core.Color, depth int) (float32, bool) {
+	if ab.Tablebase == nil {
+		return 0, false
+	}
+	entry, ok := ab.Tablebase.Probe(board, color)
+	if !ok {
+		return 0, false
+	}
+	switch entry.WDL {
+	case tablebase.Win:
+		return winScore + float32(depth-entry.Distance), true
+	case tablebase.Loss:
+		return -winScore - float32(depth-entry.Distance), true
+	default:
+		return 0, true
+	}
+}
+
+// evaluate returns the static score of the board from the point of view of color.
+func (ab *AlphaBetaBot) evaluate(board *core.Board, color core.Color) (float32, error) {
+	var evaluator Evaluator = ab.scorer
+	if ab.Evaluator != nil {
+		evaluator = ab.Evaluator
+	}
+	score, err := evaluator.Score(board)
+	if err != nil {
+		return 0, err
+	}
+	return sign(color) * score, nil
+}
@@ -0,0 +1,307 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/core/coretest"
+	"cragspider-go/internal/tablebase"
+	"cragspider-go/pkg/random"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAlphaBetaBot returns an AlphaBetaBot using the doofus scorer.
+func newTestAlphaBetaBot(t *testing.T, color core.Color, maxDepth int) *AlphaBetaBot {
+	scorer, err := NewBoardScorer("doofus")
+	require.NoError(t, err, "should create scorer")
+	return NewAlphaBetaBot(color, scorer, maxDepth)
+}
+
+func TestAlphaBetaBotNextMove_ReturnsValidMove(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	bot := newTestAlphaBetaBot(t, core.Black, 2)
+	action, err := bot.NextMove(game.Board)
+	require.NoError(t, err, "should return no error")
+	require.NotNil(t, action, "should return an action")
+	assert.Equal(t, core.Black, action.Piece.Color, "piece should be black")
+
+	_, err = game.Board.ApplyAction(action)
+	assert.NoError(t, err, "action should be valid on the board")
+}
+
+func TestAlphaBetaBotNextMove_TakesFreeCapture(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	// Put a black padwar two squares above the white warrior in the corner, where nothing can recapture
+	padwar := &core.Piece{Name: "padwar", Color: core.Black}
+	board, err := game.Board.PlacePiece(padwar, core.Position{7, 0})
+	require.NoError(t, err, "should place piece")
+	warrior := board.GetPieceAt(core.Position{9, 0})
+	require.NotNil(t, warrior, "white warrior should start in the corner")
+
+	for _, depth := range []int{1, 2, 3} {
+		bot := newTestAlphaBetaBot(t, core.White, depth)
+		action, err := bot.NextMove(board)
+		require.NoError(t, err, "should return no error at depth %d", depth)
+		assert.Equal(t, warrior, action.Piece, "should move the warrior at depth %d", depth)
+		assert.Equal(t, core.Move{-2, 0}, action.Move, "should capture the padwar at depth %d", depth)
+	}
+}
+
+func TestAlphaBetaBotNextMoveContext_StopsWhenBudgetRunsOut(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	// Far too deep to finish, so the budget is what stops the search
+	bot := newTestAlphaBetaBot(t, core.White, 50)
+	start := time.Now()
+	action, err := bot.NextMoveContext(context.Background(), game.Board, 50*time.Millisecond)
+	require.NoError(t, err, "should return the best move found so far")
+	require.NotNil(t, action, "should return an action")
+	assert.Less(t, time.Since(start), 2*time.Second, "should stop soon after the budget runs out")
+
+	_, err = game.Board.ApplyAction(action)
+	assert.NoError(t, err, "action should be valid on the board")
+}
+
+func TestAlphaBetaBotNextMoveContext_CancelledBeforeStarting(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bot := newTestAlphaBetaBot(t, core.White, 3)
+	action, err := bot.NextMoveContext(ctx, game.Board, 0)
+	assert.Error(t, err, "should fail when there was no time to find anything")
+	assert.Nil(t, action)
+}
+
+// searchVariants are the ways of configuring an AlphaBetaBot's search that the node counts compare.
+var searchVariants = []struct {
+	name      string
+	tableSize int
+	ordering  bool
+}{
+	{"plain", 0, false},
+	{"ordering", 0, true},
+	{"table", defaultTableSize, false},
+	{"table and ordering", defaultTableSize, true},
+}
+
+// midgamePosition returns the board after a few moves by each side, where there's more going on than at the start.
+func midgamePosition(t testing.TB) *core.Board {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+	for range 6 {
+		actions := game.Board.ValidActions(game.ActiveColor)
+		require.NoError(t, game.Play(&actions[len(actions)/2]))
+	}
+	return game.Board
+}
+
+func TestAlphaBetaBot_TableAndOrderingReduceNodes(t *testing.T) {
+	board := midgamePosition(t)
+	scorer, err := NewBoardScorer("strategist")
+	require.NoError(t, err, "should create scorer")
+	nodes := make(map[string]int64)
+	for _, v := range searchVariants {
+		bot := NewAlphaBetaBot(core.White, scorer, 4)
+		bot.TableSize, bot.Ordering = v.tableSize, v.ordering
+		action, err := bot.NextMove(board)
+		require.NoError(t, err, "%s should return no error", v.name)
+		_, err = board.ApplyAction(action)
+		require.NoError(t, err, "%s should find a valid move", v.name)
+		assert.Equal(t, 4, bot.Stats().Depth, "%s should search to full depth", v.name)
+		nodes[v.name] = bot.Stats().Nodes
+	}
+	t.Logf("nodes searched: %v", nodes)
+	assert.Less(t, nodes["ordering"], nodes["plain"], "ordering should search fewer nodes")
+	assert.Less(t, nodes["table"], nodes["plain"], "the table should search fewer nodes")
+	assert.Less(t, nodes["table and ordering"], nodes["ordering"], "both should search fewer nodes than either")
+	assert.Less(t, nodes["table and ordering"], nodes["table"], "both should search fewer nodes than either")
+}
+
+func BenchmarkAlphaBetaBot(b *testing.B) {
+	board := midgamePosition(b)
+	scorer, err := NewBoardScorer("strategist")
+	require.NoError(b, err)
+	for _, v := range searchVariants {
+		b.Run(v.name, func(b *testing.B) {
+			var nodes int64
+			for b.Loop() {
+				bot := NewAlphaBetaBot(core.White, scorer, 4)
+				bot.TableSize, bot.Ordering = v.tableSize, v.ordering
+				if _, err := bot.NextMove(board); err != nil {
+					b.Fatal(err)
+				}
+				nodes += bot.Stats().Nodes
+			}
+			b.ReportMetric(float64(nodes)/float64(b.N), "nodes/op")
+		})
+	}
+}
+
+func TestAlphaBetaBot_SingleThreadIsDeterministic(t *testing.T) {
+	board := midgamePosition(t)
+	scorer, err := NewBoardScorer("strategist")
+	require.NoError(t, err, "should create scorer")
+
+	play := func() []core.Action {
+		bot := NewAlphaBetaBot(core.White, scorer, 3)
+		bot.Threads = 1
+		bot.Temperature = 0.5
+		bot.rng = random.New(9)
+		var actions []core.Action
+		for range 5 {
+			action, err := bot.NextMove(board)
+			require.NoError(t, err, "should return no error")
+			actions = append(actions, *action)
+		}
+		return actions
+	}
+	assert.Equal(t, play(), play(), "the same seed should pick the same moves")
+}
+
+func TestAlphaBetaBot_Threads(t *testing.T) {
+	board := midgamePosition(t)
+	scorer, err := NewBoardScorer("strategist")
+	require.NoError(t, err, "should create scorer")
+
+	single := NewAlphaBetaBot(core.White, scorer, 4)
+	_, err = single.NextMove(board)
+	require.NoError(t, err, "should return no error")
+
+	bot := NewAlphaBetaBot(core.White, scorer, 4)
+	bot.Threads = 4
+	action, err := bot.NextMove(board)
+	require.NoError(t, err, "should return no error")
+	_, err = board.ApplyAction(action)
+	require.NoError(t, err, "should find a valid move")
+	assert.Equal(t, single.Stats().Depth, bot.Stats().Depth, "should search as deep as a single thread")
+	assert.Equal(t, 4, bot.Stats().Depth, "should search to full depth")
+	assert.Equal(t, single.Stats().Score, bot.Stats().Score, "helpers shouldn't change the position's value")
+	assert.NotZero(t, bot.Stats().Nodes)
+
+	// Every thread is stopped by the budget
+	bot.MaxDepth = 50
+	start := time.Now()
+	_, err = bot.NextMoveContext(context.Background(), board, 50*time.Millisecond)
+	require.NoError(t, err, "should return the best move found so far")
+	assert.Less(t, time.Since(start), 2*time.Second, "should stop soon after the budget runs out")
+}
+
+func BenchmarkAlphaBetaBot_Threads(b *testing.B) {
+	board := midgamePosition(b)
+	scorer, err := NewBoardScorer("strategist")
+	require.NoError(b, err)
+	for _, threads := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("%d threads", threads), func(b *testing.B) {
+			for b.Loop() {
+				bot := NewAlphaBetaBot(core.White, scorer, 5)
+				bot.Threads = threads
+				if _, err := bot.NextMove(board); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestAlphaBetaBot_PlaysEndingsFromTablebase(t *testing.T) {
+	cfg := coretest.SmallConfig(t, 4, 4)
+	material := []tablebase.Piece{
+		{Name: "warrior", Color: core.White},
+		{Name: "padwar", Color: core.White},
+		{Name: "warrior", Color: core.Black},
+	}
+	tb, err := tablebase.Generate(cfg, material, nil)
+	require.NoError(t, err)
+
+	// Find a position White wins, but not right away
+	var board *core.Board
+	var entry tablebase.Entry
+	for square := 0; square < 16 && board == nil; square++ {
+		cfg.Board.White = []core.BoardPosition{
+			{Name: "warrior", Position: core.Position{0, 0}},
+			{Name: "padwar", Position: core.Position{0, 1}},
+		}
+		cfg.Board.Black = []core.BoardPosition{{Name: "warrior", Position: core.Position{square / 4, square % 4}}}
+		game, err := core.NewGameWithConfig(cfg)
+		if err != nil {
+			continue
+		}
+		if e, ok := tb.Probe(game.Board, core.White); ok && e.WDL == tablebase.Win && e.Distance >= 3 {
+			board, entry = game.Board, e
+		}
+	}
+	require.NotNil(t, board, "should find a longer win")
+
+	bot := newTestAlphaBetaBot(t, core.White, 1)
+	bot.Tablebase = tb
+	action, err := bot.NextMove(board)
+	require.NoError(t, err)
+	child, err := board.ApplyAction(action)
+	require.NoError(t, err)
+	result, ok := tb.Probe(child, core.Black)
+	require.True(t, ok)
+	assert.Equal(t, tablebase.Entry{WDL: tablebase.Loss, Distance: entry.Distance - 1}, result,
+		"should play the quickest win")
+
+	// Searching on to the tablebase's positions scores the win as if the search had found the end of the game
+	bot = newTestAlphaBetaBot(t, core.White, 2)
+	bot.Tablebase = tb
+	_, err = bot.NextMove(board)
+	require.NoError(t, err)
+	want, ok := bot.probe(board, core.White, 2)
+	require.True(t, ok)
+	assert.Equal(t, want, bot.Stats().Score)
+	assert.Equal(t, winScore+float32(2-entry.Distance), want)
+}
+
+func TestAlphaBetaBot_StatsScore(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+	padwar := &core.Piece{Name: "padwar", Color: core.Black}
+	board, err := game.Board.PlacePiece(padwar, core.Position{7, 0})
+	require.NoError(t, err, "should place piece")
+
+	bot := newTestAlphaBetaBot(t, core.White, 1)
+	before, err := bot.scorer.Score(board)
+	require.NoError(t, err)
+	_, err = bot.NextMove(board)
+	require.NoError(t, err)
+	assert.Greater(t, bot.Stats().Score, before, "the score should count the free capture")
+}
+
+// countingEvaluator scores every board zero, counting how many it's asked about.
+type countingEvaluator struct {
+	boards int
+}
+
+func (e *countingEvaluator) Score(*core.Board) (float32, error) {
+	e.boards++
+	return 0, nil
+}
+
+func TestAlphaBetaBot_ScoresLeavesWithEvaluator(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	evaluator := &countingEvaluator{}
+	bot := newTestAlphaBetaBot(t, core.White, 2)
+	bot.Evaluator = evaluator
+	_, err = bot.NextMove(game.Board)
+	require.NoError(t, err)
+	assert.NotZero(t, evaluator.boards, "the evaluator should score the leaves")
+	assert.Zero(t, bot.Stats().Score, "every leaf scores zero")
+}
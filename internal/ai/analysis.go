@@ -0,0 +1,224 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"cmp"
+	"context"
+	"cragspider-go/internal/core"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Analyzer is a bot that can explain its choice of move rather than just make it.
+type Analyzer interface {
+	// Analyze searches the board for the specified color to move within the budget, returning up to lines of the
+	// best candidate actions it finds. A budget of zero means no time limit.
+	Analyze(ctx context.Context, board *core.Board, toMove core.Color, budget time.Duration, lines int) (*Analysis, error)
+}
+
+// Line is a candidate action along with how the bot expects the game to go if it's played.
+type Line struct {
+	Action core.Action
+	Score  float32           // From the point of view of the side to move
+	PV     []core.MoveRecord // Principal variation: the action and the best replies the search found to it
+}
+
+// Analysis is what a bot found when it searched a position: its candidate actions, best first, and how much work
+// it did to find them.
+type Analysis struct {
+	ToMove core.Color
+	Lines  []Line
+	Depth  int   // Deepest ply fully searched
+	Nodes  int64 // Positions visited
+}
+
+// Best returns the best line, or nil if there was nothing to play.
+func (a *Analysis) Best() *Line {
+	if len(a.Lines) == 0 {
+		return nil
+	}
+	return &a.Lines[0]
+}
+
+// Eval returns the score of the best line from White's point of view, as an evaluation bar shows it.
+func (a *Analysis) Eval() float32 {
+	if len(a.Lines) == 0 {
+		return 0
+	}
+	return sign(a.ToMove) * a.Lines[0].Score
+}
+
+// String returns the analysis as one line per candidate, suitable for logging.
+func (a *Analysis) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s to move, depth %d, %d nodes", a.ToMove, a.Depth, a.Nodes)
+	for i, line := range a.Lines {
+		moves := make([]string, len(line.PV))
+		for j, m := range line.PV {
+			moves[j] = m.String()
+		}
+		fmt.Fprintf(&sb, "\n%d. %s: %s", i+1, FormatScore(line.Score), strings.Join(moves, ", "))
+	}
+	return sb.String()
+}
+
+// FormatScore returns a score as a person would want to read it: won and lost games as such, and anything else as
+// a signed number.
+func FormatScore(score float32) string {
+	switch {
+	case score >= winScore/2:
+		return "win"
+	case score <= -winScore/2:
+		return "loss"
+	default:
+		return fmt.Sprintf("%+.2f", score)
+	}
+}
+
+var _ Analyzer = (*AlphaBetaBot)(nil)
+
+// Analyze searches the board with toMove to move, deepening one ply at a time until the budget runs out or the
+// bot's MaxDepth is reached, and returns the best lines of the deepest search that finished. Only the moves that
+// could make the top lines are searched with a full window, so asking for more lines costs more. The analysis is
+// done on one thread, and the principal variations are read back from the transposition table.
+func (ab *AlphaBetaBot) Analyze(ctx context.Context, board *core.Board, toMove core.Color, budget time.Duration,
+	lines int) (*Analysis, error) {
+	if budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+	if ab.MaxDepth < 1 {
+		return nil, fmt.Errorf("max depth must be at least 1, got %d", ab.MaxDepth)
+	}
+	lines = max(lines, 1)
+	switch {
+	case ab.TableSize <= 0:
+		ab.table = nil
+	case ab.table == nil || ab.table.size() != tableSize(ab.TableSize):
+		ab.table = newTranspositionTable(ab.TableSize)
+	}
+
+	// The search plays whichever color the bot is for, so analyze with a copy that's on the move
+	analyst := *ab
+	analyst.Color = toMove
+	s := &searcher{bot: &analyst, orderer: newMoveOrderer(ab.scorer, board)}
+	analysis := &Analysis{ToMove: toMove}
+	for depth := 1; depth <= ab.MaxDepth; depth++ {
+		var previousBest *core.Action
+		if best := analysis.Best(); best != nil {
+			previousBest = &best.Action
+		}
+		found, err := s.analyzeRoot(ctx, board, depth, lines, previousBest)
+		if err != nil {
+			if ctx.Err() == nil {
+				return nil, fmt.Errorf("%s analysis: %w", toMove, err)
+			}
+			// Out of time. A partial first search is still better than nothing.
+			if depth == 1 && len(found) > 0 {
+				analysis.Lines, analysis.Depth = found, 1
+			}
+			break
+		}
+		analysis.Lines, analysis.Depth = found, depth
+		if len(found) == 0 {
+			break
+		}
+	}
+	analysis.Nodes = s.nodes
+	for i := range analysis.Lines {
+		analysis.Lines[i].PV = ab.principalVariation(board, toMove, analysis.Lines[i].Action, analysis.Depth)
+	}
+	if analysis.Depth == 0 {
+		return nil, fmt.Errorf("%s analysis stopped before finding a move: %w", toMove, ctx.Err())
+	}
+	return analysis, nil
+}
+
+// analyzeRoot searches every action at the root to the given depth and returns the best lines of them, best first.
+// Each action is searched with a window just below the worst of the best lines so far, so that actions that can't
+// make the cut are cut off as soon as possible. If the context ends, the lines found so far are returned along with
+// the context's error.
+func (s *searcher) analyzeRoot(ctx context.Context, board *core.Board, depth, lines int,
+	previousBest *core.Action) ([]Line, error) {
+	color := s.bot.Color
+	moves := generateMoves(board, color)
+	s.order(board, moves, 0, previousBest)
+
+	var found []Line
+	beta := float32(math.Inf(1))
+	for i := range moves {
+		child, err := moves[i].play(board)
+		if err != nil {
+			return nil, err
+		}
+		alpha := float32(math.Inf(-1))
+		if len(found) == lines {
+			alpha = found[lines-1].Score
+		}
+		score, err := s.negamax(ctx, child, color.Opponent(), depth-1, 1, -beta, -alpha)
+		if err != nil {
+			return found, err
+		}
+		score = -score
+		if len(found) == lines && score <= alpha {
+			continue
+		}
+		found = append(found, Line{Action: moves[i].Action, Score: score})
+		slices.SortStableFunc(found, func(a, b Line) int { return cmp.Compare(b.Score, a.Score) })
+		found = found[:min(len(found), lines)]
+	}
+	return found, nil
+}
+
+// principalVariation returns the action followed by the best replies stored in the transposition table, up to
+// depth plies in all. It stops early if the table has nothing for a position, or if what it has isn't a valid move
+// there, which can happen when two positions share a table slot.
+func (ab *AlphaBetaBot) principalVariation(board *core.Board, toMove core.Color, action core.Action,
+	depth int) []core.MoveRecord {
+	var pv []core.MoveRecord
+	color := toMove
+	for len(pv) < max(depth, 1) {
+		record, next, err := playRecorded(board, action)
+		if err != nil {
+			break
+		}
+		pv = append(pv, record)
+		board, color = next, color.Opponent()
+		if ab.table == nil {
+			break
+		}
+		entry, ok := ab.table.probe(board.Hash(color))
+		if !ok || entry.best.Piece == nil || entry.best.Piece.Color != color {
+			break
+		}
+		action = entry.best
+	}
+	return pv
+}
+
+// playRecorded plays the action on the board, returning the record of the move along with the new board.
+func playRecorded(board *core.Board, action core.Action) (core.MoveRecord, *core.Board, error) {
+	from, err := board.PieceLocation(action.Piece)
+	if err != nil {
+		return core.MoveRecord{}, nil, err
+	}
+	next, err := board.MovePiece(action.Piece, from, action.Move)
+	if err != nil {
+		return core.MoveRecord{}, nil, err
+	}
+	record := core.MoveRecord{
+		Color: action.Piece.Color,
+		Piece: action.Piece.Name,
+		From:  from,
+		To:    from.Add(action.Move),
+	}
+	if captured := board.GetPieceAt(record.To); captured != nil {
+		record.Captured = captured.Name
+	}
+	return record, next, nil
+}
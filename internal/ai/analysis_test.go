@@ -0,0 +1,133 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlphaBetaBot_Analyze(t *testing.T) {
+	board := midgamePosition(t)
+	scorer, err := NewBoardScorer("strategist")
+	require.NoError(t, err)
+
+	bot := NewAlphaBetaBot(core.White, scorer, 3)
+	analysis, err := bot.Analyze(context.Background(), board, core.White, 0, 3)
+	require.NoError(t, err)
+	assert.Equal(t, core.White, analysis.ToMove)
+	assert.Equal(t, 3, analysis.Depth)
+	assert.Positive(t, analysis.Nodes)
+	require.Len(t, analysis.Lines, 3)
+	for i, line := range analysis.Lines {
+		if i > 0 {
+			assert.LessOrEqual(t, line.Score, analysis.Lines[i-1].Score, "lines should be best first")
+		}
+		require.NotEmpty(t, line.PV)
+		assert.LessOrEqual(t, len(line.PV), analysis.Depth)
+		assert.Equal(t, line.Action.Piece.Name, line.PV[0].Piece, "the variation should start with the action")
+		assert.Equal(t, line.Action.Move, line.PV[0].Move())
+		color := core.White
+		for _, m := range line.PV {
+			assert.Equal(t, color, m.Color, "the variation should alternate colors")
+			color = color.Opponent()
+		}
+	}
+	assert.Equal(t, analysis.Lines[0].Score, analysis.Eval(), "White's eval is the best line's score")
+
+	// The best line is the move the bot would play
+	action, err := NewAlphaBetaBot(core.White, scorer, 3).NextMove(board)
+	require.NoError(t, err)
+	assert.Equal(t, *action, analysis.Best().Action)
+}
+
+func TestAlphaBetaBot_AnalyzeScoresAreExact(t *testing.T) {
+	board := midgamePosition(t)
+	scorer, err := NewBoardScorer("strategist")
+	require.NoError(t, err)
+
+	bot := NewAlphaBetaBot(core.Black, scorer, 2)
+	bot.TableSize = 0
+	analysis, err := bot.Analyze(context.Background(), board, core.Black, 0, 4)
+	require.NoError(t, err)
+	require.Len(t, analysis.Lines, 4)
+
+	// Each line's score is what a full-window search of its action finds
+	s := &searcher{bot: bot, orderer: newMoveOrderer(scorer, board)}
+	for _, line := range analysis.Lines {
+		child, err := board.ApplyAction(&line.Action)
+		require.NoError(t, err)
+		score, err := s.negamax(context.Background(), child, core.White, 1, 1,
+			float32(math.Inf(-1)), float32(math.Inf(1)))
+		require.NoError(t, err)
+		assert.Equal(t, -score, line.Score)
+		assert.Len(t, line.PV, 1, "without a table there's no variation to read back")
+	}
+	assert.Equal(t, -analysis.Lines[0].Score, analysis.Eval(), "White's eval is the negative of Black's")
+}
+
+func TestAlphaBetaBot_AnalyzeFindsFreeCapture(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	padwar := &core.Piece{Name: "padwar", Color: core.Black}
+	board, err := game.Board.PlacePiece(padwar, core.Position{7, 0})
+	require.NoError(t, err)
+
+	analysis, err := newTestAlphaBetaBot(t, core.Black, 2).Analyze(context.Background(), board, core.White, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, analysis.Lines, 1)
+	best := analysis.Best()
+	assert.Equal(t, core.MoveRecord{
+		Color: core.White, Piece: "warrior", From: core.Position{9, 0}, To: core.Position{7, 0}, Captured: "padwar",
+	}, best.PV[0])
+	assert.Zero(t, analysis.Eval(), "taking the extra padwar evens the material")
+	assert.Contains(t, analysis.String(), "white warrior [9,0]x[7,0]")
+}
+
+func TestAlphaBetaBot_AnalyzeCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := newTestAlphaBetaBot(t, core.White, 2).Analyze(ctx, midgamePosition(t), core.White, 0, 1)
+	assert.Error(t, err)
+}
+
+func TestFormatScore(t *testing.T) {
+	tests := []struct {
+		score    float32
+		expected string
+	}{
+		{score: 0, expected: "+0.00"},
+		{score: 1.5, expected: "+1.50"},
+		{score: -0.25, expected: "-0.25"},
+		{score: winScore - 3, expected: "win"},
+		{score: winScore + 2, expected: "win"},
+		{score: -winScore - 2, expected: "loss"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, FormatScore(tt.score))
+	}
+}
+
+func TestPersonality_Analyze(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+
+	cfg := &AIPlayerConfig{Name: "analyst", Strategy: AlphaBetaStrategy, Search: SearchLimits{MaxDepth: 2}}
+	personality, err := NewStrategyWithConfig(cfg, core.Black, 1)
+	require.NoError(t, err)
+	analysis, err := personality.Analyze(context.Background(), game.Board, core.White, 0, 2)
+	require.NoError(t, err)
+	assert.Len(t, analysis.Lines, 2)
+	assert.Equal(t, core.White, analysis.Best().Action.Piece.Color, "analyzes for the side to move")
+
+	gambler, err := NewStrategy("gambler", core.White)
+	require.NoError(t, err)
+	_, err = gambler.Analyze(context.Background(), game.Board, core.White, 0, 1)
+	assert.Error(t, err, "MCTS personalities can't analyze")
+}
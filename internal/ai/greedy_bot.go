@@ -0,0 +1,107 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"cragspider-go/internal/core"
+	"cragspider-go/pkg/random"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// GreedyBot is an easy AI agent that doesn't look ahead. It grabs the most valuable piece it can capture, and
+// otherwise makes a random move that doesn't put the moved piece where the opponent can capture it right away.
+type GreedyBot struct {
+	Color  core.Color
+	scorer *BoardScorer
+	rng    *rand.Rand // Nil means the shared generator
+}
+
+var _ core.AgentStrategy = (*GreedyBot)(nil)
+
+// NewGreedyBot returns a new GreedyBot for the specified color that values captures with the scorer's piece values.
+func NewGreedyBot(color core.Color, scorer *BoardScorer) *GreedyBot {
+	return &GreedyBot{Color: color, scorer: scorer}
+}
+
+// greedyMove is a move the bot could make, with what it would capture and whether the piece would be safe after.
+type greedyMove struct {
+	action  core.Action
+	capture *core.Piece
+	safe    bool
+}
+
+// NextMove returns the most valuable capture, preferring one that leaves the capturing piece safe, or else a random
+// safe move, or else any random move. Returns an error if there are no valid moves.
+func (gb *GreedyBot) NextMove(board *core.Board) (*core.Action, error) {
+	var moves []greedyMove
+	for row := range board.Rows {
+		for col := range board.Columns {
+			from := core.Position{row, col}
+			piece := board.GetPieceAt(from)
+			if piece == nil || piece.Color != gb.Color {
+				continue
+			}
+			for _, to := range piece.ValidNextPositions(from, board) {
+				action := core.Action{Piece: piece, Move: core.Move{to[0] - from[0], to[1] - from[1]}}
+				child, err := board.MovePiece(piece, from, action.Move)
+				if err != nil {
+					return nil, err
+				}
+				moves = append(moves, greedyMove{
+					action:  action,
+					capture: board.GetPieceAt(to),
+					safe:    !attacked(child, to, gb.Color.Opponent()),
+				})
+			}
+		}
+	}
+	if len(moves) == 0 {
+		return nil, fmt.Errorf("no valid moves available for color %s", gb.Color)
+	}
+
+	// The most valuable captures, and of those the safe ones if there are any
+	best := float32(-1)
+	var captures []greedyMove
+	for _, m := range moves {
+		if m.capture == nil {
+			continue
+		}
+		value := gb.scorer.pieceValue(m.capture.Name)
+		if value > best {
+			best, captures = value, nil
+		}
+		if value == best {
+			captures = append(captures, m)
+		}
+	}
+	if len(captures) > 0 {
+		return gb.choose(captures), nil
+	}
+	return gb.choose(moves), nil
+}
+
+// choose returns a random one of the safe moves, or of all of them if none is safe.
+func (gb *GreedyBot) choose(moves []greedyMove) *core.Action {
+	safe := slices.DeleteFunc(slices.Clone(moves), func(m greedyMove) bool { return !m.safe })
+	if len(safe) > 0 {
+		moves = safe
+	}
+	chosen := random.ChoiceFrom(gb.rng, moves)
+	return &chosen.action
+}
+
+// attacked returns true if any of color's pieces can capture on the position.
+func attacked(board *core.Board, pos core.Position, color core.Color) bool {
+	for _, piece := range board.GetPiecesByColor(color) {
+		from, err := board.PieceLocation(piece)
+		if err != nil {
+			continue
+		}
+		if slices.Contains(piece.ValidNextPositions(from, board), pos) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"testing"
+
+	"cragspider-go/internal/core"
+	"cragspider-go/pkg/random"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smallBoard returns a 5x5 board with the standard pieces placed as given.
+func smallBoard(t *testing.T, white, black []core.BoardPosition) *core.Board {
+	t.Helper()
+	standard, err := core.GetConfig()
+	require.NoError(t, err)
+	cfg := &core.GameConfig{
+		Pieces: standard.Pieces,
+		Board:  core.BoardConfig{Rows: 5, Columns: 5, White: white, Black: black},
+	}
+	game, err := core.NewGameWithConfig(cfg)
+	require.NoError(t, err)
+	return game.Board
+}
+
+// newTestGreedyBot returns a GreedyBot using the grabber's piece values and a seeded generator.
+func newTestGreedyBot(t *testing.T, color core.Color, seed int64) *GreedyBot {
+	t.Helper()
+	scorer, err := NewBoardScorer("grabber")
+	require.NoError(t, err)
+	bot := NewGreedyBot(color, scorer)
+	bot.rng = random.New(seed)
+	return bot
+}
+
+func TestGreedyBot_TakesMostValuableCapture(t *testing.T) {
+	board := smallBoard(t,
+		[]core.BoardPosition{{Name: "warrior", Position: core.Position{2, 2}}},
+		[]core.BoardPosition{
+			{Name: "warrior", Position: core.Position{0, 2}},
+			{Name: "padwar", Position: core.Position{2, 4}},
+		})
+	for seed := range int64(10) {
+		action, err := newTestGreedyBot(t, core.White, seed).NextMove(board)
+		require.NoError(t, err)
+		assert.Equal(t, core.Move{0, 2}, action.Move, "should capture the padwar, which is worth more")
+	}
+}
+
+func TestGreedyBot_PrefersSafeCapture(t *testing.T) {
+	// Each white warrior can take a black warrior, but the padwar guards the one at 0,2
+	board := smallBoard(t,
+		[]core.BoardPosition{
+			{Name: "warrior", Position: core.Position{0, 0}},
+			{Name: "warrior", Position: core.Position{4, 4}},
+		},
+		[]core.BoardPosition{
+			{Name: "warrior", Position: core.Position{0, 2}},
+			{Name: "warrior", Position: core.Position{4, 2}},
+			{Name: "padwar", Position: core.Position{1, 1}},
+		})
+	for seed := range int64(10) {
+		action, err := newTestGreedyBot(t, core.White, seed).NextMove(board)
+		require.NoError(t, err)
+		assert.Equal(t, board.GetPieceAt(core.Position{4, 4}), action.Piece, "should capture with the safe warrior")
+		assert.Equal(t, core.Move{0, -2}, action.Move)
+	}
+}
+
+func TestGreedyBot_AvoidsMovingIntoCapture(t *testing.T) {
+	board := smallBoard(t,
+		[]core.BoardPosition{{Name: "warrior", Position: core.Position{4, 0}}},
+		[]core.BoardPosition{{Name: "warrior", Position: core.Position{0, 2}}})
+	for seed := range int64(20) {
+		action, err := newTestGreedyBot(t, core.White, seed).NextMove(board)
+		require.NoError(t, err)
+		child, err := board.ApplyAction(action)
+		require.NoError(t, err)
+		to := core.Position{4, 0}.Add(action.Move)
+		assert.False(t, attacked(child, to, core.Black), "should not move to %s, where it can be captured", to)
+	}
+}
+
+func TestGreedyBot_NoMoves(t *testing.T) {
+	board := smallBoard(t, []core.BoardPosition{{Name: "warrior", Position: core.Position{0, 0}}}, nil)
+	_, err := newTestGreedyBot(t, core.Black, 1).NextMove(board)
+	assert.Error(t, err)
+}
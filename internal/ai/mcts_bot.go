@@ -0,0 +1,211 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"context"
+	"cragspider-go/internal/core"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// defaultMCTSIterations is how many playouts an MCTSBot runs when neither an iteration count nor a time
+	// budget bounds its search.
+	defaultMCTSIterations = 1000
+	// explorationConstant balances trying new moves against playing out the ones that have done well so far.
+	explorationConstant = math.Sqrt2
+)
+
+// MCTSBot is a search bot that uses Monte Carlo tree search. It grows a tree of moves by repeatedly picking a
+// promising line, playing random moves from there for a while, and scoring where it ends up with a BoardScorer.
+// Whatever has been played out so far can be used when it runs out of time.
+type MCTSBot struct {
+	Color        core.Color
+	Iterations   int       // Number of playouts; zero means until the budget runs out
+	PlayoutDepth int       // Random moves per playout before the board is scored
+	Temperature  float32   // Zero always plays the most visited move; higher spreads the choice across good moves
+	Evaluator    Evaluator // Scores the boards at the end of playouts; nil means the scorer
+	scorer       *BoardScorer
+	rng          *rand.Rand // Nil means the shared generator
+}
+
+var (
+	_ core.AgentStrategy      = (*MCTSBot)(nil)
+	_ core.TimedAgentStrategy = (*MCTSBot)(nil)
+)
+
+// NewMCTSBot returns a new MCTSBot for the specified color that scores playouts with the given scorer and draws
+// its random moves from rng. Nil means the shared generator.
+func NewMCTSBot(color core.Color, scorer *BoardScorer, iterations, playoutDepth int, rng *rand.Rand) *MCTSBot {
+	return &MCTSBot{
+		Color:        color,
+		Iterations:   iterations,
+		PlayoutDepth: playoutDepth,
+		scorer:       scorer,
+		rng:          rng,
+	}
+}
+
+// intn returns a random int in [0, n) from the bot's generator, or the shared one if it has none.
+func (mb *MCTSBot) intn(n int) int {
+	if mb.rng == nil {
+		return rand.Intn(n) //nolint:gosec
+	}
+	return mb.rng.Intn(n)
+}
+
+// mctsNode is a position in the search tree, reached by playing action from its parent's position.
+type mctsNode struct {
+	board    *core.Board
+	toMove   core.Color
+	action   *core.Action
+	parent   *mctsNode
+	children []*mctsNode
+	untried  []core.Action
+	visits   int
+	reward   float64 // Total reward for the player who made action, from 0 (lost) to 1 (won) per visit
+}
+
+// newMCTSNode returns a new node for the board with the given color to move.
+func newMCTSNode(board *core.Board, toMove core.Color, action *core.Action, parent *mctsNode) *mctsNode {
+	return &mctsNode{
+		board:   board,
+		toMove:  toMove,
+		action:  action,
+		parent:  parent,
+		untried: board.ValidActions(toMove),
+	}
+}
+
+// NextMove returns the move the bot likes best after running all of its playouts.
+func (mb *MCTSBot) NextMove(board *core.Board) (*core.Action, error) {
+	return mb.NextMoveContext(context.Background(), board, 0)
+}
+
+// NextMoveContext returns the move the bot likes best after running its playouts, or after the budget runs out or
+// the context is cancelled, whichever comes first. A budget of zero means no time limit.
+func (mb *MCTSBot) NextMoveContext(ctx context.Context, board *core.Board, budget time.Duration) (*core.Action, error) {
+	if budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+	iterations := mb.Iterations
+	if iterations <= 0 && budget <= 0 {
+		iterations = defaultMCTSIterations
+	}
+
+	root := newMCTSNode(board, mb.Color, nil, nil)
+	if len(root.untried) == 0 {
+		return nil, fmt.Errorf("no valid moves available for color %s", mb.Color)
+	}
+	for i := 0; iterations <= 0 || i < iterations; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := mb.iterate(root); err != nil {
+			return nil, err
+		}
+	}
+	if len(root.children) == 0 {
+		return nil, fmt.Errorf("%s MCTS bot stopped before trying any moves: %w", mb.Color, ctx.Err())
+	}
+	return mb.choose(root), nil
+}
+
+// iterate runs one round of the search: select a promising node, expand it by one move, play out from there and
+// pass the result back up the tree.
+func (mb *MCTSBot) iterate(root *mctsNode) error {
+	node := root
+	for len(node.untried) == 0 && len(node.children) > 0 {
+		node = node.bestChild()
+	}
+
+	if len(node.untried) > 0 {
+		i := mb.intn(len(node.untried))
+		action := node.untried[i]
+		node.untried = append(node.untried[:i], node.untried[i+1:]...)
+		child, err := node.board.ApplyAction(&action)
+		if err != nil {
+			return err
+		}
+		node.children = append(node.children, newMCTSNode(child, node.toMove.Opponent(), &action, node))
+		node = node.children[len(node.children)-1]
+	}
+
+	whiteReward, err := mb.playout(node.board, node.toMove)
+	if err != nil {
+		return err
+	}
+	for ; node != nil; node = node.parent {
+		node.visits++
+		// The reward belongs to whoever made the move into this node, which is the opponent of the side to move
+		if node.toMove == core.Black {
+			node.reward += whiteReward
+		} else {
+			node.reward += 1 - whiteReward
+		}
+	}
+	return nil
+}
+
+// bestChild returns the child with the highest upper confidence bound, favoring moves that have done well but
+// giving moves that haven't been tried much a chance to prove themselves.
+func (n *mctsNode) bestChild() *mctsNode {
+	var best *mctsNode
+	bestBound := math.Inf(-1)
+	logVisits := math.Log(float64(n.visits))
+	for _, child := range n.children {
+		bound := child.reward/float64(child.visits) + explorationConstant*math.Sqrt(logVisits/float64(child.visits))
+		if bound > bestBound {
+			best, bestBound = child, bound
+		}
+	}
+	return best
+}
+
+// playout makes random moves from the board until PlayoutDepth moves have been made or the game is over, then
+// scores the board. The result is White's reward, from 0 (Black won or is winning) to 1 (White won or is winning).
+func (mb *MCTSBot) playout(board *core.Board, toMove core.Color) (float64, error) {
+	for range mb.PlayoutDepth {
+		actions := board.ValidActions(toMove)
+		if len(actions) == 0 {
+			// No moves on your turn loses the game, whether or not there are playout moves left
+			return lo.Ternary(toMove == core.White, 0.0, 1.0), nil
+		}
+		next, err := board.ApplyAction(&actions[mb.intn(len(actions))])
+		if err != nil {
+			return 0, err
+		}
+		board = next
+		toMove = toMove.Opponent()
+	}
+	if !board.HasValidActions(toMove) {
+		return lo.Ternary(toMove == core.White, 0.0, 1.0), nil
+	}
+	var evaluator Evaluator = mb.scorer
+	if mb.Evaluator != nil {
+		evaluator = mb.Evaluator
+	}
+	score, err := evaluator.Score(board)
+	if err != nil {
+		return 0, err
+	}
+	return 1 / (1 + math.Exp(-float64(score))), nil
+}
+
+// choose returns the root move to play. With no temperature that's the most visited move; otherwise moves are
+// picked in proportion to their visits, sharpened or flattened by the temperature.
+func (mb *MCTSBot) choose(root *mctsNode) *core.Action {
+	scores := make([]float32, len(root.children))
+	for i, child := range root.children {
+		// Log visits turn proportional choice into the exponential choice pickWithTemperature makes
+		scores[i] = float32(math.Log(float64(child.visits)))
+	}
+	return root.children[pickWithTemperature(mb.rng, scores, mb.Temperature)].action
+}
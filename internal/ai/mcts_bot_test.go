@@ -0,0 +1,89 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cragspider-go/internal/core"
+	"cragspider-go/pkg/random"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMCTSBot returns an MCTSBot using the doofus scorer and a fixed seed.
+func newTestMCTSBot(t *testing.T, color core.Color, iterations int) *MCTSBot {
+	scorer, err := NewBoardScorer("doofus")
+	require.NoError(t, err, "should create scorer")
+	return NewMCTSBot(color, scorer, iterations, 4, random.New(1))
+}
+
+func TestMCTSBotNextMove_ReturnsValidMove(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	bot := newTestMCTSBot(t, core.Black, 200)
+	action, err := bot.NextMove(game.Board)
+	require.NoError(t, err, "should return no error")
+	require.NotNil(t, action, "should return an action")
+	assert.Equal(t, core.Black, action.Piece.Color, "piece should be black")
+
+	_, err = game.Board.ApplyAction(action)
+	assert.NoError(t, err, "action should be valid on the board")
+}
+
+func TestMCTSBotNextMove_NilRNG(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+	scorer, err := NewBoardScorer("doofus")
+	require.NoError(t, err, "should create scorer")
+
+	bot := NewMCTSBot(core.White, scorer, 50, 4, nil)
+	bot.Temperature = 1
+	action, err := bot.NextMove(game.Board)
+	require.NoError(t, err, "should use the shared generator")
+	require.NotNil(t, action, "should return an action")
+	assert.Equal(t, core.White, action.Piece.Color, "piece should be white")
+}
+
+func TestMCTSBotNextMove_TakesFreeCapture(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	// Put a black padwar two squares above the white warrior in the corner, where nothing can recapture
+	board, err := game.Board.PlacePiece(&core.Piece{Name: "padwar", Color: core.Black}, core.Position{7, 0})
+	require.NoError(t, err, "should place piece")
+	warrior := board.GetPieceAt(core.Position{9, 0})
+
+	bot := newTestMCTSBot(t, core.White, 3000)
+	action, err := bot.NextMove(board)
+	require.NoError(t, err, "should return no error")
+	assert.Equal(t, warrior, action.Piece, "should move the warrior")
+	assert.Equal(t, core.Move{-2, 0}, action.Move, "should capture the padwar")
+}
+
+func TestMCTSBotNextMoveContext_StopsWhenBudgetRunsOut(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	// With no iteration limit, only the budget stops the search
+	bot := newTestMCTSBot(t, core.White, 0)
+	start := time.Now()
+	action, err := bot.NextMoveContext(context.Background(), game.Board, 50*time.Millisecond)
+	require.NoError(t, err, "should return the best move found so far")
+	require.NotNil(t, action, "should return an action")
+	assert.Less(t, time.Since(start), 2*time.Second, "should stop soon after the budget runs out")
+}
+
+func TestMCTSBotNextMove_NoPieces(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	// The game's board has no green pieces
+	bot := newTestMCTSBot(t, core.Color("green"), 10)
+	_, err = bot.NextMove(game.Board)
+	assert.Error(t, err)
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"cmp"
+	"cragspider-go/internal/core"
+	"slices"
+)
+
+// Ordering scores. Each kind of move is tried before every move of the kinds below it.
+const (
+	tableMoveScore   float32 = 4e6 // The best move the transposition table or the last iteration knows of
+	captureScore     float32 = 2e6 // Captures, ordered among themselves by MVV/LVA
+	firstKillerScore float32 = 1e6 // The most recent quiet move to cause a cutoff at the same ply
+	killerScore      float32 = 9e5 // The one before that
+	maxHistoryScore  float32 = 8e5 // Quiet moves are ordered by history, which is capped below the killers
+)
+
+// searchMove is an action along with where its piece starts, so that it can be ordered and played without looking
+// for the piece.
+type searchMove struct {
+	core.Action
+	from  core.Position
+	score float32
+}
+
+// generateMoves returns every move the color can make on the board, in the same order as Board.ValidActions.
+func generateMoves(board *core.Board, color core.Color) []searchMove {
+	var moves []searchMove
+	forEachPiece(board, func(piece *core.Piece, pos core.Position) {
+		if piece.Color != color {
+			return
+		}
+		for _, end := range piece.ValidNextPositions(pos, board) {
+			moves = append(moves, searchMove{
+				Action: core.Action{Piece: piece, Move: core.Move{end[0] - pos[0], end[1] - pos[1]}},
+				from:   pos,
+			})
+		}
+	})
+	return moves
+}
+
+// play returns the board after the move.
+func (m *searchMove) play(board *core.Board) (*core.Board, error) {
+	return board.MovePiece(m.Piece, m.from, m.Move)
+}
+
+// sameAction returns whether two actions move the same piece the same way.
+func sameAction(a, b core.Action) bool {
+	return a.Piece == b.Piece && a.Move == b.Move
+}
+
+// moveOrderer puts moves in the order a search should try them, so that the best move is usually searched first
+// and the rest are cut off quickly. It learns from the cutoffs the search reports: quiet moves that caused one are
+// remembered as killers for their ply, and credited in a history table shared by the whole search.
+type moveOrderer struct {
+	scorer  *BoardScorer
+	squares int
+	killers [][2]core.Action
+	history []float32 // Indexed by color, from square and to square
+}
+
+// newMoveOrderer returns an orderer for searches on boards like the given one, valuing pieces with the scorer.
+func newMoveOrderer(scorer *BoardScorer, board *core.Board) *moveOrderer {
+	squares := board.Rows * board.Columns
+	return &moveOrderer{scorer: scorer, squares: squares, history: make([]float32, 2*squares*squares)}
+}
+
+// order sorts moves into the order they should be searched at the given ply, starting with first if it's among
+// them. Captures come next, taking the most valuable victim with the least valuable attacker first, then killers,
+// then the quiet moves with the best history.
+func (o *moveOrderer) order(board *core.Board, moves []searchMove, ply int, first *core.Action) {
+	for i := range moves {
+		m := &moves[i]
+		target := board.GetPieceAt(m.from.Add(m.Move))
+		switch {
+		case first != nil && sameAction(m.Action, *first):
+			m.score = tableMoveScore
+		case target != nil:
+			m.score = captureScore + 100*o.scorer.pieceValue(target.Name) - o.scorer.pieceValue(m.Piece.Name)
+		case ply < len(o.killers) && sameAction(m.Action, o.killers[ply][0]):
+			m.score = firstKillerScore
+		case ply < len(o.killers) && sameAction(m.Action, o.killers[ply][1]):
+			m.score = killerScore
+		default:
+			m.score = min(o.history[o.historyIndex(board, m)], maxHistoryScore)
+		}
+	}
+	slices.SortStableFunc(moves, func(a, b searchMove) int {
+		return -cmp.Compare(a.score, b.score)
+	})
+}
+
+// cutoff records that the move caused a cutoff at the given ply with depth plies left to search. Captures are
+// already ordered well, so only quiet moves are remembered.
+func (o *moveOrderer) cutoff(board *core.Board, m *searchMove, ply, depth int) {
+	if board.IsOccupied(m.from.Add(m.Move)) {
+		return
+	}
+	for len(o.killers) <= ply {
+		o.killers = append(o.killers, [2]core.Action{})
+	}
+	if !sameAction(o.killers[ply][0], m.Action) {
+		o.killers[ply][1] = o.killers[ply][0]
+		o.killers[ply][0] = m.Action
+	}
+	// Deeper cutoffs prune more, so they count for more
+	o.history[o.historyIndex(board, m)] += float32(depth * depth)
+}
+
+// historyIndex returns the move's slot in the history table.
+func (o *moveOrderer) historyIndex(board *core.Board, m *searchMove) int {
+	color := 0
+	if m.Piece.Color == core.Black {
+		color = 1
+	}
+	to := m.from.Add(m.Move)
+	from := m.from[0]*board.Columns + m.from[1]
+	return (color*o.squares+from)*o.squares + to[0]*board.Columns + to[1]
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"testing"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateMoves(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+
+	moves := generateMoves(game.Board, core.White)
+	actions := game.Board.ValidActions(core.White)
+	require.Len(t, moves, len(actions))
+	for i, m := range moves {
+		assert.Equal(t, actions[i], m.Action, "moves should be in the same order as ValidActions")
+		assert.Equal(t, m.Piece, game.Board.GetPieceAt(m.from))
+	}
+}
+
+func TestMoveOrderer_Order(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	scorer, err := NewBoardScorer("strategist")
+	require.NoError(t, err)
+
+	// A black padwar and warrior where white's corner warrior and padwar can both reach them
+	board := game.Board
+	padwar := &core.Piece{Name: "padwar", Color: core.Black}
+	warrior := &core.Piece{Name: "warrior", Color: core.Black}
+	board, err = board.PlacePiece(padwar, core.Position{7, 0})
+	require.NoError(t, err)
+	board, err = board.PlacePiece(warrior, core.Position{7, 1})
+	require.NoError(t, err)
+
+	orderer := newMoveOrderer(scorer, board)
+	moves := generateMoves(board, core.White)
+	orderer.order(board, moves, 0, nil)
+
+	var captures []*core.Piece
+	for _, m := range moves {
+		target := board.GetPieceAt(m.from.Add(m.Move))
+		if target == nil {
+			break
+		}
+		captures = append(captures, target)
+	}
+	require.NotEmpty(t, captures, "captures should come first")
+	assert.Equal(t, padwar, captures[0], "the most valuable victim should come first")
+	for i := 1; i < len(captures); i++ {
+		assert.GreaterOrEqual(t, scorer.pieceValue(captures[i-1].Name), scorer.pieceValue(captures[i].Name))
+	}
+
+	// The table's move beats every capture
+	quiet := moves[len(moves)-1].Action
+	orderer.order(board, moves, 0, &quiet)
+	assert.Equal(t, quiet, moves[0].Action)
+}
+
+func TestMoveOrderer_Cutoff(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	scorer, err := NewBoardScorer("strategist")
+	require.NoError(t, err)
+	board := game.Board
+
+	orderer := newMoveOrderer(scorer, board)
+	moves := generateMoves(board, core.White)
+	killer, historic := moves[len(moves)-1], moves[len(moves)-2]
+	orderer.cutoff(board, &killer, 2, 3)
+
+	orderer.order(board, moves, 2, nil)
+	assert.Equal(t, killer.Action, moves[0].Action, "a killer comes first at its own ply")
+
+	orderer = newMoveOrderer(scorer, board)
+	orderer.cutoff(board, &historic, 5, 4)
+	orderer.order(board, moves, 2, nil)
+	assert.Equal(t, historic.Action, moves[0].Action, "history counts at every ply")
+	assert.Equal(t, float32(16), moves[0].score, "history grows with the square of the depth")
+}
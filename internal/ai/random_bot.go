@@ -12,6 +12,7 @@ import (
 // RandomBot is an AI agent that makes random valid moves.
 type RandomBot struct {
 	Color core.Color
+	rng   *rand.Rand // Nil means the shared generator
 }
 
 // NewRandomBot returns a new RandomBot structure for the specified color.
@@ -30,9 +31,14 @@ func (rb *RandomBot) NextMove(board *core.Board) (*core.Action, error) {
 	}
 
 	// Shuffle the pieces to randomize which one we try first
-	rand.Shuffle(len(botPieces), func(i, j int) {
+	swap := func(i, j int) {
 		botPieces[i], botPieces[j] = botPieces[j], botPieces[i]
-	})
+	}
+	if rb.rng != nil {
+		rb.rng.Shuffle(len(botPieces), swap)
+	} else {
+		rand.Shuffle(len(botPieces), swap)
+	}
 
 	// As soon as there's a piece with at least one valid move, return one of them randomly
 	for _, piece := range botPieces {
@@ -46,7 +52,7 @@ func (rb *RandomBot) NextMove(board *core.Board) (*core.Action, error) {
 			continue
 		}
 
-		selectedPos := random.Choice(validPositions)
+		selectedPos := random.ChoiceFrom(rb.rng, validPositions)
 		move := core.Move{
 			selectedPos[0] - pos[0],
 			selectedPos[1] - pos[1],
@@ -7,11 +7,55 @@ import (
 	"fmt"
 )
 
+// Names of the evaluation terms that make up a board's score. Each is weighted by the AI player's configuration.
+const (
+	// TermMaterial is the value of the pieces on the board.
+	TermMaterial = "material"
+	// TermMobility is the number of moves the pieces can make.
+	TermMobility = "mobility"
+	// TermHanging is the value of the opponent's pieces that are attacked and undefended.
+	TermHanging = "hanging"
+	// TermCenter is how close the pieces are to the center of the board.
+	TermCenter = "center"
+	// TermObjectives is how close the pieces are to the board's objective squares.
+	TermObjectives = "objectives"
+	// TermSquares is the bonus from each piece's square table.
+	TermSquares = "squares"
+)
+
+// Terms lists every evaluation term in the order they appear in a breakdown.
+var Terms = []string{TermMaterial, TermMobility, TermHanging, TermCenter, TermObjectives, TermSquares}
+
+// TermScore is one evaluation term's part of a board's score.
+type TermScore struct {
+	Term   string
+	Value  float32 // The unweighted value, White's minus Black's
+	Weight float32
+}
+
+// Contribution returns how much the term adds to the board's score.
+func (ts TermScore) Contribution() float32 {
+	return ts.Value * ts.Weight
+}
+
+// String returns a nicely formatted string representation of the term score.
+func (ts TermScore) String() string {
+	return fmt.Sprintf("%s: %.3f x %.3f = %.3f", ts.Term, ts.Value, ts.Weight, ts.Contribution())
+}
+
+// Evaluator scores a board the way a BoardScorer does: positive numbers mean that White is winning, and negative
+// numbers that Black is. Search bots score the boards at the end of their search with one.
+type Evaluator interface {
+	Score(board *core.Board) (float32, error)
+}
+
 // BoardScorer is a way to evaluate who is winning the game just by looking at the current board state
 type BoardScorer struct {
 	config *AIPlayerConfig
 }
 
+var _ Evaluator = (*BoardScorer)(nil)
+
 // NewBoardScorer creates and returns a new BoardScorer structure, given the name of the AI player from the
 // AI configuration file.
 func NewBoardScorer(playerName string) (*BoardScorer, error) {
@@ -23,22 +67,209 @@ func NewBoardScorer(playerName string) (*BoardScorer, error) {
 	if err != nil {
 		return nil, fmt.Errorf("cannot get player config: %w", err)
 	}
-	return &BoardScorer{config: playerConfig}, nil
+	return NewBoardScorerWithConfig(playerConfig), nil
+}
+
+// NewBoardScorerWithConfig returns a new BoardScorer that uses the given AI player configuration.
+func NewBoardScorerWithConfig(playerConfig *AIPlayerConfig) *BoardScorer {
+	return &BoardScorer{config: playerConfig}
 }
 
 // Score takes a pointer to a Board with pieces on it and returns a float representing which player
 // is in better shape. Positive numbers mean that White is winning; Negative numbers mean that Black
-// is winning.
+// is winning. The score is the sum of each evaluation term's value times its weight.
 func (bs *BoardScorer) Score(board *core.Board) (float32, error) {
-	// For now just add up the various pieces on the board valuing them appropriately
 	var score float32
+	for _, term := range Terms {
+		weight := bs.config.Weight(term)
+		if weight == 0 {
+			// No need to work out a term that doesn't count
+			continue
+		}
+		value, err := bs.termValue(term, board)
+		if err != nil {
+			return 0, err
+		}
+		score += value * weight
+	}
+	return score, nil
+}
 
+// Breakdown returns every evaluation term's part of the board's score, so that it's possible to see why the
+// scorer likes a position. The contributions add up to the board's score.
+func (bs *BoardScorer) Breakdown(board *core.Board) ([]TermScore, error) {
+	breakdown := make([]TermScore, 0, len(Terms))
+	for _, term := range Terms {
+		value, err := bs.termValue(term, board)
+		if err != nil {
+			return nil, err
+		}
+		breakdown = append(breakdown, TermScore{Term: term, Value: value, Weight: bs.config.Weight(term)})
+	}
+	return breakdown, nil
+}
+
+// termValue returns the unweighted value of the named term for the board.
+func (bs *BoardScorer) termValue(term string, board *core.Board) (float32, error) {
+	switch term {
+	case TermMaterial:
+		return bs.material(board), nil
+	case TermMobility:
+		return mobility(board), nil
+	case TermHanging:
+		return bs.hanging(board), nil
+	case TermCenter:
+		return center(board), nil
+	case TermObjectives:
+		return objectives(board), nil
+	case TermSquares:
+		return bs.squares(board)
+	default:
+		return 0, fmt.Errorf("unknown evaluation term '%s'", term)
+	}
+}
+
+// material returns the value of White's pieces minus the value of Black's.
+func (bs *BoardScorer) material(board *core.Board) float32 {
+	var score float32
 	for _, piece := range board.GetPiecesByColor(core.White) {
 		score += bs.config.Scoring[piece.Name]
 	}
 	for _, piece := range board.GetPiecesByColor(core.Black) {
 		score -= bs.config.Scoring[piece.Name]
 	}
+	return score
+}
+
+// pieceValue returns the value of the named piece.
+func (bs *BoardScorer) pieceValue(name string) float32 {
+	return bs.config.Scoring[name]
+}
 
+// mobility returns the number of moves White's pieces can make minus the number Black's can.
+func mobility(board *core.Board) float32 {
+	var score float32
+	forEachPiece(board, func(piece *core.Piece, pos core.Position) {
+		score += sign(piece.Color) * float32(len(piece.ValidNextPositions(pos, board)))
+	})
+	return score
+}
+
+// hanging returns the value of Black's hanging pieces minus the value of White's. A piece is hanging if an
+// opponent can capture it and none of its own pieces could capture back.
+func (bs *BoardScorer) hanging(board *core.Board) float32 {
+	attacked := map[core.Color]map[core.Position]bool{core.White: {}, core.Black: {}}
+	defended := map[core.Color]map[core.Position]bool{core.White: {}, core.Black: {}}
+	forEachPiece(board, func(piece *core.Piece, pos core.Position) {
+		for _, target := range piece.ThreatenedPositions(pos, board) {
+			occupant := board.GetPieceAt(target)
+			switch {
+			case occupant == nil:
+			case occupant.Color == piece.Color:
+				defended[piece.Color][target] = true
+			default:
+				attacked[occupant.Color][target] = true
+			}
+		}
+	})
+
+	var score float32
+	forEachPiece(board, func(piece *core.Piece, pos core.Position) {
+		if attacked[piece.Color][pos] && !defended[piece.Color][pos] {
+			score -= sign(piece.Color) * bs.config.Scoring[piece.Name]
+		}
+	})
+	return score
+}
+
+// center returns how close White's pieces are to the middle of the board minus how close Black's are. Each piece
+// counts from 1 in the very middle down to 0 in the corners.
+func center(board *core.Board) float32 {
+	midRow := float32(board.Rows-1) / 2
+	midCol := float32(board.Columns-1) / 2
+	var score float32
+	forEachPiece(board, func(piece *core.Piece, pos core.Position) {
+		distance := abs(float32(pos[0])-midRow) + abs(float32(pos[1])-midCol)
+		score += sign(piece.Color) * (1 - distance/(midRow+midCol))
+	})
+	return score
+}
+
+// objectives returns how close White's pieces are to the board's objective squares minus how close Black's are.
+// Each piece counts from 1 on an objective down to 0 at the far side of the board from the nearest one. Boards
+// without objectives score zero.
+func objectives(board *core.Board) float32 {
+	cfg := board.Config()
+	if cfg == nil || len(cfg.Board.Objectives) == 0 {
+		return 0
+	}
+	farthest := float32(board.Rows + board.Columns - 2)
+	var score float32
+	forEachPiece(board, func(piece *core.Piece, pos core.Position) {
+		nearest := farthest
+		for _, objective := range cfg.Board.Objectives {
+			distance := abs(float32(pos[0]-objective[0])) + abs(float32(pos[1]-objective[1]))
+			nearest = min(nearest, distance)
+		}
+		score += sign(piece.Color) * (1 - nearest/farthest)
+	})
+	return score
+}
+
+// squares returns the square table bonuses of White's pieces minus those of Black's. Tables are written from
+// White's side of the board, so Black's pieces look them up with the rows flipped. Pieces without a table add
+// nothing; a table that doesn't fit the board is an error.
+func (bs *BoardScorer) squares(board *core.Board) (float32, error) {
+	for name, table := range bs.config.SquareTables {
+		if len(table) != board.Rows {
+			return 0, fmt.Errorf("square table for %s has %d rows, board has %d", name, len(table), board.Rows)
+		}
+		for _, row := range table {
+			if len(row) != board.Columns {
+				return 0, fmt.Errorf("square table for %s has %d columns, board has %d", name, len(row), board.Columns)
+			}
+		}
+	}
+
+	var score float32
+	forEachPiece(board, func(piece *core.Piece, pos core.Position) {
+		table, ok := bs.config.SquareTables[piece.Name]
+		if !ok {
+			return
+		}
+		row := pos[0]
+		if piece.Color == core.Black {
+			row = board.Rows - 1 - row
+		}
+		score += sign(piece.Color) * table[row][pos[1]]
+	})
 	return score, nil
 }
+
+// forEachPiece calls fn with every piece on the board and its position.
+func forEachPiece(board *core.Board, fn func(piece *core.Piece, pos core.Position)) {
+	for row := range board.Rows {
+		for col := range board.Columns {
+			pos := core.Position{row, col}
+			if piece := board.GetPieceAt(pos); piece != nil {
+				fn(piece, pos)
+			}
+		}
+	}
+}
+
+// sign returns 1 for White and -1 for Black, since scores are from White's point of view.
+func sign(color core.Color) float32 {
+	if color == core.Black {
+		return -1
+	}
+	return 1
+}
+
+// abs returns the absolute value of x.
+func abs(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -88,3 +88,147 @@ func TestScore_CustomPieces(t *testing.T) {
 	require.NoError(t, err, "should score final board")
 	assert.Equal(t, expectedScore+1-2, finalScore, "score should be adjusted by both pieces: +1 (white warrior) -2 (black padwar)")
 }
+
+func TestAIPlayerConfig_Weight(t *testing.T) {
+	cfg := &AIPlayerConfig{Weights: map[string]float32{TermMobility: 0.5}}
+	assert.Equal(t, float32(1), cfg.Weight(TermMaterial), "material should count fully by default")
+	assert.Equal(t, float32(0.5), cfg.Weight(TermMobility), "listed terms should use their weight")
+	assert.Equal(t, float32(0), cfg.Weight(TermCenter), "unlisted terms should have no weight")
+
+	cfg.Weights[TermMaterial] = 0
+	assert.Equal(t, float32(0), cfg.Weight(TermMaterial), "material can be turned off")
+}
+
+func TestBreakdown_StartingBoardIsEven(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	scorer, err := NewBoardScorer("strategist")
+	require.NoError(t, err, "should create scorer")
+
+	// The starting position is mirrored, so every term should cancel out
+	breakdown, err := scorer.Breakdown(game.Board)
+	require.NoError(t, err, "should break down the board")
+	require.Len(t, breakdown, len(Terms), "should have every term")
+	for i, ts := range breakdown {
+		assert.Equal(t, Terms[i], ts.Term, "terms should be in order")
+		assert.InDelta(t, 0, ts.Value, 0.0001, "%s should be even", ts.Term)
+	}
+}
+
+func TestBreakdown_AddsUpToScore(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	// An undefended black padwar that the white warrior in the corner can take
+	board, err := game.Board.PlacePiece(&core.Piece{Name: "padwar", Color: core.Black}, core.Position{7, 0})
+	require.NoError(t, err, "should place piece")
+
+	scorer, err := NewBoardScorer("strategist")
+	require.NoError(t, err, "should create scorer")
+
+	breakdown, err := scorer.Breakdown(board)
+	require.NoError(t, err, "should break down the board")
+	var total float32
+	values := make(map[string]float32)
+	for _, ts := range breakdown {
+		total += ts.Contribution()
+		values[ts.Term] = ts.Value
+	}
+
+	score, err := scorer.Score(board)
+	require.NoError(t, err, "should score the board")
+	assert.InDelta(t, score, total, 0.0001, "contributions should add up to the score")
+	assert.Equal(t, float32(-2), values[TermMaterial], "black should be a padwar ahead")
+	assert.Equal(t, float32(2), values[TermHanging], "the black padwar should be hanging")
+}
+
+func TestScore_Hanging(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+	scorer := NewBoardScorerWithConfig(&AIPlayerConfig{
+		Scoring: map[string]float32{"warrior": 1, "padwar": 2},
+		Weights: map[string]float32{TermMaterial: 0, TermHanging: 1},
+	})
+
+	// A black warrior next to the white padwar's diagonal is attacked but not defended
+	warrior := &core.Piece{Name: "warrior", Color: core.Black}
+	board, err := game.Board.PlacePiece(warrior, core.Position{8, 2})
+	require.NoError(t, err, "should place piece")
+	score, err := scorer.Score(board)
+	require.NoError(t, err, "should score the board")
+	assert.Equal(t, float32(1), score, "undefended black warrior should be hanging")
+
+	// A second black warrior that could recapture defends it
+	defender := &core.Piece{Name: "warrior", Color: core.Black}
+	defenderConfig, err := board.Config().GetPieceConfig("warrior")
+	require.NoError(t, err, "should find warrior config")
+	defender.Config = *defenderConfig
+	board, err = board.PlacePiece(defender, core.Position{6, 2})
+	require.NoError(t, err, "should place piece")
+	score, err = scorer.Score(board)
+	require.NoError(t, err, "should score the board")
+	assert.Equal(t, float32(0), score, "defended black warrior should not be hanging")
+}
+
+func TestScore_CenterAndObjectives(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	board, err := game.Board.PlacePiece(&core.Piece{Name: "warrior", Color: core.White}, core.Position{4, 4})
+	require.NoError(t, err, "should place piece")
+
+	centerScorer := NewBoardScorerWithConfig(&AIPlayerConfig{
+		Weights: map[string]float32{TermMaterial: 0, TermCenter: 1},
+	})
+	score, err := centerScorer.Score(board)
+	require.NoError(t, err, "should score the board")
+	assert.InDelta(t, 1-1.0/9, score, 0.0001, "a piece next to the middle should be nearly fully central")
+
+	objectiveScorer := NewBoardScorerWithConfig(&AIPlayerConfig{
+		Weights: map[string]float32{TermMaterial: 0, TermObjectives: 1},
+	})
+	score, err = objectiveScorer.Score(board)
+	require.NoError(t, err, "should score the board")
+	assert.InDelta(t, 1, score, 0.0001, "a piece on an objective should count fully")
+}
+
+func TestScore_SquareTables(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	table := make([][]float32, game.Board.Rows)
+	for i := range table {
+		table[i] = make([]float32, game.Board.Columns)
+	}
+	table[9][0] = 0.5
+	table[5][5] = 1
+	scorer := NewBoardScorerWithConfig(&AIPlayerConfig{
+		Weights:      map[string]float32{TermMaterial: 0, TermSquares: 1},
+		SquareTables: map[string][][]float32{"warrior": table},
+	})
+
+	// Both sides have a warrior on their own [9,0], so it evens out
+	score, err := scorer.Score(game.Board)
+	require.NoError(t, err, "should score the board")
+	assert.Equal(t, float32(0), score, "mirrored tables should even out")
+
+	board, err := game.Board.PlacePiece(&core.Piece{Name: "warrior", Color: core.White}, core.Position{5, 5})
+	require.NoError(t, err, "should place piece")
+	score, err = scorer.Score(board)
+	require.NoError(t, err, "should score the board")
+	assert.Equal(t, float32(1), score, "white warrior should get the table bonus")
+
+	board, err = game.Board.PlacePiece(&core.Piece{Name: "warrior", Color: core.Black}, core.Position{4, 5})
+	require.NoError(t, err, "should place piece")
+	score, err = scorer.Score(board)
+	require.NoError(t, err, "should score the board")
+	assert.Equal(t, float32(-1), score, "black warrior should get the mirrored table bonus")
+
+	badScorer := NewBoardScorerWithConfig(&AIPlayerConfig{
+		Weights:      map[string]float32{TermSquares: 1},
+		SquareTables: map[string][][]float32{"warrior": {{1, 2}}},
+	})
+	_, err = badScorer.Score(game.Board)
+	assert.Error(t, err, "a table that doesn't fit the board should be an error")
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"context"
+	"cragspider-go/internal/core"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// depthSearch searches the position to a fixed depth and returns the best action it found. If the context ends
+// before the search finishes, it returns the best action among the moves it had fully searched (which may be nil)
+// along with the context's error.
+type depthSearch func(ctx context.Context, depth int) (*core.Action, error)
+
+// iterativeDeepening runs search at depth 1, 2, and so on up to maxDepth, stopping early if the context ends. It
+// returns the action from the deepest search that finished and the depth that search reached. If the context ends
+// during the very first search, that search's partial answer is used so that a move is always available when
+// there is one; an error is returned only if there is no answer at all.
+func iterativeDeepening(ctx context.Context, maxDepth int, search depthSearch) (*core.Action, int, error) {
+	if maxDepth < 1 {
+		return nil, 0, fmt.Errorf("max depth must be at least 1, got %d", maxDepth)
+	}
+
+	var best *core.Action
+	for depth := 1; depth <= maxDepth; depth++ {
+		action, err := search(ctx, depth)
+		if err != nil {
+			if ctx.Err() == nil {
+				return nil, depth, err
+			}
+			// Out of time. A partial first search is still better than nothing.
+			if best == nil && action != nil {
+				return action, depth, nil
+			}
+			if best == nil {
+				return nil, depth, fmt.Errorf("search stopped before finding a move: %w", err)
+			}
+			return best, depth - 1, nil
+		}
+		if action == nil {
+			return nil, depth, fmt.Errorf("no valid moves available")
+		}
+		best = action
+	}
+	return best, maxDepth, nil
+}
+
+// pickWithTemperature returns the index of a score chosen at random, where each score's chance falls off
+// exponentially with how far it is below the best. The temperature is how far below the best a score can be and
+// still have a fair chance; at zero the best score is always picked. If r is nil, the shared generator is used.
+func pickWithTemperature(r *rand.Rand, scores []float32, temperature float32) int {
+	best := 0
+	for i, score := range scores {
+		if score > scores[best] {
+			best = i
+		}
+	}
+	if temperature <= 0 {
+		return best
+	}
+
+	weights := make([]float64, len(scores))
+	var total float64
+	for i, score := range scores {
+		weights[i] = math.Exp(float64((score - scores[best]) / temperature))
+		total += weights[i]
+	}
+	roll := total * rand.Float64() //nolint:gosec
+	if r != nil {
+		roll = total * r.Float64()
+	}
+	for i, weight := range weights {
+		roll -= weight
+		if roll < 0 {
+			return i
+		}
+	}
+	return best
+}
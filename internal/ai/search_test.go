@@ -0,0 +1,112 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cragspider-go/internal/core"
+	"cragspider-go/pkg/random"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterativeDeepening(t *testing.T) {
+	actions := []*core.Action{
+		{Move: core.Move{1, 0}},
+		{Move: core.Move{2, 0}},
+		{Move: core.Move{3, 0}},
+	}
+
+	t.Run("searches to max depth", func(t *testing.T) {
+		var depths []int
+		action, depth, err := iterativeDeepening(context.Background(), 3, func(_ context.Context, d int) (*core.Action, error) {
+			depths = append(depths, d)
+			return actions[d-1], nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, depths)
+		assert.Equal(t, 3, depth)
+		assert.Same(t, actions[2], action)
+	})
+
+	t.Run("keeps the deepest finished search when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		action, depth, err := iterativeDeepening(ctx, 3, func(ctx context.Context, d int) (*core.Action, error) {
+			if d == 3 {
+				cancel()
+				return actions[2], ctx.Err()
+			}
+			return actions[d-1], nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, depth)
+		assert.Same(t, actions[1], action)
+	})
+
+	t.Run("uses a partial first search", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		action, _, err := iterativeDeepening(ctx, 3, func(ctx context.Context, _ int) (*core.Action, error) {
+			return actions[0], ctx.Err()
+		})
+		require.NoError(t, err)
+		assert.Same(t, actions[0], action)
+	})
+
+	t.Run("fails when nothing was found", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, _, err := iterativeDeepening(ctx, 3, func(ctx context.Context, _ int) (*core.Action, error) {
+			return nil, ctx.Err()
+		})
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+
+	t.Run("search errors are returned", func(t *testing.T) {
+		_, _, err := iterativeDeepening(context.Background(), 3, func(_ context.Context, _ int) (*core.Action, error) {
+			return nil, errors.New("broken")
+		})
+		assert.EqualError(t, err, "broken")
+	})
+
+	t.Run("no moves", func(t *testing.T) {
+		_, _, err := iterativeDeepening(context.Background(), 3, func(_ context.Context, _ int) (*core.Action, error) {
+			return nil, nil
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestPickWithTemperature(t *testing.T) {
+	scores := []float32{1, 3, 2, 3}
+
+	t.Run("zero temperature picks the first best", func(t *testing.T) {
+		assert.Equal(t, 1, pickWithTemperature(random.New(1), scores, 0))
+	})
+
+	t.Run("low temperature only picks near-equal moves", func(t *testing.T) {
+		rng := random.New(1)
+		picked := make(map[int]int)
+		for range 1000 {
+			picked[pickWithTemperature(rng, scores, 0.05)]++
+		}
+		assert.Zero(t, picked[0], "far worse move should not be picked")
+		assert.Zero(t, picked[2], "worse move should not be picked")
+		assert.Positive(t, picked[1], "tied best move should be picked")
+		assert.Positive(t, picked[3], "tied best move should be picked")
+	})
+
+	t.Run("high temperature picks anything", func(t *testing.T) {
+		rng := random.New(1)
+		picked := make(map[int]int)
+		for range 1000 {
+			picked[pickWithTemperature(rng, scores, 100)]++
+		}
+		assert.Len(t, picked, len(scores), "every move should be picked sometimes")
+	})
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"context"
+	"cragspider-go/internal/book"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/engine"
+	"cragspider-go/internal/tablebase"
+	"cragspider-go/internal/training"
+	"cragspider-go/pkg/random"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// maxSearchDepth is how deep an alpha-beta search may go when only its think time limits it.
+const maxSearchDepth = 64
+
+// Personality is an AI opponent as configured in the AI configuration file: a strategy for picking moves, plus
+// how long it takes to think about them and the opening book it plays from, if any.
+type Personality struct {
+	Config   *AIPlayerConfig
+	color    core.Color
+	strategy core.TimedAgentStrategy
+	book     *book.Book
+	rng      *rand.Rand
+	booked   bool // Whether the last move came from the book
+}
+
+var (
+	_ core.AgentStrategy      = (*Personality)(nil)
+	_ core.TimedAgentStrategy = (*Personality)(nil)
+	_ Analyzer                = (*Personality)(nil)
+	_ io.Closer               = (*Personality)(nil)
+)
+
+// NewStrategy returns the named AI personality from the AI configuration file, playing the specified color.
+func NewStrategy(name string, color core.Color) (*Personality, error) {
+	return NewSeededStrategy(name, color, time.Now().UnixNano())
+}
+
+// NewSeededStrategy returns the named AI personality playing the specified color, with all of its random choices
+// drawn from a generator seeded with seed.
+func NewSeededStrategy(name string, color core.Color, seed int64) (*Personality, error) {
+	cfg, err := GetAIConfig()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get AI config: %w", err)
+	}
+	playerConfig, err := cfg.GetPlayerConfig(name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get player config: %w", err)
+	}
+	return NewStrategyWithConfig(playerConfig, color, seed)
+}
+
+// NewStrategyWithConfig returns an AI personality built from the given configuration, playing the specified color,
+// with all of its random choices drawn from a generator seeded with seed.
+func NewStrategyWithConfig(playerConfig *AIPlayerConfig, color core.Color, seed int64) (*Personality, error) {
+	rng := random.New(seed)
+	scorer := NewBoardScorerWithConfig(playerConfig)
+	var evaluator Evaluator
+	if playerConfig.Evaluator != "" {
+		network, err := loadNetwork(playerConfig.Evaluator)
+		if err != nil {
+			return nil, fmt.Errorf("AI player '%s' cannot load its evaluator: %w", playerConfig.Name, err)
+		}
+		evaluator = network
+	}
+
+	var strategy core.TimedAgentStrategy
+	switch playerConfig.Strategy {
+	case RandomStrategy, "":
+		strategy = core.AsTimed(&RandomBot{Color: color, rng: rng})
+	case GreedyStrategy:
+		bot := NewGreedyBot(color, scorer)
+		bot.rng = rng
+		strategy = core.AsTimed(bot)
+	case AlphaBetaStrategy:
+		depth := playerConfig.Search.MaxDepth
+		if depth <= 0 {
+			if playerConfig.ThinkTime.Max <= 0 {
+				return nil, fmt.Errorf("AI player '%s' needs a search depth or a think time", playerConfig.Name)
+			}
+			depth = maxSearchDepth
+		}
+		bot := NewAlphaBetaBot(color, scorer, depth)
+		if playerConfig.Search.TableSize > 0 {
+			bot.TableSize = playerConfig.Search.TableSize
+		}
+		bot.Threads = playerConfig.Search.Threads
+		if playerConfig.Tablebase != "" {
+			tb, err := loadTablebase(playerConfig.Tablebase)
+			if err != nil {
+				return nil, fmt.Errorf("AI player '%s' cannot load its tablebase: %w", playerConfig.Name, err)
+			}
+			bot.Tablebase = tb
+		}
+		bot.Evaluator = evaluator
+		bot.Temperature = playerConfig.Temperature
+		bot.rng = rng
+		strategy = bot
+	case MCTSStrategy:
+		bot := NewMCTSBot(color, scorer, playerConfig.Search.Iterations, playerConfig.Search.PlayoutDepth, rng)
+		bot.Evaluator = evaluator
+		bot.Temperature = playerConfig.Temperature
+		strategy = bot
+	case EngineStrategy:
+		if playerConfig.Engine.Command == "" {
+			return nil, fmt.Errorf("AI player '%s' needs an engine command", playerConfig.Name)
+		}
+		e, err := engine.Start(color, playerConfig.Engine.Command, playerConfig.Engine.Args...)
+		if err != nil {
+			return nil, fmt.Errorf("AI player '%s' cannot start its engine: %w", playerConfig.Name, err)
+		}
+		strategy = e
+	default:
+		return nil, fmt.Errorf("AI player '%s' has unknown strategy '%s'", playerConfig.Name, playerConfig.Strategy)
+	}
+
+	p := &Personality{Config: playerConfig, color: color, strategy: strategy, rng: rng}
+	if playerConfig.Book.Enabled {
+		var err error
+		if playerConfig.Book.File == "" {
+			p.book, err = book.Default()
+		} else {
+			p.book, err = book.Load(playerConfig.Book.File)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("AI player '%s' cannot load its opening book: %w", playerConfig.Name, err)
+		}
+	}
+	return p, nil
+}
+
+var (
+	tablebases   = make(map[string]*tablebase.Tablebase)
+	tablebasesMu sync.Mutex
+)
+
+// loadTablebase returns the tablebase in the named file, reading it only the first time it's asked for, since
+// tablebases are large and every game builds its personalities anew.
+func loadTablebase(path string) (*tablebase.Tablebase, error) {
+	tablebasesMu.Lock()
+	defer tablebasesMu.Unlock()
+	if tb, ok := tablebases[path]; ok {
+		return tb, nil
+	}
+	tb, err := tablebase.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	tablebases[path] = tb
+	return tb, nil
+}
+
+var (
+	networks   = make(map[string]*training.Network)
+	networksMu sync.Mutex
+)
+
+// loadNetwork returns the network in the named file, reading it only the first time it's asked for, like
+// loadTablebase.
+func loadNetwork(path string) (*training.Network, error) {
+	networksMu.Lock()
+	defer networksMu.Unlock()
+	if n, ok := networks[path]; ok {
+		return n, nil
+	}
+	n, err := training.LoadNetwork(path)
+	if err != nil {
+		return nil, err
+	}
+	networks[path] = n
+	return n, nil
+}
+
+// NewAIPlayer returns a new player for the named AI personality, playing the specified color.
+func NewAIPlayer(name string, color core.Color) (*core.Player, error) {
+	personality, err := NewStrategy(name, color)
+	if err != nil {
+		return nil, err
+	}
+	return core.NewAIPlayer(personality.Config.String(), personality), nil
+}
+
+// String returns the personality's display name.
+func (p *Personality) String() string {
+	return p.Config.String()
+}
+
+// NextMove returns the personality's next move, taking its configured think time.
+func (p *Personality) NextMove(board *core.Board) (*core.Action, error) {
+	return p.NextMoveContext(context.Background(), board, 0)
+}
+
+// NextMoveContext returns the personality's next move. If it plays from an opening book that knows the position, the
+// move comes straight from the book; otherwise it's searched for. A budget of zero means the personality picks its
+// own from its configured think time.
+func (p *Personality) NextMoveContext(ctx context.Context, board *core.Board, budget time.Duration) (*core.Action, error) {
+	if p.book != nil {
+		if action, ok := p.book.Choose(board, p.color, p.Config.Book.Variety, p.rng); ok {
+			p.booked = true
+			return action, nil
+		}
+	}
+	p.booked = false
+	if budget == 0 {
+		budget = p.Config.ThinkTime.Budget(p.rng)
+	}
+	return p.strategy.NextMoveContext(ctx, board, budget)
+}
+
+// SearchStats returns the work the personality did to find its last move, or false if it wasn't searched for with
+// alpha-beta, as when it came from the book or the personality plays some other way.
+func (p *Personality) SearchStats() (SearchStats, bool) {
+	bot, ok := p.strategy.(*AlphaBetaBot)
+	if !ok || p.booked || bot.Stats().Depth == 0 {
+		return SearchStats{}, false
+	}
+	return bot.Stats(), true
+}
+
+// Close frees whatever the personality's strategy holds on to, such as an engine's program.
+func (p *Personality) Close() error {
+	if closer, ok := p.strategy.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Analyze returns the personality's analysis of the board, searched the way it searches for its own moves but
+// without its opening book or temperature. Only personalities with an alpha-beta strategy can explain their moves.
+func (p *Personality) Analyze(ctx context.Context, board *core.Board, toMove core.Color, budget time.Duration,
+	lines int) (*Analysis, error) {
+	analyzer, ok := p.strategy.(Analyzer)
+	if !ok {
+		return nil, fmt.Errorf("AI player '%s' cannot analyze positions with strategy '%s'", p.Config.Name,
+			p.Config.Strategy)
+	}
+	if budget == 0 {
+		budget = p.Config.ThinkTime.Budget(p.rng)
+	}
+	return analyzer.Analyze(ctx, board, toMove, budget, lines)
+}
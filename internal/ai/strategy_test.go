@@ -0,0 +1,225 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cragspider-go/internal/book"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/training"
+	"cragspider-go/pkg/random"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStrategy_BuildsEveryPersonality(t *testing.T) {
+	cfg, err := GetAIConfig()
+	require.NoError(t, err, "should load AI config")
+
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	for _, player := range cfg.Players {
+		t.Run(player.Name, func(t *testing.T) {
+			personality, err := NewStrategy(player.Name, core.White)
+			require.NoError(t, err, "should build the personality")
+			assert.Equal(t, player.DisplayName, personality.String(), "should use the display name")
+
+			// Keep the test quick by giving the bot a short budget instead of its think time
+			action, err := personality.NextMoveContext(context.Background(), game.Board, 100*time.Millisecond)
+			require.NoError(t, err, "should find a move")
+			_, err = game.Board.ApplyAction(action)
+			assert.NoError(t, err, "action should be valid on the board")
+		})
+	}
+}
+
+func TestNewStrategy_StrategyTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   AIPlayerConfig
+		expected any
+		wantErr  bool
+	}{
+		{name: "default is random", config: AIPlayerConfig{}, expected: nil},
+		{name: "random", config: AIPlayerConfig{Strategy: RandomStrategy}, expected: nil},
+		{name: "greedy", config: AIPlayerConfig{Strategy: GreedyStrategy}, expected: nil},
+		{name: "alpha-beta", config: AIPlayerConfig{Strategy: AlphaBetaStrategy, Search: SearchLimits{MaxDepth: 2}}, expected: &AlphaBetaBot{}},
+		{name: "mcts", config: AIPlayerConfig{Strategy: MCTSStrategy}, expected: &MCTSBot{}},
+		{name: "alpha-beta without limits", config: AIPlayerConfig{Strategy: AlphaBetaStrategy}, wantErr: true},
+		{name: "unknown", config: AIPlayerConfig{Strategy: "psychic"}, wantErr: true},
+		{name: "engine without a command", config: AIPlayerConfig{Strategy: EngineStrategy}, wantErr: true},
+		{name: "engine that won't start", config: AIPlayerConfig{Strategy: EngineStrategy, Engine: EngineConfig{Command: "/nonexistent/engine"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			personality, err := NewStrategyWithConfig(&tt.config, core.Black, 1)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expected != nil {
+				assert.IsType(t, tt.expected, personality.strategy)
+			}
+			assert.NoError(t, personality.Close())
+		})
+	}
+}
+
+func TestNewStrategy_AlphaBetaDepthFromThinkTime(t *testing.T) {
+	personality, err := NewStrategyWithConfig(&AIPlayerConfig{
+		Strategy:  AlphaBetaStrategy,
+		ThinkTime: ThinkTime{Min: time.Second, Max: time.Second},
+	}, core.White, 1)
+	require.NoError(t, err)
+	bot, ok := personality.strategy.(*AlphaBetaBot)
+	require.True(t, ok, "should be an alpha-beta bot")
+	assert.Equal(t, maxSearchDepth, bot.MaxDepth, "think time alone should bound the search")
+}
+
+func TestNewStrategy_UnknownPersonality(t *testing.T) {
+	_, err := NewStrategy("nonexistent", core.White)
+	assert.Error(t, err)
+}
+
+func TestNewSeededStrategy_IsRepeatable(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	moves := func() []core.Action {
+		personality, err := NewSeededStrategy("doofus", core.White, 42)
+		require.NoError(t, err)
+		var actions []core.Action
+		for range 10 {
+			action, err := personality.NextMove(game.Board)
+			require.NoError(t, err)
+			actions = append(actions, *action)
+		}
+		return actions
+	}
+	assert.Equal(t, moves(), moves(), "the same seed should make the same moves")
+}
+
+func TestPersonality_PlaysFromBook(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+	opening := book.New()
+	opening.Add(game.Board, core.White, core.Position{9, 9}, core.Position{8, 9}, 1)
+	path := filepath.Join(t.TempDir(), "book.json")
+	require.NoError(t, opening.Save(path))
+
+	cfg := &AIPlayerConfig{
+		Name:     "bookworm",
+		Strategy: AlphaBetaStrategy,
+		Search:   SearchLimits{MaxDepth: 1},
+		Book:     BookConfig{Enabled: true, File: path},
+	}
+	personality, err := NewStrategyWithConfig(cfg, core.White, 1)
+	require.NoError(t, err)
+	action, err := personality.NextMove(game.Board)
+	require.NoError(t, err)
+	assert.Equal(t, core.Action{Piece: game.Board.GetPieceAt(core.Position{9, 9}), Move: core.Move{-1, 0}}, *action,
+		"the book's move should be played")
+
+	// Out of book, the personality searches as usual
+	require.NoError(t, game.Play(action))
+	require.NoError(t, game.Play(&game.Board.ValidActions(core.Black)[0]))
+	action, err = personality.NextMove(game.Board)
+	require.NoError(t, err)
+	assert.NoError(t, game.Play(action), "the searched move should be valid")
+
+	cfg.Book.File = filepath.Join(t.TempDir(), "missing.json")
+	_, err = NewStrategyWithConfig(cfg, core.White, 1)
+	assert.Error(t, err, "a missing book is an error")
+}
+
+func TestNewStrategy_Evaluator(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+	encoder := training.NewEncoder(gameConfig)
+	network := &training.Network{
+		Rows:    encoder.Rows,
+		Columns: encoder.Columns,
+		Pieces:  encoder.Pieces,
+		Scale:   1,
+		Output:  make([]float32, encoder.Size()),
+	}
+	path := filepath.Join(t.TempDir(), "network.json")
+	require.NoError(t, network.Save(path))
+
+	for _, strategy := range []StrategyType{AlphaBetaStrategy, MCTSStrategy} {
+		cfg := &AIPlayerConfig{Strategy: strategy, Search: SearchLimits{MaxDepth: 1, Iterations: 10}, Evaluator: path}
+		personality, err := NewStrategyWithConfig(cfg, core.White, 1)
+		require.NoError(t, err, "%s should load its evaluator", strategy)
+		switch bot := personality.strategy.(type) {
+		case *AlphaBetaBot:
+			assert.NotNil(t, bot.Evaluator)
+		case *MCTSBot:
+			assert.NotNil(t, bot.Evaluator)
+		}
+
+		cfg.Evaluator = filepath.Join(t.TempDir(), "missing.json")
+		_, err = NewStrategyWithConfig(cfg, core.White, 1)
+		assert.Error(t, err, "%s: a missing evaluator is an error", strategy)
+	}
+}
+
+func TestNewAIPlayer(t *testing.T) {
+	player, err := NewAIPlayer("strategist", core.Black)
+	require.NoError(t, err)
+	assert.True(t, player.IsAI())
+	assert.Equal(t, "The Strategist", player.String())
+}
+
+func TestThinkTime_Budget(t *testing.T) {
+	rng := random.New(1)
+	assert.Equal(t, time.Duration(0), ThinkTime{}.Budget(rng), "no think time means no limit")
+	assert.Equal(t, time.Second, ThinkTime{Min: time.Second, Max: time.Second}.Budget(rng))
+	for range 100 {
+		budget := ThinkTime{Min: time.Second, Max: 2 * time.Second}.Budget(rng)
+		assert.GreaterOrEqual(t, budget, time.Second)
+		assert.Less(t, budget, 2*time.Second)
+	}
+}
+
+func TestPersonality_SearchStats(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err, "should create new game")
+
+	random, err := NewStrategyWithConfig(&AIPlayerConfig{Strategy: RandomStrategy}, core.White, 1)
+	require.NoError(t, err)
+	_, err = random.NextMove(game.Board)
+	require.NoError(t, err)
+	_, ok := random.SearchStats()
+	assert.False(t, ok, "random moves aren't searched for")
+
+	opening := book.New()
+	opening.Add(game.Board, core.White, core.Position{9, 9}, core.Position{8, 9}, 1)
+	path := filepath.Join(t.TempDir(), "book.json")
+	require.NoError(t, opening.Save(path))
+	searcher, err := NewStrategyWithConfig(&AIPlayerConfig{
+		Strategy: AlphaBetaStrategy,
+		Search:   SearchLimits{MaxDepth: 2},
+		Book:     BookConfig{Enabled: true, File: path},
+	}, core.White, 1)
+	require.NoError(t, err)
+	action, err := searcher.NextMove(game.Board)
+	require.NoError(t, err)
+	_, ok = searcher.SearchStats()
+	assert.False(t, ok, "book moves aren't searched for")
+
+	require.NoError(t, game.Play(action))
+	require.NoError(t, game.Play(&game.Board.ValidActions(core.Black)[0]))
+	_, err = searcher.NextMove(game.Board)
+	require.NoError(t, err)
+	stats, ok := searcher.SearchStats()
+	require.True(t, ok, "searched moves have stats")
+	assert.Equal(t, 2, stats.Depth)
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"cragspider-go/internal/core"
+	"math/bits"
+	"sync"
+)
+
+const (
+	// defaultTableSize is the number of entries in a search bot's transposition table unless it's configured
+	// otherwise.
+	defaultTableSize = 1 << 16
+	// tableLocks is how many locks guard a transposition table's slots, so that threads searching at once seldom
+	// wait on one another.
+	tableLocks = 64
+)
+
+// bound says how a stored score relates to the position's true score, which depends on whether the search that
+// produced it was cut off.
+type bound uint8
+
+const (
+	// exactBound scores are the position's true score to the stored depth.
+	exactBound bound = iota
+	// lowerBound scores caused a cutoff, so the true score is at least this good.
+	lowerBound
+	// upperBound scores never beat alpha, so the true score is at most this good.
+	upperBound
+)
+
+// ttEntry is what a transposition table remembers about one position.
+type ttEntry struct {
+	key   uint64
+	depth int
+	bound bound
+	score float32
+	best  core.Action // Best move found, or one with no piece if there wasn't one
+}
+
+// transpositionTable remembers the results of searching positions, keyed by position hash, so that a position
+// reached again by a different order of moves needn't be searched again. It has a fixed number of entries; each
+// position has one slot, and a deeper search of a position in that slot is kept over a shallower one. It's safe
+// for threads searching at once to share a table.
+type transpositionTable struct {
+	entries []ttEntry
+	mask    uint64
+	used    []bool
+	locks   [tableLocks]sync.Mutex
+}
+
+// newTranspositionTable returns an empty table with room for size entries, rounded down to a power of two.
+func newTranspositionTable(size int) *transpositionTable {
+	size = tableSize(size)
+	return &transpositionTable{
+		entries: make([]ttEntry, size),
+		mask:    uint64(size - 1),
+		used:    make([]bool, size),
+	}
+}
+
+// tableSize returns the number of entries a table asked to hold size entries has room for.
+func tableSize(size int) int {
+	return 1 << (bits.Len(uint(max(size, 1))) - 1)
+}
+
+// size returns the number of entries the table has room for.
+func (t *transpositionTable) size() int {
+	return len(t.entries)
+}
+
+// probe returns what the table remembers about the position with the given key, if anything.
+func (t *transpositionTable) probe(key uint64) (ttEntry, bool) {
+	i := key & t.mask
+	lock := &t.locks[i%tableLocks]
+	lock.Lock()
+	defer lock.Unlock()
+	if !t.used[i] || t.entries[i].key != key {
+		return ttEntry{}, false
+	}
+	return t.entries[i], true
+}
+
+// store remembers the result of searching the position with the given key. It replaces whatever was in the
+// position's slot unless that was the same position searched deeper.
+func (t *transpositionTable) store(key uint64, depth int, b bound, score float32, best *core.Action) {
+	i := key & t.mask
+	lock := &t.locks[i%tableLocks]
+	lock.Lock()
+	defer lock.Unlock()
+	if t.used[i] && t.entries[i].key == key && t.entries[i].depth > depth {
+		return
+	}
+	entry := ttEntry{key: key, depth: depth, bound: b, score: score}
+	if best != nil {
+		entry.best = *best
+	}
+	t.entries[i] = entry
+	t.used[i] = true
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package ai
+
+import (
+	"testing"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTranspositionTable(t *testing.T) {
+	assert.Equal(t, 1024, newTranspositionTable(1024).size())
+	assert.Equal(t, 1024, newTranspositionTable(1500).size(), "sizes round down to a power of two")
+	assert.Equal(t, 1, newTranspositionTable(0).size())
+}
+
+func TestTranspositionTable_ProbeAndStore(t *testing.T) {
+	table := newTranspositionTable(16)
+	piece := &core.Piece{Name: "warrior", Color: core.White}
+	best := core.Action{Piece: piece, Move: core.Move{-1, 0}}
+
+	_, ok := table.probe(5)
+	assert.False(t, ok, "an empty table knows nothing")
+
+	table.store(5, 3, lowerBound, 1.5, &best)
+	entry, ok := table.probe(5)
+	require.True(t, ok)
+	assert.Equal(t, ttEntry{key: 5, depth: 3, bound: lowerBound, score: 1.5, best: best}, entry)
+
+	_, ok = table.probe(5 + 16)
+	assert.False(t, ok, "a different position in the same slot isn't a match")
+
+	table.store(5, 2, exactBound, 0.5, nil)
+	entry, _ = table.probe(5)
+	assert.Equal(t, 3, entry.depth, "a shallower search doesn't replace a deeper one")
+
+	table.store(5+16, 1, upperBound, -1, nil)
+	_, ok = table.probe(5)
+	assert.False(t, ok, "a different position replaces whatever was in its slot")
+	entry, ok = table.probe(5 + 16)
+	require.True(t, ok)
+	assert.Nil(t, entry.best.Piece, "no best move was stored")
+}
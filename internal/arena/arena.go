@@ -0,0 +1,293 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Package arena plays AI personalities against each other without a window, to measure which is stronger.
+package arena
+
+import (
+	"context"
+	"cragspider-go/internal/ai"
+	"cragspider-go/internal/core"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// Mode is the way the arena pairs up players.
+type Mode string
+
+const (
+	// RoundRobin plays every player against every other player.
+	RoundRobin Mode = "roundrobin"
+	// Gauntlet plays one challenger against every other player.
+	Gauntlet Mode = "gauntlet"
+)
+
+// Config describes a tournament between AI personalities.
+type Config struct {
+	Players    []string      // Names of the AI personalities taking part
+	Mode       Mode          // How the players are paired up
+	Challenger string        // The player who faces everyone else in a gauntlet
+	Games      int           // Games per pairing; colors alternate from game to game
+	Workers    int           // Games played at once; zero means one per CPU
+	MaxMoves   int           // Moves before a game is drawn; zero means the game configuration's limit
+	MoveBudget time.Duration // Time per move; zero means each personality's own think time
+	Untimed    bool          // Ignore think times and search on one thread, so each game depends only on its seed
+	Seed       int64         // Seed for every random choice the bots make
+
+	// Progress, if set, is called after each game finishes with the number of games done so far.
+	Progress func(done, total int, game GameResult)
+}
+
+// GameResult is the record of one game played in the arena.
+type GameResult struct {
+	Round    int               `json:"round"`
+	White    string            `json:"white"`
+	Black    string            `json:"black"`
+	Seed     int64             `json:"seed"`
+	Result   core.Result       `json:"result"`
+	Reason   string            `json:"reason"`
+	Moves    []core.MoveRecord `json:"moves"`
+	Duration time.Duration     `json:"duration"`
+
+	// Scores holds, for the position before each move, the score the mover's search gave it from White's point of
+	// view, or nil where the move wasn't searched for. It's left out when no move was.
+	Scores []*float32 `json:"scores,omitempty"`
+}
+
+// Length returns the number of moves played in the game.
+func (g GameResult) Length() int {
+	return len(g.Moves)
+}
+
+// Results holds every game played in a tournament.
+type Results struct {
+	Mode    Mode         `json:"mode"`
+	Players []string     `json:"players"`
+	Seed    int64        `json:"seed"`
+	Games   []GameResult `json:"games"`
+}
+
+// match is one game waiting to be played.
+type match struct {
+	round        int
+	white, black string
+}
+
+// Run plays the tournament described by cfg with the given game configuration, spreading the games over a pool of
+// workers. Games are seeded from cfg.Seed and their round number, so results come out in the same order with the
+// same seeds however many workers there are.
+func Run(ctx context.Context, cfg Config, gameConfig *core.GameConfig) (*Results, error) {
+	matches, err := schedule(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxMoves > 0 {
+		limited := *gameConfig
+		limited.Rules.MaxMoves = cfg.MaxMoves
+		gameConfig = &limited
+	}
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	games := make([]GameResult, len(matches))
+	jobs := make(chan match)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		done     int
+		firstErr error
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for m := range jobs {
+				game, err := playMatch(ctx, cfg, gameConfig, m)
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+				} else {
+					game.Round = m.round
+					games[m.round] = game
+					done++
+					if cfg.Progress != nil {
+						cfg.Progress(done, len(matches), game)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, m := range matches {
+		select {
+		case jobs <- m:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &Results{Mode: cfg.Mode, Players: cfg.Players, Seed: cfg.Seed, Games: games}, nil
+}
+
+// schedule returns every game the tournament will play, in round order.
+func schedule(cfg Config) ([]match, error) {
+	if cfg.Games < 1 {
+		return nil, fmt.Errorf("need at least one game per pairing, got %d", cfg.Games)
+	}
+
+	type pairing struct{ first, second string }
+	var pairings []pairing
+	switch cfg.Mode {
+	case RoundRobin:
+		if len(cfg.Players) < 2 {
+			return nil, fmt.Errorf("a round robin needs at least two players, got %d", len(cfg.Players))
+		}
+		for i := range cfg.Players {
+			for j := i + 1; j < len(cfg.Players); j++ {
+				pairings = append(pairings, pairing{cfg.Players[i], cfg.Players[j]})
+			}
+		}
+	case Gauntlet:
+		for _, player := range cfg.Players {
+			if player != cfg.Challenger {
+				pairings = append(pairings, pairing{cfg.Challenger, player})
+			}
+		}
+		if len(pairings) == 0 || len(pairings) == len(cfg.Players) {
+			return nil, fmt.Errorf("a gauntlet needs challenger '%s' and at least one opponent among the players",
+				cfg.Challenger)
+		}
+	default:
+		return nil, fmt.Errorf("unknown arena mode '%s'", cfg.Mode)
+	}
+
+	var matches []match
+	for _, p := range pairings {
+		for game := range cfg.Games {
+			m := match{round: len(matches), white: p.first, black: p.second}
+			if game%2 == 1 {
+				m.white, m.black = m.black, m.white
+			}
+			matches = append(matches, m)
+		}
+	}
+	return matches, nil
+}
+
+// playMatch plays one scheduled game of the tournament.
+func playMatch(ctx context.Context, cfg Config, gameConfig *core.GameConfig, m match) (GameResult, error) {
+	seed := cfg.Seed + int64(m.round)
+	if !cfg.Untimed {
+		return PlayGame(ctx, gameConfig, m.white, m.black, seed, cfg.MoveBudget)
+	}
+	aiConfig, err := ai.GetAIConfig()
+	if err != nil {
+		return GameResult{}, err
+	}
+	white, err := aiConfig.GetPlayerConfig(m.white)
+	if err != nil {
+		return GameResult{}, err
+	}
+	black, err := aiConfig.GetPlayerConfig(m.black)
+	if err != nil {
+		return GameResult{}, err
+	}
+	white.ThinkTime, black.ThinkTime = ai.ThinkTime{}, ai.ThinkTime{}
+	white.Search.Threads, black.Search.Threads = 1, 1
+	return PlayConfigs(ctx, gameConfig, white, black, seed, cfg.MoveBudget)
+}
+
+// PlayGame plays one game between the named AI personalities until it's over, giving each player budget to think
+// about each move. Both players' random choices are seeded from seed.
+func PlayGame(ctx context.Context, gameConfig *core.GameConfig, white, black string, seed int64, budget time.Duration) (GameResult, error) {
+	aiConfig, err := ai.GetAIConfig()
+	if err != nil {
+		return GameResult{}, err
+	}
+	whiteConfig, err := aiConfig.GetPlayerConfig(white)
+	if err != nil {
+		return GameResult{}, err
+	}
+	blackConfig, err := aiConfig.GetPlayerConfig(black)
+	if err != nil {
+		return GameResult{}, err
+	}
+	return PlayConfigs(ctx, gameConfig, whiteConfig, blackConfig, seed, budget)
+}
+
+// PlayConfigs plays one game between AI personalities built from the given configurations, which needn't be in the
+// AI configuration file. It's otherwise the same as PlayGame.
+func PlayConfigs(ctx context.Context, gameConfig *core.GameConfig, whiteConfig, blackConfig *ai.AIPlayerConfig, seed int64, budget time.Duration) (GameResult, error) {
+	white, black := whiteConfig.Name, blackConfig.Name
+	whiteStrategy, err := ai.NewStrategyWithConfig(whiteConfig, core.White, 2*seed)
+	if err != nil {
+		return GameResult{}, err
+	}
+	defer whiteStrategy.Close()
+	blackStrategy, err := ai.NewStrategyWithConfig(blackConfig, core.Black, 2*seed+1)
+	if err != nil {
+		return GameResult{}, err
+	}
+	defer blackStrategy.Close()
+	game, err := core.NewGameWithConfigAndPlayers(gameConfig,
+		core.NewAIPlayer(white, whiteStrategy), core.NewAIPlayer(black, blackStrategy))
+	if err != nil {
+		return GameResult{}, err
+	}
+
+	start := time.Now()
+	var scores []*float32
+	searched := false
+	for !game.Over() {
+		player := game.GetPlayer(game.ActiveColor)
+		action, err := core.AsTimed(player.Strategy).NextMoveContext(ctx, game.Board, budget)
+		if err != nil {
+			return GameResult{}, fmt.Errorf("%s (%s) failed to move in %s vs %s: %w",
+				player, game.ActiveColor, white, black, err)
+		}
+		personality := lo.Ternary(game.ActiveColor == core.White, whiteStrategy, blackStrategy)
+		var score *float32
+		if stats, ok := personality.SearchStats(); ok {
+			score = lo.ToPtr(lo.Ternary(game.ActiveColor == core.White, stats.Score, -stats.Score))
+			searched = true
+		}
+		scores = append(scores, score)
+		if err := game.Play(action); err != nil {
+			return GameResult{}, fmt.Errorf("%s (%s) made an invalid move in %s vs %s: %w",
+				player, game.ActiveColor, white, black, err)
+		}
+	}
+
+	outcome := game.Outcome()
+	return GameResult{
+		White:    white,
+		Black:    black,
+		Seed:     seed,
+		Result:   outcome.Result,
+		Reason:   outcome.Reason,
+		Moves:    game.Moves,
+		Duration: time.Since(start),
+		Scores:   lo.Ternary(searched, scores, nil),
+	}, nil
+}
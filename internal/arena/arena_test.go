@@ -0,0 +1,169 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package arena
+
+import (
+	"context"
+	"testing"
+
+	"cragspider-go/internal/ai"
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule(t *testing.T) {
+	t.Run("round robin plays every pairing with alternating colors", func(t *testing.T) {
+		matches, err := schedule(Config{Players: []string{"a", "b", "c"}, Mode: RoundRobin, Games: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []match{
+			{round: 0, white: "a", black: "b"},
+			{round: 1, white: "b", black: "a"},
+			{round: 2, white: "a", black: "c"},
+			{round: 3, white: "c", black: "a"},
+			{round: 4, white: "b", black: "c"},
+			{round: 5, white: "c", black: "b"},
+		}, matches)
+	})
+
+	t.Run("gauntlet plays the challenger against everyone", func(t *testing.T) {
+		matches, err := schedule(Config{Players: []string{"a", "b", "c"}, Mode: Gauntlet, Challenger: "b", Games: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []match{
+			{round: 0, white: "b", black: "a"},
+			{round: 1, white: "b", black: "c"},
+		}, matches)
+	})
+
+	errorTests := []struct {
+		name string
+		cfg  Config
+	}{
+		{name: "no games", cfg: Config{Players: []string{"a", "b"}, Mode: RoundRobin}},
+		{name: "round robin of one", cfg: Config{Players: []string{"a"}, Mode: RoundRobin, Games: 1}},
+		{name: "gauntlet without challenger", cfg: Config{Players: []string{"a", "b"}, Mode: Gauntlet, Games: 1}},
+		{name: "gauntlet with only the challenger", cfg: Config{Players: []string{"a"}, Mode: Gauntlet, Challenger: "a", Games: 1}},
+		{name: "unknown mode", cfg: Config{Players: []string{"a", "b"}, Mode: "swiss", Games: 1}},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := schedule(tt.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPlayGame(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+
+	game, err := PlayGame(context.Background(), gameConfig, "doofus", "doofus", 7, 0)
+	require.NoError(t, err)
+	assert.NotEqual(t, core.InProgress, game.Result, "game should be finished")
+	assert.NotEmpty(t, game.Reason, "game should say why it ended")
+	assert.LessOrEqual(t, game.Length(), gameConfig.Rules.MaxMoves, "game should respect the move limit")
+	assert.Equal(t, core.White, game.Moves[0].Color, "white should move first")
+}
+
+func TestRun(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+
+	cfg := Config{
+		Players:  []string{"doofus", "doofus"},
+		Mode:     RoundRobin,
+		Games:    4,
+		MaxMoves: 20,
+		Seed:     3,
+	}
+	run := func(workers int) *Results {
+		cfg.Workers = workers
+		var progress []int
+		cfg.Progress = func(done, total int, _ GameResult) {
+			assert.Equal(t, 4, total)
+			progress = append(progress, done)
+		}
+		results, err := Run(context.Background(), cfg, gameConfig)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4}, progress, "progress should count every game")
+		return results
+	}
+
+	serial := run(1)
+	parallel := run(4)
+	require.Len(t, serial.Games, 4)
+	for i := range serial.Games {
+		assert.Equal(t, i, serial.Games[i].Round, "games should be in round order")
+		assert.Equal(t, int64(3+i), serial.Games[i].Seed, "games should be seeded by round")
+		assert.LessOrEqual(t, serial.Games[i].Length(), 20, "games should respect the arena's move limit")
+		assert.Equal(t, serial.Games[i].Moves, parallel.Games[i].Moves, "workers should not change the games")
+		assert.Equal(t, serial.Games[i].Result, parallel.Games[i].Result, "workers should not change the results")
+	}
+	assert.Equal(t, 200, gameConfig.Rules.MaxMoves, "the shared game config should be untouched")
+}
+
+func TestRun_UnknownPlayer(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+
+	_, err = Run(context.Background(), Config{
+		Players: []string{"doofus", "nonexistent"},
+		Mode:    RoundRobin,
+		Games:   2,
+		Workers: 2,
+	}, gameConfig)
+	assert.Error(t, err)
+}
+
+func TestRun_Untimed(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+
+	cfg := Config{
+		Players:  []string{"doofus", "strategist"},
+		Mode:     RoundRobin,
+		Games:    2,
+		Workers:  2,
+		MaxMoves: 6,
+		Untimed:  true,
+		Seed:     5,
+	}
+	first, err := Run(context.Background(), cfg, gameConfig)
+	require.NoError(t, err)
+	second, err := Run(context.Background(), cfg, gameConfig)
+	require.NoError(t, err)
+	for i := range first.Games {
+		assert.Equal(t, first.Games[i].Moves, second.Games[i].Moves, "untimed games should depend only on the seed")
+	}
+}
+
+func TestPlayConfigs(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+
+	custom := &ai.AIPlayerConfig{Name: "custom", Strategy: ai.RandomStrategy}
+	game, err := PlayConfigs(context.Background(), gameConfig, custom, custom, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "custom", game.White)
+	assert.NotEqual(t, core.InProgress, game.Result)
+	assert.Nil(t, game.Scores, "games without searches have no scores")
+}
+
+func TestPlayConfigs_RecordsSearchScores(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+
+	searcher := &ai.AIPlayerConfig{Name: "searcher", Strategy: ai.AlphaBetaStrategy, Search: ai.SearchLimits{MaxDepth: 1}}
+	random := &ai.AIPlayerConfig{Name: "random", Strategy: ai.RandomStrategy}
+	game, err := PlayConfigs(context.Background(), gameConfig, random, searcher, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, game.Scores, game.Length(), "every move should have a place for its score")
+	for i, move := range game.Moves {
+		if move.Color == core.White {
+			assert.Nil(t, game.Scores[i], "the random player's moves have no score")
+		} else {
+			assert.NotNil(t, game.Scores[i], "the searcher's moves have a score")
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package arena
+
+import (
+	"cmp"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/rating"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"text/tabwriter"
+)
+
+// Record is a tally of wins, draws and losses.
+type Record struct {
+	Wins, Draws, Losses int
+}
+
+// Games returns the number of games in the record.
+func (r Record) Games() int {
+	return r.Wins + r.Draws + r.Losses
+}
+
+// Score returns the points scored, with a win worth one point and a draw worth half.
+func (r Record) Score() float64 {
+	return float64(r.Wins) + float64(r.Draws)/2
+}
+
+// add tallies one game's result from the point of view of the player with the specified color.
+func (r *Record) add(result core.Result, color core.Color) {
+	winner, won := result.Winner()
+	switch {
+	case !won:
+		r.Draws++
+	case winner == color:
+		r.Wins++
+	default:
+		r.Losses++
+	}
+}
+
+// PairingStats is how one player did against another over all of their games.
+type PairingStats struct {
+	Player, Opponent string
+	Record
+	TotalMoves int
+}
+
+// AverageLength returns the average number of moves in the pairing's games.
+func (p PairingStats) AverageLength() float64 {
+	if p.Games() == 0 {
+		return 0
+	}
+	return float64(p.TotalMoves) / float64(p.Games())
+}
+
+// Standing is how one player did against the whole field.
+type Standing struct {
+	Player string
+	Record
+}
+
+// Pairings returns the stats for each pair of players that met, from the point of view of whichever of the two
+// comes first in the player list. Pairings are in the order they were scheduled.
+func (r *Results) Pairings() []PairingStats {
+	var pairings []PairingStats
+	index := make(map[[2]string]int)
+	for _, game := range r.Games {
+		player, opponent := game.White, game.Black
+		color := core.White
+		if slices.Index(r.Players, opponent) < slices.Index(r.Players, player) {
+			player, opponent = opponent, player
+			color = core.Black
+		}
+		key := [2]string{player, opponent}
+		i, ok := index[key]
+		if !ok {
+			i = len(pairings)
+			index[key] = i
+			pairings = append(pairings, PairingStats{Player: player, Opponent: opponent})
+		}
+		pairings[i].add(game.Result, color)
+		pairings[i].TotalMoves += game.Length()
+	}
+	return pairings
+}
+
+// Standings returns each player's record against the field, best score first. Ties keep the player list's order.
+func (r *Results) Standings() []Standing {
+	records := make(map[string]*Record)
+	for _, game := range r.Games {
+		for _, color := range []core.Color{core.White, core.Black} {
+			player := game.White
+			if color == core.Black {
+				player = game.Black
+			}
+			if records[player] == nil {
+				records[player] = &Record{}
+			}
+			records[player].add(game.Result, color)
+		}
+	}
+
+	var standings []Standing
+	for _, player := range r.Players {
+		if record, ok := records[player]; ok {
+			standings = append(standings, Standing{Player: player, Record: *record})
+		}
+	}
+	slices.SortStableFunc(standings, func(a, b Standing) int {
+		return -cmp.Compare(a.Score()/float64(a.Games()), b.Score()/float64(b.Games()))
+	})
+	return standings
+}
+
+// RatingEstimates returns each player's Elo rating as estimated from the tournament's games, with a 95% confidence
+// interval, best first.
+func (r *Results) RatingEstimates() []rating.Estimate {
+	games := make([]rating.GameResult, len(r.Games))
+	for i, game := range r.Games {
+		games[i] = rating.GameResult{White: game.White, Black: game.Black, Result: game.Result}
+	}
+	return rating.EstimateRatings(games)
+}
+
+// WriteTables writes the standings, the estimated ratings and the per-pairing win/draw/loss table to w as aligned
+// text.
+func (r *Results) WriteTables(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Player\tGames\tWins\tDraws\tLosses\tScore\t")
+	for _, s := range r.Standings() {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%.1f%%\t\n",
+			s.Player, s.Games(), s.Wins, s.Draws, s.Losses, 100*s.Score()/float64(s.Games()))
+	}
+	fmt.Fprintln(tw, "\t\t\t\t\t\t")
+	fmt.Fprintln(tw, "Player\tElo\t95% low\t95% high\t\t\t")
+	for _, e := range r.RatingEstimates() {
+		fmt.Fprintf(tw, "%s\t%.0f\t%.0f\t%.0f\t\t\t\n", e.Key, e.Elo, e.Low, e.High)
+	}
+	fmt.Fprintln(tw, "\t\t\t\t\t\t")
+	fmt.Fprintln(tw, "Player\tOpponent\tWins\tDraws\tLosses\tAvg moves\t")
+	for _, p := range r.Pairings() {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%.1f\t\n",
+			p.Player, p.Opponent, p.Wins, p.Draws, p.Losses, p.AverageLength())
+	}
+	return tw.Flush()
+}
+
+// WriteFile writes the results to the named file as JSON.
+func (r *Results) WriteFile(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal results: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+	return nil
+}
+
+// ReadResults reads results written by WriteFile.
+func ReadResults(path string) (*Results, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results: %w", err)
+	}
+	var results Results
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal results: %w", err)
+	}
+	return &results, nil
+}
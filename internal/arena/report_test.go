@@ -0,0 +1,81 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package arena
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testResults returns a small tournament: a beats b twice, a and c draw, and c beats b as Black.
+func testResults() *Results {
+	moves := func(n int) []core.MoveRecord { return make([]core.MoveRecord, n) }
+	return &Results{
+		Mode:    RoundRobin,
+		Players: []string{"a", "b", "c"},
+		Games: []GameResult{
+			{Round: 0, White: "a", Black: "b", Result: core.WhiteWins, Moves: moves(10)},
+			{Round: 1, White: "b", Black: "a", Result: core.BlackWins, Moves: moves(20)},
+			{Round: 2, White: "a", Black: "c", Result: core.Draw, Moves: moves(30)},
+			{Round: 3, White: "b", Black: "c", Result: core.BlackWins, Moves: moves(40)},
+		},
+	}
+}
+
+func TestResults_Pairings(t *testing.T) {
+	pairings := testResults().Pairings()
+	require.Len(t, pairings, 3)
+
+	assert.Equal(t, "a", pairings[0].Player)
+	assert.Equal(t, "b", pairings[0].Opponent)
+	assert.Equal(t, Record{Wins: 2}, pairings[0].Record)
+	assert.Equal(t, 15.0, pairings[0].AverageLength())
+
+	assert.Equal(t, Record{Draws: 1}, pairings[1].Record, "a vs c was drawn")
+	assert.Equal(t, "b", pairings[2].Player)
+	assert.Equal(t, Record{Losses: 1}, pairings[2].Record, "b lost to c")
+}
+
+func TestResults_Standings(t *testing.T) {
+	standings := testResults().Standings()
+	require.Len(t, standings, 3)
+	assert.Equal(t, Standing{Player: "a", Record: Record{Wins: 2, Draws: 1}}, standings[0])
+	assert.Equal(t, Standing{Player: "c", Record: Record{Wins: 1, Draws: 1}}, standings[1])
+	assert.Equal(t, Standing{Player: "b", Record: Record{Losses: 3}}, standings[2])
+	assert.Equal(t, 2.5, standings[0].Score())
+}
+
+func TestResults_RatingEstimates(t *testing.T) {
+	estimates := testResults().RatingEstimates()
+	require.Len(t, estimates, 3)
+	assert.Equal(t, []string{"a", "c", "b"}, []string{estimates[0].Key, estimates[1].Key, estimates[2].Key})
+	assert.Equal(t, 3, estimates[0].Games)
+	for _, e := range estimates {
+		assert.Less(t, e.Low, e.Elo)
+		assert.Greater(t, e.High, e.Elo)
+	}
+}
+
+func TestResults_WriteTables(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testResults().WriteTables(&buf))
+	assert.Contains(t, buf.String(), "Avg moves")
+	assert.Contains(t, buf.String(), "95% low")
+	assert.Contains(t, buf.String(), "83.3%")
+}
+
+func TestResults_FileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	results := testResults()
+	require.NoError(t, results.WriteFile(path))
+
+	read, err := ReadResults(path)
+	require.NoError(t, err)
+	assert.Equal(t, results, read)
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package audio
+
+import "path/filepath"
+
+// DefaultDir is where the game's sound and music files are.
+const DefaultDir = "assets/audio"
+
+// Backend loads and plays sound and music files, identified by their paths. The game plays through raylib, and tests
+// through a fake that records what it's asked to do.
+type Backend interface {
+	// LoadSound loads a sound file so that it can be played, or returns why it can't.
+	LoadSound(path string) error
+	// PlaySound plays a loaded sound at the volume, from 0 to 1.
+	PlaySound(path string, volume float32)
+	// LoadMusic opens a music file for streaming, or returns why it can't.
+	LoadMusic(path string) error
+	// PlayMusic starts the loaded music from the beginning at the volume, from 0 to 1.
+	PlayMusic(path string, volume float32)
+	// SetMusicVolume changes the volume of the music.
+	SetMusicVolume(path string, volume float32)
+	// UpdateMusic keeps the music streaming. It must be called every frame while music plays.
+	UpdateMusic(path string)
+	// StopMusic stops the music.
+	StopMusic(path string)
+	// Close unloads everything that has been loaded.
+	Close()
+}
+
+// Manager plays the sounds for game events and the music for games, at the volume set for each category of sound.
+// Sound files are loaded when they're first needed; files that can't be loaded are reported once and then skipped,
+// so that a missing sound never stops the game. A nil Manager plays nothing.
+type Manager struct {
+	backend Backend
+	config  *AudioConfig
+	dir     string
+	sounds  SoundSet         // The sounds for the variant being played
+	loaded  map[string]error // Every file that has been loaded, with why it couldn't be if it couldn't
+	volumes map[Category]float32
+	muted   bool
+	music   string // The path of the music playing, if there is any
+	warn    func(format string, args ...any)
+}
+
+// NewManager returns a manager that plays the configured sounds from the files in dir through the backend, starting
+// with the standard game's sounds. Problems loading files are reported to warn.
+func NewManager(backend Backend, config *AudioConfig, dir string, warn func(format string, args ...any)) *Manager {
+	m := &Manager{
+		backend: backend,
+		config:  config,
+		dir:     dir,
+		loaded:  make(map[string]error),
+		volumes: make(map[Category]float32),
+		warn:    warn,
+	}
+	for category, volume := range config.Volumes {
+		m.volumes[category] = volume
+	}
+	m.sounds = config.ForVariant("")
+	return m
+}
+
+// UseVariant switches to the sounds for the named variant.
+func (m *Manager) UseVariant(variant string) {
+	if m == nil {
+		return
+	}
+	m.sounds = m.config.ForVariant(variant)
+}
+
+// Play plays the sound for the event, unless the sound is muted or there's no sound for it.
+func (m *Manager) Play(event Event) {
+	if m == nil || m.muted {
+		return
+	}
+	sound, ok := m.sounds.Sounds[event]
+	if !ok || sound.File == "" {
+		return
+	}
+	path := filepath.Join(m.dir, sound.File)
+	if !m.load(path, m.backend.LoadSound) {
+		return
+	}
+	if volume := m.Volume(sound.Category) * sound.level(); volume > 0 {
+		m.backend.PlaySound(path, volume)
+	}
+}
+
+// PlayMusic starts the music for the variant being played, if it has any, stopping any other music.
+func (m *Manager) PlayMusic() {
+	if m == nil || m.sounds.Music == "" {
+		return
+	}
+	path := filepath.Join(m.dir, m.sounds.Music)
+	if path == m.music {
+		return
+	}
+	m.StopMusic()
+	if !m.load(path, m.backend.LoadMusic) {
+		return
+	}
+	m.music = path
+	m.backend.PlayMusic(path, m.musicVolume())
+}
+
+// StopMusic stops the music, if there's any playing.
+func (m *Manager) StopMusic() {
+	if m == nil || m.music == "" {
+		return
+	}
+	m.backend.StopMusic(m.music)
+	m.music = ""
+}
+
+// Update keeps the music playing. It's called every frame.
+func (m *Manager) Update() {
+	if m == nil || m.music == "" {
+		return
+	}
+	m.backend.UpdateMusic(m.music)
+}
+
+// load loads the file with the loader the first time it's needed, and returns whether it can be played.
+func (m *Manager) load(path string, loader func(string) error) bool {
+	err, tried := m.loaded[path]
+	if !tried {
+		err = loader(path)
+		m.loaded[path] = err
+		if err != nil && m.warn != nil {
+			m.warn("sound %s unavailable: %v", path, err)
+		}
+	}
+	return err == nil
+}
+
+// Volume returns how loud the category of sound plays, from 0 to 1. Categories start at full volume unless they're
+// configured otherwise.
+func (m *Manager) Volume(category Category) float32 {
+	if m == nil {
+		return 0
+	}
+	volume, ok := m.volumes[category]
+	if !ok {
+		return 1
+	}
+	return volume
+}
+
+// SetVolume sets how loud the category of sound plays, from 0 to 1.
+func (m *Manager) SetVolume(category Category, volume float32) {
+	if m == nil {
+		return
+	}
+	m.volumes[category] = min(max(volume, 0), 1)
+	if category == Music {
+		m.applyMusicVolume()
+	}
+}
+
+// Muted returns true if all sound is muted.
+func (m *Manager) Muted() bool {
+	return m != nil && m.muted
+}
+
+// SetMuted mutes or unmutes all sound. The music carries on silently while muted.
+func (m *Manager) SetMuted(muted bool) {
+	if m == nil {
+		return
+	}
+	m.muted = muted
+	m.applyMusicVolume()
+}
+
+// musicVolume returns how loud the music plays.
+func (m *Manager) musicVolume() float32 {
+	if m.muted {
+		return 0
+	}
+	return m.Volume(Music)
+}
+
+// applyMusicVolume sets the music playing to the volume it should be at.
+func (m *Manager) applyMusicVolume() {
+	if m.music != "" {
+		m.backend.SetMusicVolume(m.music, m.musicVolume())
+	}
+}
+
+// Close stops the music and unloads every sound.
+func (m *Manager) Close() {
+	if m == nil {
+		return
+	}
+	m.StopMusic()
+	m.backend.Close()
+	m.loaded = make(map[string]error)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package audio
+
+import (
+	_ "embed"
+	"fmt"
+	"maps"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Event is something that happens in a game that a sound is played for.
+type Event string
+
+const (
+	// Select is played when a player picks up one of their pieces.
+	Select Event = "select"
+	// Move is played when a piece moves without capturing.
+	Move Event = "move"
+	// Capture is played when a piece captures another.
+	Capture Event = "capture"
+	// InvalidMove is played when a player tries a move that isn't allowed.
+	InvalidMove Event = "invalid_move"
+	// GameOver is played when the game ends.
+	GameOver Event = "game_over"
+)
+
+// Category is a group of sounds whose volume is set together.
+type Category string
+
+const (
+	// Interface sounds answer what the player does, like selecting a piece.
+	Interface Category = "interface"
+	// Effects sounds are what happens on the board, like moves and captures.
+	Effects Category = "effects"
+	// Music is the music played during a game.
+	Music Category = "music"
+)
+
+// Sound is a sound file and how loud it's played.
+type Sound struct {
+	File     string   `yaml:"file"`
+	Category Category `yaml:"category"`
+	Volume   float32  `yaml:"volume,omitempty"` // Relative to the category's volume; zero means full volume
+}
+
+// level returns how loud the sound is relative to its category.
+func (s Sound) level() float32 {
+	if s.Volume <= 0 {
+		return 1
+	}
+	return s.Volume
+}
+
+// SoundSet is the sound played for each event, and the music for a game.
+type SoundSet struct {
+	Sounds map[Event]Sound `yaml:"sounds"`
+	Music  string          `yaml:"music,omitempty"` // File streamed during a game, if any
+}
+
+// AudioConfig holds the game's sounds, with the changes each variant makes to them, and how loud each category of
+// sound is to start with.
+type AudioConfig struct {
+	Volumes  map[Category]float32 `yaml:"volumes"`
+	SoundSet `yaml:",inline"`
+	Variants map[string]SoundSet `yaml:"variants"`
+}
+
+var (
+	audioConfig     *AudioConfig
+	audioConfigOnce sync.Once
+)
+
+//go:embed audio.yml
+var audioConfigData []byte
+
+// GetAudioConfig loads and returns the audio configuration from the embedded YAML file.
+// It uses sync.Once to ensure the configuration is only loaded once.
+func GetAudioConfig() (*AudioConfig, error) {
+	var loadErr error
+
+	audioConfigOnce.Do(func() {
+		var cfg AudioConfig
+		if err := yaml.Unmarshal(audioConfigData, &cfg); err != nil {
+			loadErr = fmt.Errorf("failed to unmarshal audio config: %w", err)
+			return
+		}
+
+		audioConfig = &cfg
+	})
+
+	if loadErr != nil {
+		return nil, loadErr
+	}
+
+	return audioConfig, nil
+}
+
+// ForVariant returns the sounds for a game of the named variant: the standard sounds, with any the variant overrides
+// replaced.
+func (c *AudioConfig) ForVariant(variant string) SoundSet {
+	set := SoundSet{Sounds: maps.Clone(c.Sounds), Music: c.Music}
+	override, ok := c.Variants[variant]
+	if !ok {
+		return set
+	}
+	if set.Sounds == nil {
+		set.Sounds = make(map[Event]Sound)
+	}
+	maps.Copy(set.Sounds, override.Sounds)
+	if override.Music != "" {
+		set.Music = override.Music
+	}
+	return set
+}
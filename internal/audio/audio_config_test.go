@@ -0,0 +1,45 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package audio
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAudioConfig(t *testing.T) {
+	config, err := GetAudioConfig()
+	require.NoError(t, err)
+
+	for _, event := range []Event{Select, Move, Capture, InvalidMove, GameOver} {
+		sound, ok := config.Sounds[event]
+		require.True(t, ok, "no sound for %s", event)
+		assert.NotEmpty(t, sound.Category, "%s has no category", event)
+		assert.FileExists(t, filepath.Join("..", "..", DefaultDir, sound.File))
+	}
+	for variant, set := range config.Variants {
+		for event, sound := range set.Sounds {
+			assert.FileExists(t, filepath.Join("..", "..", DefaultDir, sound.File), "%s %s", variant, event)
+		}
+		if set.Music != "" {
+			_, err := os.Stat(filepath.Join("..", "..", DefaultDir, set.Music))
+			assert.NoError(t, err, "%s music", variant)
+		}
+	}
+}
+
+func TestAudioConfig_ForVariant(t *testing.T) {
+	config, err := GetAudioConfig()
+	require.NoError(t, err)
+
+	standard := config.ForVariant("standard")
+	phalanx := config.ForVariant("phalanx")
+	assert.Equal(t, "capture.wav", standard.Sounds[Capture].File)
+	assert.Equal(t, "capture_shield.wav", phalanx.Sounds[Capture].File)
+	assert.Equal(t, standard.Sounds[Move], phalanx.Sounds[Move])
+	assert.Equal(t, "capture.wav", config.Sounds[Capture].File, "overrides don't change the standard sounds")
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// played is a sound or music the fake backend was asked to play, and how loud.
+type played struct {
+	path   string
+	volume float32
+}
+
+// fakeBackend records what it's asked to play, and fails to load the files it's told are missing.
+type fakeBackend struct {
+	missing     map[string]bool
+	loads       []string
+	sounds      []played
+	music       []played
+	musicVolume float32
+	updates     int
+	stopped     []string
+	closed      bool
+}
+
+func newFakeBackend(missing ...string) *fakeBackend {
+	b := &fakeBackend{missing: make(map[string]bool)}
+	for _, file := range missing {
+		b.missing[filepath.Join("sfx", file)] = true
+	}
+	return b
+}
+
+func (b *fakeBackend) load(path string) error {
+	b.loads = append(b.loads, path)
+	if b.missing[path] {
+		return errors.New("no such file")
+	}
+	return nil
+}
+
+func (b *fakeBackend) LoadSound(path string) error { return b.load(path) }
+func (b *fakeBackend) LoadMusic(path string) error { return b.load(path) }
+func (b *fakeBackend) PlaySound(path string, volume float32) {
+	b.sounds = append(b.sounds, played{path, volume})
+}
+func (b *fakeBackend) PlayMusic(path string, volume float32) {
+	b.music = append(b.music, played{path, volume})
+	b.musicVolume = volume
+}
+func (b *fakeBackend) SetMusicVolume(_ string, volume float32) { b.musicVolume = volume }
+func (b *fakeBackend) UpdateMusic(string)                      { b.updates++ }
+func (b *fakeBackend) StopMusic(path string)                   { b.stopped = append(b.stopped, path) }
+func (b *fakeBackend) Close()                                  { b.closed = true }
+
+// testConfig has a sound for every event, with quieter interface sounds, and a variant with its own capture sound
+// and music.
+func testConfig() *AudioConfig {
+	return &AudioConfig{
+		Volumes: map[Category]float32{Interface: 0.5, Effects: 1, Music: 0.4},
+		SoundSet: SoundSet{
+			Sounds: map[Event]Sound{
+				Select:      {File: "select.wav", Category: Interface},
+				Move:        {File: "move.wav", Category: Effects},
+				Capture:     {File: "capture.wav", Category: Effects},
+				InvalidMove: {File: "invalid.wav", Category: Interface, Volume: 0.5},
+				GameOver:    {File: "over.wav", Category: Effects},
+			},
+		},
+		Variants: map[string]SoundSet{
+			"siege": {
+				Sounds: map[Event]Sound{Capture: {File: "crash.wav", Category: Effects, Volume: 0.8}},
+				Music:  "drums.ogg",
+			},
+		},
+	}
+}
+
+func sfx(file string) string {
+	return filepath.Join("sfx", file)
+}
+
+func TestManager_Play(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant string
+		event   Event
+		want    played
+	}{
+		{"select plays at the interface volume", "", Select, played{sfx("select.wav"), 0.5}},
+		{"move plays at the effects volume", "", Move, played{sfx("move.wav"), 1}},
+		{"sound's own volume scales its category's", "", InvalidMove, played{sfx("invalid.wav"), 0.25}},
+		{"standard capture", "", Capture, played{sfx("capture.wav"), 1}},
+		{"variant overrides capture", "siege", Capture, played{sfx("crash.wav"), 0.8}},
+		{"variant keeps the sounds it doesn't override", "siege", GameOver, played{sfx("over.wav"), 1}},
+		{"unknown variant plays the standard sounds", "nowhere", Capture, played{sfx("capture.wav"), 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newFakeBackend()
+			m := NewManager(backend, testConfig(), "sfx", nil)
+			m.UseVariant(tt.variant)
+			m.Play(tt.event)
+			require.Len(t, backend.sounds, 1)
+			assert.Equal(t, tt.want.path, backend.sounds[0].path)
+			assert.InDelta(t, tt.want.volume, backend.sounds[0].volume, 1e-6)
+		})
+	}
+}
+
+func TestManager_PlayLoadsEachSoundOnce(t *testing.T) {
+	backend := newFakeBackend()
+	m := NewManager(backend, testConfig(), "sfx", nil)
+	m.Play(Move)
+	m.Play(Move)
+	m.Play(Select)
+	assert.Equal(t, []string{sfx("move.wav"), sfx("select.wav")}, backend.loads)
+	assert.Len(t, backend.sounds, 3)
+}
+
+func TestManager_MissingSoundIsSkipped(t *testing.T) {
+	backend := newFakeBackend("capture.wav")
+	var warnings []string
+	warn := func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+	m := NewManager(backend, testConfig(), "sfx", warn)
+
+	m.Play(Capture)
+	m.Play(Capture)
+	m.Play(Move)
+
+	assert.Equal(t, []played{{sfx("move.wav"), 1}}, backend.sounds)
+	require.Len(t, warnings, 1, "a missing file is only reported once")
+	assert.Contains(t, warnings[0], "capture.wav")
+}
+
+func TestManager_EventWithoutSound(t *testing.T) {
+	config := testConfig()
+	delete(config.Sounds, Select)
+	backend := newFakeBackend()
+	m := NewManager(backend, config, "sfx", nil)
+	m.Play(Select)
+	assert.Empty(t, backend.loads)
+	assert.Empty(t, backend.sounds)
+}
+
+func TestManager_Volume(t *testing.T) {
+	backend := newFakeBackend()
+	m := NewManager(backend, testConfig(), "sfx", nil)
+	assert.Equal(t, float32(0.5), m.Volume(Interface))
+
+	m.SetVolume(Interface, 0.2)
+	m.Play(Select)
+	require.Len(t, backend.sounds, 1)
+	assert.InDelta(t, 0.2, backend.sounds[0].volume, 1e-6)
+
+	m.SetVolume(Effects, 3)
+	assert.Equal(t, float32(1), m.Volume(Effects), "volumes are clamped")
+
+	m.SetVolume(Effects, 0)
+	m.Play(Move)
+	assert.Len(t, backend.sounds, 1, "silent categories aren't played")
+}
+
+func TestManager_Mute(t *testing.T) {
+	backend := newFakeBackend()
+	m := NewManager(backend, testConfig(), "sfx", nil)
+	m.UseVariant("siege")
+	m.PlayMusic()
+	require.Len(t, backend.music, 1)
+	assert.InDelta(t, 0.4, backend.musicVolume, 1e-6)
+
+	m.SetMuted(true)
+	assert.True(t, m.Muted())
+	m.Play(Move)
+	assert.Empty(t, backend.sounds)
+	assert.Zero(t, backend.musicVolume, "music carries on silently")
+
+	m.SetMuted(false)
+	m.Play(Move)
+	assert.Len(t, backend.sounds, 1)
+	assert.InDelta(t, 0.4, backend.musicVolume, 1e-6)
+}
+
+func TestManager_Music(t *testing.T) {
+	backend := newFakeBackend()
+	m := NewManager(backend, testConfig(), "sfx", nil)
+
+	m.PlayMusic()
+	assert.Empty(t, backend.music, "the standard game has no music")
+
+	m.UseVariant("siege")
+	m.PlayMusic()
+	m.PlayMusic()
+	assert.Equal(t, []played{{sfx("drums.ogg"), 0.4}}, backend.music, "music already playing carries on")
+
+	m.Update()
+	m.Update()
+	assert.Equal(t, 2, backend.updates)
+
+	m.SetVolume(Music, 0.1)
+	assert.InDelta(t, 0.1, backend.musicVolume, 1e-6)
+
+	m.StopMusic()
+	m.Update()
+	assert.Equal(t, []string{sfx("drums.ogg")}, backend.stopped)
+	assert.Equal(t, 2, backend.updates, "stopped music isn't streamed")
+
+	m.Close()
+	assert.True(t, backend.closed)
+}
+
+func TestManager_Nil(t *testing.T) {
+	var m *Manager
+	assert.NotPanics(t, func() {
+		m.UseVariant("siege")
+		m.Play(Capture)
+		m.PlayMusic()
+		m.Update()
+		m.SetVolume(Effects, 0.5)
+		m.SetMuted(true)
+		m.StopMusic()
+		m.Close()
+	})
+	assert.False(t, m.Muted())
+	assert.Zero(t, m.Volume(Effects))
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package audio
+
+import (
+	"fmt"
+	"os"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// RaylibBackend plays sounds through raylib's audio device, which must be initialized before anything is loaded.
+type RaylibBackend struct {
+	sounds map[string]rl.Sound
+	music  map[string]rl.Music
+}
+
+// NewRaylibBackend returns a backend that plays through raylib.
+func NewRaylibBackend() *RaylibBackend {
+	return &RaylibBackend{sounds: make(map[string]rl.Sound), music: make(map[string]rl.Music)}
+}
+
+// LoadSound loads a sound file into memory.
+func (b *RaylibBackend) LoadSound(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to find sound: %w", err)
+	}
+	sound := rl.LoadSound(path)
+	if !rl.IsSoundValid(sound) {
+		return fmt.Errorf("failed to load sound %s", path)
+	}
+	b.sounds[path] = sound
+	return nil
+}
+
+// PlaySound plays a loaded sound at the volume.
+func (b *RaylibBackend) PlaySound(path string, volume float32) {
+	sound, ok := b.sounds[path]
+	if !ok {
+		return
+	}
+	rl.SetSoundVolume(sound, volume)
+	rl.PlaySound(sound)
+}
+
+// LoadMusic opens a music file for streaming.
+func (b *RaylibBackend) LoadMusic(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to find music: %w", err)
+	}
+	music := rl.LoadMusicStream(path)
+	if !rl.IsMusicValid(music) {
+		return fmt.Errorf("failed to load music %s", path)
+	}
+	b.music[path] = music
+	return nil
+}
+
+// PlayMusic starts the loaded music from the beginning at the volume.
+func (b *RaylibBackend) PlayMusic(path string, volume float32) {
+	music, ok := b.music[path]
+	if !ok {
+		return
+	}
+	rl.SetMusicVolume(music, volume)
+	rl.PlayMusicStream(music)
+}
+
+// SetMusicVolume changes the volume of the music.
+func (b *RaylibBackend) SetMusicVolume(path string, volume float32) {
+	if music, ok := b.music[path]; ok {
+		rl.SetMusicVolume(music, volume)
+	}
+}
+
+// UpdateMusic streams the next part of the music.
+func (b *RaylibBackend) UpdateMusic(path string) {
+	if music, ok := b.music[path]; ok {
+		rl.UpdateMusicStream(music)
+	}
+}
+
+// StopMusic stops the music.
+func (b *RaylibBackend) StopMusic(path string) {
+	if music, ok := b.music[path]; ok {
+		rl.StopMusicStream(music)
+	}
+}
+
+// Close unloads every sound and music stream.
+func (b *RaylibBackend) Close() {
+	for path, sound := range b.sounds {
+		rl.UnloadSound(sound)
+		delete(b.sounds, path)
+	}
+	for path, music := range b.music {
+		rl.UnloadMusicStream(music)
+		delete(b.music, path)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Package book holds opening books: for positions early in the game, the moves worth playing there and how much
+// each is favored. AI players look their position up in a book before searching, so that they open well without
+// thinking and don't open the same way every game.
+package book
+
+import (
+	"cmp"
+	"cragspider-go/internal/core"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// Candidate is a move the book suggests for a position, with how strongly it's favored.
+type Candidate struct {
+	From   core.Position `json:"from"`
+	To     core.Position `json:"to"`
+	Weight float64       `json:"weight"`
+}
+
+// Book maps positions, by their hash with the side to move, to the moves to play there. Since it's keyed by
+// position rather than by the moves that led there, it knows a position however it was reached.
+type Book struct {
+	positions map[uint64][]Candidate
+}
+
+// New returns an empty book.
+func New() *Book {
+	return &Book{positions: make(map[uint64][]Candidate)}
+}
+
+// Len returns the number of positions in the book.
+func (b *Book) Len() int {
+	return len(b.positions)
+}
+
+// Add adds weight to the move from one square to another in the board's position with the specified color to move,
+// adding the move to the book if it isn't there yet.
+func (b *Book) Add(board *core.Board, toMove core.Color, from, to core.Position, weight float64) {
+	key := board.Hash(toMove)
+	candidates := b.positions[key]
+	for i := range candidates {
+		if candidates[i].From == from && candidates[i].To == to {
+			candidates[i].Weight += weight
+			return
+		}
+	}
+	b.positions[key] = append(candidates, Candidate{From: from, To: to, Weight: weight})
+}
+
+// Candidates returns the book's moves for the board's position with the specified color to move, most favored
+// first. A position that isn't in the book has none.
+func (b *Book) Candidates(board *core.Board, toMove core.Color) []Candidate {
+	candidates := slices.Clone(b.positions[board.Hash(toMove)])
+	sortCandidates(candidates)
+	return candidates
+}
+
+// Choose picks one of the book's moves for the board's position with the specified color to move, or returns false
+// if the book has nothing to suggest. Variety is how far the choice strays from the most favored move: at zero it's
+// always played, at one moves are picked in proportion to their weight, and above one the choice is flatter still.
+// Moves that aren't valid on the board are passed over.
+func (b *Book) Choose(board *core.Board, toMove core.Color, variety float64, rng *rand.Rand) (*core.Action, bool) {
+	var actions []core.Action
+	var weights []float64
+	for _, c := range b.Candidates(board, toMove) {
+		piece := board.GetPieceAt(c.From)
+		if piece == nil || piece.Color != toMove || c.Weight <= 0 {
+			continue
+		}
+		action := core.Action{Piece: piece, Move: core.Move{c.To[0] - c.From[0], c.To[1] - c.From[1]}}
+		if _, err := board.MovePiece(piece, c.From, action.Move); err != nil {
+			continue
+		}
+		actions = append(actions, action)
+		weights = append(weights, c.Weight)
+	}
+	if len(actions) == 0 {
+		return nil, false
+	}
+	if variety <= 0 {
+		return &actions[0], true
+	}
+
+	var total float64
+	for i, weight := range weights {
+		weights[i] = math.Pow(weight, 1/variety)
+		total += weights[i]
+	}
+	roll := total * rng.Float64()
+	for i, weight := range weights {
+		roll -= weight
+		if roll < 0 {
+			return &actions[i], true
+		}
+	}
+	return &actions[0], true
+}
+
+// bookFile is how a book is written to disk. Keys are written in hex, since JSON numbers can't hold every uint64.
+type bookFile struct {
+	Positions []bookPosition `json:"positions"`
+}
+
+type bookPosition struct {
+	Key   string      `json:"key"`
+	Moves []Candidate `json:"moves"`
+}
+
+// MarshalJSON writes the book with its positions in key order, so that the same book always writes the same file.
+func (b *Book) MarshalJSON() ([]byte, error) {
+	var file bookFile
+	for _, key := range slices.Sorted(maps.Keys(b.positions)) {
+		candidates := slices.Clone(b.positions[key])
+		sortCandidates(candidates)
+		file.Positions = append(file.Positions, bookPosition{Key: strconv.FormatUint(key, 16), Moves: candidates})
+	}
+	return json.Marshal(file)
+}
+
+// UnmarshalJSON reads a book written by MarshalJSON.
+func (b *Book) UnmarshalJSON(data []byte) error {
+	var file bookFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	b.positions = make(map[uint64][]Candidate, len(file.Positions))
+	for _, p := range file.Positions {
+		key, err := strconv.ParseUint(p.Key, 16, 64)
+		if err != nil {
+			return fmt.Errorf("bad position key '%s': %w", p.Key, err)
+		}
+		b.positions[key] = p.Moves
+	}
+	return nil
+}
+
+// Load reads a book from the named file.
+func Load(path string) (*Book, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read book: %w", err)
+	}
+	b := New()
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal book: %w", err)
+	}
+	return b, nil
+}
+
+// Save writes the book to the named file.
+func (b *Book) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal book: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write book: %w", err)
+	}
+	return nil
+}
+
+var (
+	defaultBook     *Book
+	defaultBookErr  error
+	defaultBookOnce sync.Once
+)
+
+// defaultBookData is the book that ships with the game. It was built from an untimed tournament:
+//
+//	cragspider-arena -players strategist,gambler -games 20 -untimed -seed 1 -out games.json
+//	cragspider-book -plies 8 -min-weight 1 -out default_book.json games.json
+//
+//go:embed default_book.json
+var defaultBookData []byte
+
+// Default returns the book that ships with the game, built from tournaments between the AI personalities.
+func Default() (*Book, error) {
+	defaultBookOnce.Do(func() {
+		b := New()
+		if err := json.Unmarshal(defaultBookData, b); err != nil {
+			defaultBookErr = fmt.Errorf("failed to unmarshal default book: %w", err)
+			return
+		}
+		defaultBook = b
+	})
+	return defaultBook, defaultBookErr
+}
+
+// sortCandidates sorts candidates most favored first, breaking ties by square so the order is always the same.
+func sortCandidates(candidates []Candidate) {
+	slices.SortFunc(candidates, func(a, b Candidate) int {
+		return cmp.Or(
+			-cmp.Compare(a.Weight, b.Weight),
+			cmp.Compare(a.From[0], b.From[0]), cmp.Compare(a.From[1], b.From[1]),
+			cmp.Compare(a.To[0], b.To[0]), cmp.Compare(a.To[1], b.To[1]),
+		)
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package book
+
+import (
+	"path/filepath"
+	"testing"
+
+	"cragspider-go/internal/core"
+	"cragspider-go/pkg/random"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBook returns a book for the starting position with White's corner warriors favored three to one.
+func testBook(t *testing.T) (*Book, *core.Board) {
+	t.Helper()
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	b := New()
+	b.Add(game.Board, core.White, core.Position{9, 0}, core.Position{8, 0}, 1)
+	b.Add(game.Board, core.White, core.Position{9, 9}, core.Position{8, 9}, 1)
+	b.Add(game.Board, core.White, core.Position{9, 0}, core.Position{8, 0}, 2)
+	return b, game.Board
+}
+
+func TestBook_Candidates(t *testing.T) {
+	b, board := testBook(t)
+	assert.Equal(t, 1, b.Len())
+	assert.Equal(t, []Candidate{
+		{From: core.Position{9, 0}, To: core.Position{8, 0}, Weight: 3},
+		{From: core.Position{9, 9}, To: core.Position{8, 9}, Weight: 1},
+	}, b.Candidates(board, core.White))
+	assert.Empty(t, b.Candidates(board, core.Black), "the side to move is part of the position")
+}
+
+func TestBook_Choose(t *testing.T) {
+	b, board := testBook(t)
+	corner := board.GetPieceAt(core.Position{9, 0})
+
+	action, ok := b.Choose(board, core.White, 0, nil)
+	require.True(t, ok)
+	assert.Equal(t, core.Action{Piece: corner, Move: core.Move{-1, 0}}, *action, "no variety plays the favorite")
+
+	_, ok = b.Choose(board, core.Black, 1, random.New(1))
+	assert.False(t, ok, "positions not in the book have no moves")
+
+	rng := random.New(1)
+	counts := make(map[*core.Piece]int)
+	for range 1000 {
+		action, ok := b.Choose(board, core.White, 1, rng)
+		require.True(t, ok)
+		counts[action.Piece]++
+	}
+	assert.InDelta(t, 750, counts[corner], 60, "variety of one picks in proportion to weight")
+
+	counts = make(map[*core.Piece]int)
+	for range 1000 {
+		action, _ := b.Choose(board, core.White, 100, rng)
+		counts[action.Piece]++
+	}
+	assert.InDelta(t, 500, counts[corner], 60, "lots of variety picks almost evenly")
+}
+
+func TestBook_ChooseSkipsInvalidMoves(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	b := New()
+	b.Add(game.Board, core.White, core.Position{9, 0}, core.Position{0, 0}, 5) // Not a move a warrior can make
+	b.Add(game.Board, core.White, core.Position{5, 5}, core.Position{4, 5}, 5) // No piece there
+	b.Add(game.Board, core.White, core.Position{0, 0}, core.Position{1, 0}, 5) // Black's piece
+	b.Add(game.Board, core.White, core.Position{9, 9}, core.Position{8, 9}, 1)
+
+	action, ok := b.Choose(game.Board, core.White, 0, nil)
+	require.True(t, ok)
+	assert.Equal(t, game.Board.GetPieceAt(core.Position{9, 9}), action.Piece)
+}
+
+func TestBook_SaveLoad(t *testing.T) {
+	b, board := testBook(t)
+	path := filepath.Join(t.TempDir(), "book.json")
+	require.NoError(t, b.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, b.Candidates(board, core.White), loaded.Candidates(board, core.White))
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestDefault(t *testing.T) {
+	b, err := Default()
+	require.NoError(t, err)
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	_, ok := b.Choose(game.Board, core.White, 0, nil)
+	assert.True(t, ok, "the built-in book should know the starting position")
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package book
+
+import (
+	"cragspider-go/internal/core"
+	"fmt"
+)
+
+// Filter chooses which games' moves go into a book, by how the games turned out for the player who made them.
+type Filter string
+
+const (
+	// Wins keeps only the moves of players who went on to win.
+	Wins Filter = "wins"
+	// WinsAndDraws keeps the moves of players who didn't lose, with a draw counting half as much as a win.
+	WinsAndDraws Filter = "draws"
+	// All keeps every move, with a win counting twice as much as a draw and a loss counting for a little.
+	All Filter = "all"
+)
+
+// lossWeight is what a loss counts for when every move is kept, so that a book built from few games still has
+// something to say about positions only the losers reached.
+const lossWeight = 0.1
+
+// Game is a finished game to build a book from.
+type Game struct {
+	Moves  []core.MoveRecord
+	Result core.Result
+}
+
+// BuildOptions controls which moves go into a book.
+type BuildOptions struct {
+	Plies     int     // Moves from the start of each game to take; later moves are left to search
+	MinWeight float64 // Moves with less weight than this across all the games are left out
+	Filter    Filter  // Which players' moves to keep, by how their games ended
+}
+
+// Build returns a book of the moves played at the start of the games, each weighted by how often it was played by
+// players whose games ended the way the filter keeps. Unfinished games are left out.
+func Build(gameConfig *core.GameConfig, games []Game, opts BuildOptions) (*Book, error) {
+	weights, err := resultWeights(opts.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	b := New()
+	for n, g := range games {
+		winner, won := g.Result.Winner()
+		if g.Result == core.InProgress {
+			continue
+		}
+		game, err := core.NewGameWithConfig(gameConfig)
+		if err != nil {
+			return nil, err
+		}
+		for i, move := range g.Moves {
+			if i >= opts.Plies {
+				break
+			}
+			var weight float64
+			switch {
+			case !won:
+				weight = weights.draw
+			case winner == move.Color:
+				weight = weights.win
+			default:
+				weight = weights.loss
+			}
+			if weight > 0 {
+				b.Add(game.Board, move.Color, move.From, move.To, weight)
+			}
+
+			piece := game.Board.GetPieceAt(move.From)
+			if piece == nil {
+				return nil, fmt.Errorf("cannot replay game %d move %d (%s): no piece at %s", n+1, i+1, move, move.From)
+			}
+			if err := game.Play(&core.Action{Piece: piece, Move: move.Move()}); err != nil {
+				return nil, fmt.Errorf("cannot replay game %d move %d (%s): %w", n+1, i+1, move, err)
+			}
+		}
+	}
+	b.prune(opts.MinWeight)
+	return b, nil
+}
+
+// filterWeights are what a move counts for in a book, by how the game ended for the player who made it.
+type filterWeights struct {
+	win, draw, loss float64
+}
+
+// resultWeights returns the weights for the filter.
+func resultWeights(filter Filter) (filterWeights, error) {
+	switch filter {
+	case Wins:
+		return filterWeights{win: 1}, nil
+	case WinsAndDraws:
+		return filterWeights{win: 1, draw: 0.5}, nil
+	case All:
+		return filterWeights{win: 1, draw: 0.5, loss: lossWeight}, nil
+	default:
+		return filterWeights{}, fmt.Errorf("unknown book filter '%s'", filter)
+	}
+}
+
+// prune removes the moves with less than the minimum weight, and any positions left with no moves.
+func (b *Book) prune(minWeight float64) {
+	for key, candidates := range b.positions {
+		kept := candidates[:0]
+		for _, c := range candidates {
+			if c.Weight >= minWeight {
+				kept = append(kept, c)
+			}
+		}
+		if len(kept) == 0 {
+			delete(b.positions, key)
+		} else {
+			b.positions[key] = kept
+		}
+	}
+}
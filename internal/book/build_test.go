@@ -0,0 +1,85 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package book
+
+import (
+	"testing"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Opening moves used to build test books.
+var (
+	whiteLeft  = core.MoveRecord{Color: core.White, Piece: "warrior", From: core.Position{9, 0}, To: core.Position{8, 0}}
+	whiteRight = core.MoveRecord{Color: core.White, Piece: "warrior", From: core.Position{9, 9}, To: core.Position{8, 9}}
+	blackLeft  = core.MoveRecord{Color: core.Black, Piece: "warrior", From: core.Position{0, 0}, To: core.Position{1, 0}}
+)
+
+func TestBuild(t *testing.T) {
+	cfg, err := core.GetConfig()
+	require.NoError(t, err)
+	games := []Game{
+		{Moves: []core.MoveRecord{whiteLeft, blackLeft}, Result: core.WhiteWins},
+		{Moves: []core.MoveRecord{whiteLeft, blackLeft}, Result: core.Draw},
+		{Moves: []core.MoveRecord{whiteRight, blackLeft}, Result: core.BlackWins},
+		{Moves: []core.MoveRecord{whiteRight}, Result: core.InProgress},
+	}
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	start := game.Board
+	require.NoError(t, game.Play(&core.Action{Piece: start.GetPieceAt(whiteLeft.From), Move: whiteLeft.Move()}))
+	afterLeft := game.Board
+
+	tests := []struct {
+		filter Filter
+		white  []Candidate
+		black  []Candidate
+	}{
+		{Wins, []Candidate{{From: whiteLeft.From, To: whiteLeft.To, Weight: 1}}, nil},
+		{WinsAndDraws, []Candidate{{From: whiteLeft.From, To: whiteLeft.To, Weight: 1.5}},
+			[]Candidate{{From: blackLeft.From, To: blackLeft.To, Weight: 0.5}}},
+		{All, []Candidate{
+			{From: whiteLeft.From, To: whiteLeft.To, Weight: 1.5},
+			{From: whiteRight.From, To: whiteRight.To, Weight: lossWeight},
+		}, []Candidate{{From: blackLeft.From, To: blackLeft.To, Weight: 0.5 + lossWeight}}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.filter), func(t *testing.T) {
+			b, err := Build(cfg, games, BuildOptions{Plies: 8, Filter: tt.filter})
+			require.NoError(t, err)
+			assert.Equal(t, tt.white, b.Candidates(start, core.White))
+			assert.Equal(t, tt.black, b.Candidates(afterLeft, core.Black))
+		})
+	}
+}
+
+func TestBuild_Options(t *testing.T) {
+	cfg, err := core.GetConfig()
+	require.NoError(t, err)
+	games := []Game{
+		{Moves: []core.MoveRecord{whiteLeft, blackLeft}, Result: core.Draw},
+		{Moves: []core.MoveRecord{whiteRight, blackLeft}, Result: core.Draw},
+		{Moves: []core.MoveRecord{whiteRight, blackLeft}, Result: core.Draw},
+	}
+
+	b, err := Build(cfg, games, BuildOptions{Plies: 1, Filter: WinsAndDraws})
+	require.NoError(t, err)
+	assert.Equal(t, 1, b.Len(), "only the first ply is kept")
+
+	b, err = Build(cfg, games, BuildOptions{Plies: 1, MinWeight: 1, Filter: WinsAndDraws})
+	require.NoError(t, err)
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	assert.Equal(t, []Candidate{{From: whiteRight.From, To: whiteRight.To, Weight: 1}},
+		b.Candidates(game.Board, core.White), "moves below the minimum weight are left out")
+
+	_, err = Build(cfg, games, BuildOptions{Plies: 1, Filter: "bogus"})
+	assert.Error(t, err)
+
+	bad := []Game{{Moves: []core.MoveRecord{blackLeft}, Result: core.Draw}}
+	_, err = Build(cfg, bad, BuildOptions{Plies: 1, Filter: All})
+	assert.Error(t, err, "moves that can't be replayed are an error")
+}
@@ -2,6 +2,12 @@
 
 package core
 
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
 // Action represents a complete move action, containing the piece and its move delta.
 type Action struct {
 	Piece *Piece
@@ -14,3 +20,53 @@ type AgentStrategy interface {
 	// It returns an Action (containing the piece and move delta) or an error if no valid moves are available.
 	NextMove(board *Board) (*Action, error)
 }
+
+// TimedAgentStrategy is an interface for bots that can be interrupted while they think.
+type TimedAgentStrategy interface {
+	// NextMoveContext returns the next move for the strategy given the current board state. The bot may think for
+	// up to budget; a budget of zero means the bot should use its own default. When the context is cancelled or the
+	// budget runs out, search bots return the best move they have found so far.
+	NextMoveContext(ctx context.Context, board *Board, budget time.Duration) (*Action, error)
+}
+
+// AsTimed returns the strategy as a TimedAgentStrategy. Strategies that already implement the interface are
+// returned as they are; others are wrapped so that they run in the background and are abandoned when the context
+// is cancelled or the budget runs out.
+func AsTimed(strategy AgentStrategy) TimedAgentStrategy {
+	if timed, ok := strategy.(TimedAgentStrategy); ok {
+		return timed
+	}
+	return &timedAdapter{strategy: strategy}
+}
+
+// timedAdapter adapts a plain AgentStrategy to the TimedAgentStrategy interface.
+type timedAdapter struct {
+	strategy AgentStrategy
+}
+
+// NextMoveContext runs the wrapped strategy's NextMove in a goroutine. Since the wrapped strategy has no notion of
+// a partial result, an error is returned if the context ends before it does.
+func (t *timedAdapter) NextMoveContext(ctx context.Context, board *Board, budget time.Duration) (*Action, error) {
+	if budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+
+	type result struct {
+		action *Action
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		action, err := t.strategy.NextMove(board)
+		done <- result{action: action, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.action, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("strategy did not produce a move: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedStrategy is an AgentStrategy that returns the same action after an optional delay.
+type fixedStrategy struct {
+	action *Action
+	delay  time.Duration
+}
+
+func (f *fixedStrategy) NextMove(_ *Board) (*Action, error) {
+	time.Sleep(f.delay)
+	return f.action, nil
+}
+
+// timedStrategy is a strategy that implements TimedAgentStrategy itself.
+type timedStrategy struct {
+	fixedStrategy
+}
+
+func (ts *timedStrategy) NextMoveContext(_ context.Context, b *Board, _ time.Duration) (*Action, error) {
+	return ts.NextMove(b)
+}
+
+func TestAsTimed(t *testing.T) {
+	game, err := NewGame()
+	require.NoError(t, err)
+	action := &Action{Piece: &Piece{Name: "test", Color: White}, Move: Move{1, 0}}
+
+	t.Run("returns the wrapped strategy's move", func(t *testing.T) {
+		timed := AsTimed(&fixedStrategy{action: action})
+		result, err := timed.NextMoveContext(context.Background(), game.Board, time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, action, result)
+	})
+
+	t.Run("zero budget means no time limit", func(t *testing.T) {
+		timed := AsTimed(&fixedStrategy{action: action, delay: 10 * time.Millisecond})
+		result, err := timed.NextMoveContext(context.Background(), game.Board, 0)
+		require.NoError(t, err)
+		assert.Equal(t, action, result)
+	})
+
+	t.Run("gives up when the budget runs out", func(t *testing.T) {
+		timed := AsTimed(&fixedStrategy{action: action, delay: time.Second})
+		result, err := timed.NextMoveContext(context.Background(), game.Board, 10*time.Millisecond)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "should report the deadline, got %v", err)
+	})
+
+	t.Run("gives up when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		timed := AsTimed(&fixedStrategy{action: action, delay: time.Second})
+		result, err := timed.NextMoveContext(ctx, game.Board, 0)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, context.Canceled), "should report the cancellation, got %v", err)
+	})
+
+	t.Run("timed strategies are not wrapped", func(t *testing.T) {
+		strategy := &timedStrategy{fixedStrategy{action: action}}
+		assert.Same(t, strategy, AsTimed(strategy))
+	})
+}
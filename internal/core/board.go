@@ -65,14 +65,9 @@ type Board struct {
 	config *GameConfig
 }
 
-const (
-	// SpriteSize is the standard size of one of our square sprites
-	SpriteSize = 24
-	// Scale is the scale factor for the board, to make each square bigger than 24x24.
-	Scale float32 = 3.0
-	// SquareSize is the standard size of a square in number of pixels.
-	SquareSize int = SpriteSize * int(Scale)
-)
+// SpriteSize is the standard size of one of our square sprites. How big a square is drawn depends on the size of
+// the window it's drawn in.
+const SpriteSize = 24
 
 // CardinalDirections contains unit vectors for the four cardinal directions: up, right, down, left.
 var CardinalDirections = [4]Move{
@@ -266,6 +261,19 @@ func (b *Board) MovePiece(piece *Piece, start Position, move Move) (*Board, erro
 	return newBoard, nil
 }
 
+// ApplyAction applies the action to the board, returning a new board with the move made. An error is returned if
+// the action's piece isn't on the board or the move isn't valid for it.
+func (b *Board) ApplyAction(action *Action) (*Board, error) {
+	if action == nil {
+		return nil, fmt.Errorf("action is nil")
+	}
+	start, err := b.PieceLocation(action.Piece)
+	if err != nil {
+		return nil, err
+	}
+	return b.MovePiece(action.Piece, start, action.Move)
+}
+
 // GetSquareAt returns the square at the given position.
 func (b *Board) GetSquareAt(pos Position) *Square {
 	return &b.squares.data[pos[0]][pos[1]]
@@ -276,6 +284,12 @@ func (b *Board) GetPieceAt(pos Position) *Piece {
 	return b.pieces[pos[0]][pos[1]]
 }
 
+// Config returns the game configuration the board was created from. Boards built by hand in tests may not have one,
+// in which case nil is returned.
+func (b *Board) Config() *GameConfig {
+	return b.config
+}
+
 // GetCapturedPieces returns all pieces captured by the specified color.
 func (b *Board) GetCapturedPieces(color Color) []*Piece {
 	return b.captured[color]
@@ -294,3 +308,24 @@ func (b *Board) GetPiecesByColor(color Color) []*Piece {
 	}
 	return pieces
 }
+
+// ValidActions returns every action the specified color can make on this board. Pieces are visited in row-major
+// order and each piece's moves are in path order, so the result is stable for a given board.
+func (b *Board) ValidActions(color Color) []Action {
+	var actions []Action
+	for row := 0; row < b.Rows; row++ {
+		for col := 0; col < b.Columns; col++ {
+			piece := b.pieces[row][col]
+			if piece == nil || piece.Color != color {
+				continue
+			}
+			for _, end := range piece.ValidNextPositions(Position{row, col}, b) {
+				actions = append(actions, Action{
+					Piece: piece,
+					Move:  Move{end[0] - row, end[1] - col},
+				})
+			}
+		}
+	}
+	return actions
+}
@@ -417,3 +417,65 @@ func TestBoard_Capture(t *testing.T) {
 		assert.Equal(t, whitePiece, resultBoard.pieces[3][2], "Piece should be at new position")
 	})
 }
+
+func TestBoard_ValidActions(t *testing.T) {
+	board := createTestBoard(5, 5)
+	board.captured = make(map[Color][]*Piece)
+	whitePiece := getTestPiece()
+	blackPiece := &Piece{Name: "black", Color: Black, Config: PieceConfig{Moves: [][]Move{{{1, 0}}}}}
+	board.pieces[2][2] = whitePiece
+	board.pieces[0][2] = blackPiece
+
+	t.Run("actions match valid positions", func(t *testing.T) {
+		actions := board.ValidActions(White)
+		positions := whitePiece.ValidNextPositions(Position{2, 2}, board)
+		require.Len(t, actions, len(positions))
+		for i, action := range actions {
+			assert.Equal(t, whitePiece, action.Piece)
+			assert.Equal(t, positions[i], Position{2, 2}.Add(action.Move))
+		}
+	})
+
+	t.Run("only the requested color", func(t *testing.T) {
+		actions := board.ValidActions(Black)
+		require.Len(t, actions, 1)
+		assert.Equal(t, blackPiece, actions[0].Piece)
+		assert.Equal(t, Move{1, 0}, actions[0].Move)
+	})
+
+	t.Run("no pieces means no actions", func(t *testing.T) {
+		assert.Empty(t, createTestBoard(3, 3).ValidActions(White))
+	})
+}
+
+func TestBoard_ApplyAction(t *testing.T) {
+	board := createTestBoard(5, 5)
+	board.captured = make(map[Color][]*Piece)
+	whitePiece := getTestPiece()
+	blackPiece := &Piece{Name: "black", Color: Black}
+	board.pieces[2][2] = whitePiece
+	board.pieces[0][2] = blackPiece
+
+	t.Run("applies a capture", func(t *testing.T) {
+		result, err := board.ApplyAction(&Action{Piece: whitePiece, Move: Move{-2, 0}})
+		require.NoError(t, err)
+		assert.Equal(t, whitePiece, result.GetPieceAt(Position{0, 2}))
+		assert.Equal(t, []*Piece{blackPiece}, result.GetCapturedPieces(White))
+		assert.Equal(t, whitePiece, board.GetPieceAt(Position{2, 2}), "original board should be unchanged")
+	})
+
+	t.Run("piece not on board", func(t *testing.T) {
+		_, err := board.ApplyAction(&Action{Piece: &Piece{Name: "ghost", Color: White}, Move: Move{1, 0}})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid move", func(t *testing.T) {
+		_, err := board.ApplyAction(&Action{Piece: whitePiece, Move: Move{1, 1}})
+		assert.Error(t, err)
+	})
+
+	t.Run("nil action", func(t *testing.T) {
+		_, err := board.ApplyAction(nil)
+		assert.Error(t, err)
+	})
+}
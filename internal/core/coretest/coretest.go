@@ -0,0 +1,24 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Package coretest provides game configurations for the tests of packages built on core.
+package coretest
+
+import (
+	"testing"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/require"
+)
+
+// SmallConfig returns the standard pieces on an empty board of the given size, small enough to solve or train on
+// quickly. Tests place the pieces they need.
+func SmallConfig(t testing.TB, rows, columns int) *core.GameConfig {
+	t.Helper()
+	standard, err := core.GetConfig()
+	require.NoError(t, err)
+	return &core.GameConfig{
+		Pieces: standard.Pieces,
+		Board:  core.BoardConfig{Rows: rows, Columns: columns},
+	}
+}
@@ -8,12 +8,37 @@ import (
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
+// MoveRecord is a move that was made during a game, kept so the game can be reviewed or replayed.
+type MoveRecord struct {
+	Color    Color    `json:"color"`
+	Piece    string   `json:"piece"`
+	From     Position `json:"from"`
+	To       Position `json:"to"`
+	Captured string   `json:"captured,omitempty"` // Name of the piece that was captured, if any
+}
+
+// String returns a nicely formatted string representation of the move.
+func (m MoveRecord) String() string {
+	if m.Captured != "" {
+		return fmt.Sprintf("%s %s %sx%s", m.Color, m.Piece, m.From, m.To)
+	}
+	return fmt.Sprintf("%s %s %s-%s", m.Color, m.Piece, m.From, m.To)
+}
+
+// Move returns the move delta the record represents.
+func (m MoveRecord) Move() Move {
+	return Move{m.To[0] - m.From[0], m.To[1] - m.From[1]}
+}
+
 // Game represents a single instance of a game.
 type Game struct {
 	Board       *Board
 	config      *GameConfig
 	ActiveColor Color
+	Moves       []MoveRecord
 	players     map[Color]*Player
+	history     []*Board // The board before each move, for taking moves back
+	forfeit     *Outcome // How the game ended off the board, if it did
 }
 
 // NewGame returns a new game with the standard configuration.
@@ -53,7 +78,84 @@ func NewGameWithConfigAndPlayers(cfg *GameConfig, whitePlayer, blackPlayer *Play
 
 // Over returns true if the game is over.
 func (g *Game) Over() bool {
-	return false
+	return g.Outcome().Over()
+}
+
+// Outcome returns how the game ended, or an in-progress outcome if it hasn't. On top of the board's victory rules,
+// a game that reaches the configured move limit is drawn.
+func (g *Game) Outcome() Outcome {
+	if g.forfeit != nil {
+		return *g.forfeit
+	}
+	if outcome := g.Board.Judge(g.ActiveColor); outcome.Over() {
+		return outcome
+	}
+	if g.config.Rules.MaxMoves > 0 && len(g.Moves) >= g.config.Rules.MaxMoves {
+		return Outcome{Result: Draw, Reason: fmt.Sprintf("no winner after %d moves", len(g.Moves))}
+	}
+	return Outcome{Result: InProgress}
+}
+
+// Forfeit ends the game as a loss for the color, for a reason off the board such as running out of time. A game
+// that's already over is left as it ended.
+func (g *Game) Forfeit(color Color, reason string) {
+	if g.Over() {
+		return
+	}
+	g.forfeit = &Outcome{Result: WinFor(color.Opponent()), Reason: reason}
+}
+
+// Config returns the configuration the game is played with.
+func (g *Game) Config() *GameConfig {
+	return g.config
+}
+
+// Play makes the action for the active player, records it and advances the turn. An error is returned if it isn't
+// the action's piece's turn or the move isn't valid; the game is unchanged in that case.
+func (g *Game) Play(action *Action) error {
+	if action == nil || action.Piece == nil {
+		return fmt.Errorf("action has no piece")
+	}
+	if action.Piece.Color != g.ActiveColor {
+		return fmt.Errorf("it is %s's turn, not %s's", g.ActiveColor, action.Piece.Color)
+	}
+	from, err := g.Board.PieceLocation(action.Piece)
+	if err != nil {
+		return err
+	}
+	newBoard, err := g.Board.MovePiece(action.Piece, from, action.Move)
+	if err != nil {
+		return err
+	}
+
+	record := MoveRecord{
+		Color: action.Piece.Color,
+		Piece: action.Piece.Name,
+		From:  from,
+		To:    from.Add(action.Move),
+	}
+	if captured := g.Board.GetPieceAt(record.To); captured != nil {
+		record.Captured = captured.Name
+	}
+	g.history = append(g.history, g.Board)
+	g.Board = newBoard
+	g.Moves = append(g.Moves, record)
+	g.AdvanceTurn()
+	return nil
+}
+
+// Undo takes back the last move, returning the board to how it was and the turn to whoever made it. An error is
+// returned if there's no move to take back.
+func (g *Game) Undo() error {
+	if len(g.Moves) == 0 || len(g.history) < len(g.Moves) {
+		return fmt.Errorf("no move to undo")
+	}
+	last := len(g.Moves) - 1
+	g.ActiveColor = g.Moves[last].Color
+	g.Board = g.history[len(g.history)-1]
+	g.Moves = g.Moves[:last]
+	g.history = g.history[:len(g.history)-1]
+	return nil
 }
 
 // AdvanceTurn advances the game to the next player's turn.
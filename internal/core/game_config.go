@@ -7,6 +7,7 @@ import (
 	_ "embed"
 	"fmt"
 	"sync"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -16,9 +17,33 @@ type SpriteCoords []graphics.FrameCoords
 
 // PieceConfig represents a type of piece in the game, like bishop or pawn.
 type PieceConfig struct {
-	Name    string                 `yaml:"name"`
-	Sprites map[Color]SpriteCoords `yaml:"sprites"`
-	Moves   [][]Move               `yaml:"moves"`
+	Name       string                       `yaml:"name"`
+	Sprites    map[Color]SpriteCoords       `yaml:"sprites"`
+	Animations map[Color]graphics.Animation `yaml:"animations,omitempty"` // Clips for each color, by name
+	Moves      [][]Move                     `yaml:"moves"`
+}
+
+const (
+	// idleFrameTime is how long each sprite frame is shown while a piece without animations idles.
+	idleFrameTime = 500 * time.Millisecond
+	// walkFrameTime is how long each sprite frame is shown while a piece without animations walks.
+	walkFrameTime = 150 * time.Millisecond
+)
+
+// Animation returns the clips the piece is animated with for the color. A piece that doesn't declare any steps
+// through its sprites, slowly while idle and quickly while walking.
+func (p *PieceConfig) Animation(color Color) graphics.Animation {
+	if animation, ok := p.Animations[color]; ok {
+		return animation
+	}
+	frames := make([]graphics.Frame, len(p.Sprites[color]))
+	for i, coords := range p.Sprites[color] {
+		frames[i] = graphics.Frame{Coords: coords}
+	}
+	return graphics.Animation{
+		graphics.Idle: {Frames: frames, FrameTime: idleFrameTime},
+		graphics.Walk: {Frames: frames, FrameTime: walkFrameTime},
+	}
 }
 
 // BoardPosition represents a starting position on the board: what piece and where.
@@ -29,10 +54,11 @@ type BoardPosition struct {
 
 // BoardConfig is how the board looks at the very start of the game.
 type BoardConfig struct {
-	Rows    int             `yaml:"rows"`
-	Columns int             `yaml:"columns"`
-	White   []BoardPosition `yaml:"white"`
-	Black   []BoardPosition `yaml:"black"`
+	Rows       int             `yaml:"rows"`
+	Columns    int             `yaml:"columns"`
+	White      []BoardPosition `yaml:"white"`
+	Black      []BoardPosition `yaml:"black"`
+	Objectives []Position      `yaml:"objectives"`
 }
 
 // GetStartingPositions returns the starting positions for the specified color.
@@ -47,10 +73,16 @@ func (b *BoardConfig) GetStartingPositions(color Color) ([]BoardPosition, error)
 	}
 }
 
+// RulesConfig holds the rules that decide when a game is over.
+type RulesConfig struct {
+	MaxMoves int `yaml:"max_moves"` // The game is drawn after this many moves; zero means no limit
+}
+
 // GameConfig holds all the parameters for how the game is played.
 type GameConfig struct {
 	Pieces []PieceConfig `yaml:"pieces"`
 	Board  BoardConfig   `yaml:"board"`
+	Rules  RulesConfig   `yaml:"rules"`
 }
 
 var (
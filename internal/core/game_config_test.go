@@ -2,6 +2,9 @@ package core
 
 import (
 	"testing"
+	"time"
+
+	"cragspider-go/pkg/graphics"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -39,5 +42,46 @@ func TestLoadConfig(t *testing.T) {
 		blackPieces, err := cfg.Board.GetStartingPositions(Black)
 		require.NoError(t, err)
 		assert.Len(t, blackPieces, 4, "should have 4 black pieces")
+
+		// Test objective squares
+		assert.Len(t, cfg.Board.Objectives, 4, "should have 4 objective squares")
+
+		// Test rules
+		assert.Equal(t, 200, cfg.Rules.MaxMoves, "game should be drawn after 200 moves")
 	})
 }
+
+func TestPieceConfig_Animation(t *testing.T) {
+	cfg, err := GetConfig()
+	require.NoError(t, err)
+	for _, piece := range cfg.Pieces {
+		for _, color := range []Color{White, Black} {
+			animation := piece.Animation(color)
+			for _, clip := range []string{graphics.Idle, graphics.Walk, graphics.Attack, graphics.Die} {
+				assert.Contains(t, animation, clip, "%s %s should have a %s clip", color, piece.Name, clip)
+				assert.Positive(t, animation[clip].Duration(), "%s %s %s clip", color, piece.Name, clip)
+				for _, frame := range animation[clip].Frames {
+					assert.Contains(t, piece.Sprites[color], frame.Coords, "clips should use the piece's own sprites")
+				}
+			}
+			assert.Equal(t, graphics.Loop, animation[graphics.Idle].Mode)
+			assert.Equal(t, graphics.Once, animation[graphics.Die].Mode)
+		}
+	}
+
+	warrior, err := cfg.GetPieceConfig("warrior")
+	require.NoError(t, err)
+	attack := warrior.Animation(White)[graphics.Attack]
+	require.Len(t, attack.Frames, 3)
+	assert.Equal(t, graphics.Frame{Coords: graphics.FrameCoords{1, 0}, Duration: 80 * time.Millisecond},
+		attack.Frames[0])
+	assert.Equal(t, 400*time.Millisecond, attack.Duration())
+
+	// Pieces that don't declare clips step through their sprites
+	plain := PieceConfig{Sprites: map[Color]SpriteCoords{White: {{0, 0}, {1, 0}}}}
+	animation := plain.Animation(White)
+	assert.Equal(t, graphics.FrameCoords{0, 0}, animation.Clip(graphics.Idle).FrameAt(0))
+	assert.Equal(t, graphics.FrameCoords{1, 0}, animation.Clip(graphics.Idle).FrameAt(idleFrameTime))
+	assert.Equal(t, graphics.FrameCoords{1, 0}, animation.Clip(graphics.Walk).FrameAt(walkFrameTime))
+	assert.Equal(t, animation[graphics.Idle], animation.Clip(graphics.Die))
+}
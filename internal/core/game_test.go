@@ -48,3 +48,106 @@ func TestAdvanceTurn(t *testing.T) {
 	game.AdvanceTurn()
 	assert.Equal(t, White, game.ActiveColor, "White should be the current player after advancing twice")
 }
+
+func TestGamePlay(t *testing.T) {
+	game, err := NewGame()
+	require.NoError(t, err)
+	warrior := game.Board.GetPieceAt(Position{9, 0})
+	blackWarrior := game.Board.GetPieceAt(Position{0, 0})
+
+	t.Run("rejects the wrong color", func(t *testing.T) {
+		err := game.Play(&Action{Piece: blackWarrior, Move: Move{1, 0}})
+		assert.Error(t, err)
+		assert.Empty(t, game.Moves)
+	})
+
+	t.Run("rejects an invalid move", func(t *testing.T) {
+		err := game.Play(&Action{Piece: warrior, Move: Move{-3, 0}})
+		assert.Error(t, err)
+		assert.Equal(t, White, game.ActiveColor, "turn should not advance")
+	})
+
+	t.Run("records the move and advances the turn", func(t *testing.T) {
+		require.NoError(t, game.Play(&Action{Piece: warrior, Move: Move{-2, 0}}))
+		assert.Equal(t, Black, game.ActiveColor)
+		require.Len(t, game.Moves, 1)
+		assert.Equal(t, MoveRecord{Color: White, Piece: "warrior", From: Position{9, 0}, To: Position{7, 0}}, game.Moves[0])
+		assert.Equal(t, Move{-2, 0}, game.Moves[0].Move())
+		assert.Equal(t, warrior, game.Board.GetPieceAt(Position{7, 0}))
+	})
+}
+
+func TestGameUndo(t *testing.T) {
+	game, err := NewGame()
+	require.NoError(t, err)
+	assert.Error(t, game.Undo(), "there's nothing to undo at the start")
+
+	start := game.Board
+	warrior := game.Board.GetPieceAt(Position{9, 0})
+	require.NoError(t, game.Play(&Action{Piece: warrior, Move: Move{-2, 0}}))
+	afterWhite := game.Board
+	require.NoError(t, game.Play(&game.Board.ValidActions(Black)[0]))
+
+	require.NoError(t, game.Undo())
+	assert.Same(t, afterWhite, game.Board, "the board should be as it was before Black's move")
+	assert.Equal(t, Black, game.ActiveColor, "it should be Black's turn again")
+	assert.Len(t, game.Moves, 1)
+
+	require.NoError(t, game.Undo())
+	assert.Same(t, start, game.Board)
+	assert.Equal(t, White, game.ActiveColor)
+	assert.Empty(t, game.Moves)
+	assert.Equal(t, warrior, game.Board.GetPieceAt(Position{9, 0}), "pieces should be the same ones")
+	assert.Error(t, game.Undo())
+}
+
+func TestGameOutcome(t *testing.T) {
+	t.Run("new game is in progress", func(t *testing.T) {
+		game, err := NewGame()
+		require.NoError(t, err)
+		assert.False(t, game.Over())
+		assert.Equal(t, InProgress, game.Outcome().Result)
+	})
+
+	t.Run("capturing the last piece wins", func(t *testing.T) {
+		cfg, err := GetConfig()
+		require.NoError(t, err)
+		lonely := *cfg
+		lonely.Board.White = cfg.Board.White[:1]
+		lonely.Board.Black = []BoardPosition{{Name: "padwar", Position: Position{7, 0}}}
+		game, err := NewGameWithConfig(&lonely)
+		require.NoError(t, err)
+
+		require.NoError(t, game.Play(&Action{Piece: game.Board.GetPieceAt(Position{9, 0}), Move: Move{-2, 0}}))
+		assert.True(t, game.Over())
+		assert.Equal(t, WhiteWins, game.Outcome().Result)
+		assert.Equal(t, "padwar", game.Moves[0].Captured)
+	})
+
+	t.Run("move limit draws", func(t *testing.T) {
+		cfg, err := GetConfig()
+		require.NoError(t, err)
+		limited := *cfg
+		limited.Rules.MaxMoves = 2
+		game, err := NewGameWithConfig(&limited)
+		require.NoError(t, err)
+
+		require.NoError(t, game.Play(&Action{Piece: game.Board.GetPieceAt(Position{9, 0}), Move: Move{-1, 0}}))
+		assert.False(t, game.Over())
+		require.NoError(t, game.Play(&Action{Piece: game.Board.GetPieceAt(Position{0, 0}), Move: Move{1, 0}}))
+		assert.True(t, game.Over())
+		assert.Equal(t, Draw, game.Outcome().Result)
+	})
+
+	t.Run("forfeiting loses", func(t *testing.T) {
+		game, err := NewGame()
+		require.NoError(t, err)
+
+		game.Forfeit(Black, "black ran out of time")
+		assert.True(t, game.Over())
+		assert.Equal(t, Outcome{Result: WhiteWins, Reason: "black ran out of time"}, game.Outcome())
+
+		game.Forfeit(White, "white ran out of time")
+		assert.Equal(t, WhiteWins, game.Outcome().Result, "a finished game stays finished")
+	})
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package core
+
+import "hash/fnv"
+
+// blackToMove is mixed into the hash of every position with Black to move.
+const blackToMove uint64 = 0x9e3779b97f4a7c15
+
+// Hash returns a 64-bit key for the position with the specified color to move, for search bots to recognize a
+// position they've seen before however it was reached. It's a Zobrist hash: each piece on each square has its own
+// key and the position's key is all of them combined with xor. Keys are worked out from the pieces' names, colors
+// and squares rather than drawn at random, so the same position has the same key in every run of the game and keys
+// can be saved to disk. Pieces that have been captured don't count.
+func (b *Board) Hash(toMove Color) uint64 {
+	var h uint64
+	for row := range b.Rows {
+		for col := range b.Columns {
+			if piece := b.pieces[row][col]; piece != nil {
+				h ^= pieceKey(piece, row*b.Columns+col)
+			}
+		}
+	}
+	if toMove == Black {
+		h ^= blackToMove
+	}
+	return h
+}
+
+// pieceKey returns the Zobrist key for the piece on the numbered square.
+func pieceKey(piece *Piece, square int) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(piece.Name))
+	key := h.Sum64() ^ uint64(square)<<1
+	if piece.Color == Black {
+		key ^= 1
+	}
+	return mix(key)
+}
+
+// mix scrambles the bits of x so that keys built from similar inputs share no pattern. It's the finalizer from
+// the SplitMix64 generator.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
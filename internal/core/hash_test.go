@@ -0,0 +1,53 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoard_Hash(t *testing.T) {
+	game, err := NewGame()
+	require.NoError(t, err)
+	board := game.Board
+	warrior := board.GetPieceAt(Position{9, 0})
+	blackWarrior := board.GetPieceAt(Position{0, 0})
+
+	assert.Equal(t, board.Hash(White), board.Copy().Hash(White), "copies hash the same")
+	assert.NotEqual(t, board.Hash(White), board.Hash(Black), "the side to move counts")
+
+	moved, err := board.MovePiece(warrior, Position{9, 0}, Move{-1, 0})
+	require.NoError(t, err)
+	assert.NotEqual(t, board.Hash(White), moved.Hash(White), "moving a piece changes the hash")
+
+	// The same position reached by two move orders hashes the same
+	a, err := moved.MovePiece(blackWarrior, Position{0, 0}, Move{1, 0})
+	require.NoError(t, err)
+	b, err := board.MovePiece(blackWarrior, Position{0, 0}, Move{1, 0})
+	require.NoError(t, err)
+	b, err = b.MovePiece(warrior, Position{9, 0}, Move{-1, 0})
+	require.NoError(t, err)
+	assert.Equal(t, a.Hash(White), b.Hash(White))
+
+	// Keys depend only on what's on the board, not which piece structs are there
+	other, err := NewGame()
+	require.NoError(t, err)
+	assert.Equal(t, board.Hash(White), other.Board.Hash(White))
+}
+
+func TestPieceKey(t *testing.T) {
+	white := &Piece{Name: "warrior", Color: White}
+	black := &Piece{Name: "warrior", Color: Black}
+	padwar := &Piece{Name: "padwar", Color: White}
+
+	keys := map[uint64]bool{}
+	for square := range 100 {
+		for _, piece := range []*Piece{white, black, padwar} {
+			keys[pieceKey(piece, square)] = true
+		}
+	}
+	assert.Len(t, keys, 300, "every piece and square has its own key")
+}
@@ -14,6 +14,14 @@ const (
 	Black Color = "black"
 )
 
+// Opponent returns the color that plays against this one.
+func (c Color) Opponent() Color {
+	if c == White {
+		return Black
+	}
+	return White
+}
+
 // Piece is a white or black piece on the board with its associated data
 type Piece struct {
 	Name   string
@@ -31,6 +39,48 @@ func (p Piece) String() string {
 // - If a same-color piece blocks, the path ends and that position cannot be moved to.
 // - If an opposite-color piece blocks, the piece can capture it but cannot continue past.
 func (p *Piece) ValidNextPositions(start Position, b *Board) []Position {
+	return p.walkPaths(start, b, false)
+}
+
+// ThreatenedPositions returns the positions the piece attacks or defends from the given starting position. This is
+// every valid next position plus the squares of same-color pieces that block its paths: if an opponent captured
+// one of those pieces, this piece could capture back.
+func (p *Piece) ThreatenedPositions(start Position, b *Board) []Position {
+	return p.walkPaths(start, b, true)
+}
+
+// PathTo returns the squares the piece passes through moving from start to dest along the first of its paths that
+// reaches it, ending with dest, or nil if none does. Paths are blocked by pieces just as ValidNextPositions walks
+// them.
+func (p *Piece) PathTo(start, dest Position, b *Board) []Position {
+	for _, path := range p.Config.Moves {
+		var squares []Position
+		currentPos := start
+		for _, delta := range path {
+			nextPos := currentPos.Add(delta)
+			if !b.IsValid(nextPos) {
+				break
+			}
+			occupant := b.GetPieceAt(nextPos)
+			if occupant != nil && occupant.Color == p.Color {
+				break
+			}
+			squares = append(squares, nextPos)
+			if nextPos == dest {
+				return squares
+			}
+			if occupant != nil {
+				break
+			}
+			currentPos = nextPos
+		}
+	}
+	return nil
+}
+
+// walkPaths walks each of the piece's paths from start until it leaves the board or hits a piece, returning the
+// positions reached. A same-color blocker's position is included only if includeFriendly is set.
+func (p *Piece) walkPaths(start Position, b *Board, includeFriendly bool) []Position {
 	positions := make([]Position, 0)
 
 	// Process each path independently
@@ -50,6 +100,9 @@ func (p *Piece) ValidNextPositions(start Position, b *Board) []Position {
 			// If there's a piece of my color there, we're blocked along this path
 			occupant := b.GetPieceAt(nextPos)
 			if occupant != nil && occupant.Color == p.Color {
+				if includeFriendly {
+					positions = append(positions, nextPos)
+				}
 				break
 			}
 
@@ -178,3 +178,54 @@ func getTestPiece() *Piece {
 	}
 	return piece
 }
+
+func TestColor_Opponent(t *testing.T) {
+	assert.Equal(t, Black, White.Opponent())
+	assert.Equal(t, White, Black.Opponent())
+}
+
+func TestPiece_ThreatenedPositions(t *testing.T) {
+	board := createTestBoard(5, 5)
+	piece := getTestPiece()
+	friend := &Piece{Name: "friend", Color: White}
+	enemy := &Piece{Name: "enemy", Color: Black}
+	board.pieces[2][2] = piece
+	board.pieces[2][3] = friend
+	board.pieces[1][2] = enemy
+
+	threatened := piece.ThreatenedPositions(Position{2, 2}, board)
+	assert.ElementsMatch(t, []Position{
+		{3, 2}, {4, 2}, // Path down: open
+		{1, 2},         // Path up: attacks the enemy
+		{2, 3},         // Path right: defends the friend
+		{2, 1}, {2, 0}, // Path left: open
+	}, threatened)
+	assert.NotContains(t, piece.ValidNextPositions(Position{2, 2}, board), Position{2, 3},
+		"defended squares are not valid moves")
+}
+
+func TestPiece_PathTo(t *testing.T) {
+	board := createTestBoard(5, 5)
+	piece := getTestPiece()
+	board.pieces[2][2] = piece
+	board.pieces[2][3] = &Piece{Name: "friend", Color: White}
+	board.pieces[1][2] = &Piece{Name: "enemy", Color: Black}
+
+	tests := []struct {
+		name string
+		dest Position
+		want []Position
+	}{
+		{"one step", Position{3, 2}, []Position{{3, 2}}},
+		{"two steps pass through the first", Position{4, 2}, []Position{{3, 2}, {4, 2}}},
+		{"ends on a capture", Position{1, 2}, []Position{{1, 2}}},
+		{"can't pass a capture", Position{0, 2}, nil},
+		{"blocked by a friend", Position{2, 3}, nil},
+		{"not on any path", Position{3, 3}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, piece.PathTo(Position{2, 2}, tt.dest, board))
+		})
+	}
+}
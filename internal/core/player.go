@@ -8,9 +8,21 @@ type Player struct {
 	Strategy AgentStrategy
 }
 
-// NewHumanPlayer returns a new player set up as a Human.
+// DefaultProfile is the profile human players play under when they haven't chosen one.
+const DefaultProfile = "Human"
+
+// NewHumanPlayer returns a new player set up as a Human, playing under the default profile.
 func NewHumanPlayer() *Player {
-	return &Player{Name: "Human"}
+	return NewProfilePlayer(DefaultProfile)
+}
+
+// NewProfilePlayer returns a new human player who plays under the named local profile, or the default profile if
+// the name is empty.
+func NewProfilePlayer(profile string) *Player {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	return &Player{Name: profile}
 }
 
 // NewAIPlayer returns a new player object with the specified AI strategy.
@@ -18,10 +30,10 @@ func NewAIPlayer(name string, agentStrategy AgentStrategy) *Player {
 	return &Player{Name: name, Strategy: agentStrategy}
 }
 
-// String returns the name of the player, human or AI.
+// String returns the name of the player: the AI's name, or the human's profile.
 func (p Player) String() string {
-	if p.Strategy == nil {
-		return "Human"
+	if p.Strategy == nil && p.Name == "" {
+		return DefaultProfile
 	}
 	return p.Name
 }
@@ -0,0 +1,29 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayer_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		player *Player
+		want   string
+	}{
+		{"human", NewHumanPlayer(), "Human"},
+		{"profile", NewProfilePlayer("Dejah"), "Dejah"},
+		{"empty profile", NewProfilePlayer(""), "Human"},
+		{"unnamed human", &Player{}, "Human"},
+		{"AI", NewAIPlayer("The Grabber", &fixedStrategy{}), "The Grabber"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.player.String())
+			assert.Equal(t, tt.player.Strategy == nil, tt.player.IsHuman())
+		})
+	}
+}
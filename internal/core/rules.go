@@ -0,0 +1,85 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package core
+
+import "fmt"
+
+// Result is the result of a game, written the way chess results are.
+type Result string
+
+const (
+	// InProgress means the game isn't over yet.
+	InProgress Result = "*"
+	// WhiteWins means White won the game.
+	WhiteWins Result = "1-0"
+	// BlackWins means Black won the game.
+	BlackWins Result = "0-1"
+	// Draw means neither player won the game.
+	Draw Result = "1/2-1/2"
+)
+
+// WinFor returns the result where the specified color wins.
+func WinFor(color Color) Result {
+	if color == White {
+		return WhiteWins
+	}
+	return BlackWins
+}
+
+// Winner returns the color that won and true, or false if the game was drawn or isn't over.
+func (r Result) Winner() (Color, bool) {
+	switch r {
+	case WhiteWins:
+		return White, true
+	case BlackWins:
+		return Black, true
+	default:
+		return "", false
+	}
+}
+
+// Outcome is the result of a game along with the reason for it.
+type Outcome struct {
+	Result Result
+	Reason string
+}
+
+// Over returns true if the outcome ends the game.
+func (o Outcome) Over() bool {
+	return o.Result != InProgress
+}
+
+// String returns a nicely formatted string representation of the outcome.
+func (o Outcome) String() string {
+	if !o.Over() {
+		return string(o.Result)
+	}
+	return fmt.Sprintf("%s (%s)", o.Result, o.Reason)
+}
+
+// Judge applies the victory rules to the board with the specified color to move: a player who has no valid move on
+// their turn loses, whether because all of their pieces were captured or because every piece is blocked. Move
+// limits depend on the game's history, so they're up to the Game.
+func (b *Board) Judge(toMove Color) Outcome {
+	if len(b.GetPiecesByColor(toMove)) == 0 {
+		return Outcome{Result: WinFor(toMove.Opponent()), Reason: fmt.Sprintf("%s has no pieces left", toMove)}
+	}
+	if !b.HasValidActions(toMove) {
+		return Outcome{Result: WinFor(toMove.Opponent()), Reason: fmt.Sprintf("%s has no valid moves", toMove)}
+	}
+	return Outcome{Result: InProgress}
+}
+
+// HasValidActions returns true if the specified color has at least one valid action on the board. It stops looking
+// as soon as it finds one, so it's cheaper than ValidActions.
+func (b *Board) HasValidActions(color Color) bool {
+	for row := 0; row < b.Rows; row++ {
+		for col := 0; col < b.Columns; col++ {
+			piece := b.pieces[row][col]
+			if piece != nil && piece.Color == color && len(piece.ValidNextPositions(Position{row, col}, b)) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
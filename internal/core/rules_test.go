@@ -0,0 +1,63 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult_Winner(t *testing.T) {
+	tests := []struct {
+		result   Result
+		expected Color
+		won      bool
+	}{
+		{result: WhiteWins, expected: White, won: true},
+		{result: BlackWins, expected: Black, won: true},
+		{result: Draw, won: false},
+		{result: InProgress, won: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.result), func(t *testing.T) {
+			winner, won := tt.result.Winner()
+			assert.Equal(t, tt.won, won)
+			assert.Equal(t, tt.expected, winner)
+		})
+	}
+	assert.Equal(t, WhiteWins, WinFor(White))
+	assert.Equal(t, BlackWins, WinFor(Black))
+}
+
+func TestBoard_Judge(t *testing.T) {
+	mover := func(color Color) *Piece {
+		return &Piece{Name: "mover", Color: color, Config: PieceConfig{Moves: [][]Move{{{1, 0}}, {{-1, 0}}}}}
+	}
+
+	t.Run("both sides can move", func(t *testing.T) {
+		board := createTestBoard(3, 3)
+		board.pieces[1][0] = mover(White)
+		board.pieces[1][2] = mover(Black)
+		assert.False(t, board.Judge(White).Over())
+		assert.False(t, board.Judge(Black).Over())
+	})
+
+	t.Run("no pieces left loses", func(t *testing.T) {
+		board := createTestBoard(3, 3)
+		board.pieces[1][0] = mover(White)
+		outcome := board.Judge(Black)
+		assert.Equal(t, WhiteWins, outcome.Result)
+		assert.Contains(t, outcome.Reason, "no pieces")
+	})
+
+	t.Run("no valid moves loses", func(t *testing.T) {
+		board := createTestBoard(1, 2)
+		board.pieces[0][0] = mover(White)
+		board.pieces[0][1] = mover(Black)
+		outcome := board.Judge(White)
+		assert.Equal(t, BlackWins, outcome.Result)
+		assert.Contains(t, outcome.Reason, "no valid moves")
+		assert.False(t, board.HasValidActions(Black))
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package core
+
+import (
+	_ "embed"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StandardVariant is the name of the standard game, set up as in the game configuration.
+const StandardVariant = "standard"
+
+// Variant is a way of setting up the game: a board and rules of its own, played with the standard pieces.
+type Variant struct {
+	Name        string      `yaml:"name"`
+	DisplayName string      `yaml:"display_name"`
+	Board       BoardConfig `yaml:"board"`
+	Rules       RulesConfig `yaml:"rules"`
+}
+
+// String returns the name to show for the variant, falling back to its configuration name.
+func (v Variant) String() string {
+	if v.DisplayName != "" {
+		return v.DisplayName
+	}
+	return v.Name
+}
+
+// GameConfig returns the configuration for playing the variant with the pieces of the given configuration.
+func (v Variant) GameConfig(base *GameConfig) *GameConfig {
+	return &GameConfig{Pieces: base.Pieces, Board: v.Board, Rules: v.Rules}
+}
+
+var (
+	variants     []Variant
+	variantsErr  error
+	variantsOnce sync.Once
+)
+
+//go:embed variants.yml
+var variantsData []byte
+
+// GetVariants returns every variant of the game, the standard game first.
+func GetVariants() ([]Variant, error) {
+	variantsOnce.Do(func() {
+		cfg, err := GetConfig()
+		if err != nil {
+			variantsErr = err
+			return
+		}
+		var file struct {
+			Variants []Variant `yaml:"variants"`
+		}
+		if err := yaml.Unmarshal(variantsData, &file); err != nil {
+			variantsErr = fmt.Errorf("failed to unmarshal variants: %w", err)
+			return
+		}
+		standard := Variant{Name: StandardVariant, DisplayName: "Standard", Board: cfg.Board, Rules: cfg.Rules}
+		variants = append([]Variant{standard}, file.Variants...)
+	})
+	return variants, variantsErr
+}
+
+// GetVariant returns the named variant.
+func GetVariant(name string) (Variant, error) {
+	all, err := GetVariants()
+	if err != nil {
+		return Variant{}, err
+	}
+	for _, v := range all {
+		if v.Name == name {
+			return v, nil
+		}
+	}
+	return Variant{}, fmt.Errorf("variant '%s' not found", name)
+}
+
+// GetVariantConfig returns the game configuration for playing the named variant.
+func GetVariantConfig(name string) (*GameConfig, error) {
+	v, err := GetVariant(name)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return v.GameConfig(cfg), nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVariants(t *testing.T) {
+	all, err := GetVariants()
+	require.NoError(t, err)
+	require.NotEmpty(t, all)
+	assert.Equal(t, StandardVariant, all[0].Name, "the standard game should come first")
+	assert.Equal(t, "Standard", all[0].String())
+
+	standard, err := GetConfig()
+	require.NoError(t, err)
+	names := make(map[string]bool)
+	for _, v := range all {
+		t.Run(v.Name, func(t *testing.T) {
+			assert.False(t, names[v.Name], "names should be unique")
+			names[v.Name] = true
+			assert.NotEmpty(t, v.DisplayName)
+
+			cfg, err := GetVariantConfig(v.Name)
+			require.NoError(t, err)
+			assert.Equal(t, standard.Pieces, cfg.Pieces, "variants use the standard pieces")
+			game, err := NewGameWithConfig(cfg)
+			require.NoError(t, err, "the variant's board should set up")
+			assert.False(t, game.Over())
+			assert.NotEmpty(t, game.Board.ValidActions(White))
+			assert.NotEmpty(t, game.Board.ValidActions(Black))
+			assert.Positive(t, cfg.Rules.MaxMoves)
+		})
+	}
+}
+
+func TestGetVariant_Unknown(t *testing.T) {
+	_, err := GetVariant("chaturanga")
+	assert.Error(t, err)
+	_, err = GetVariantConfig("chaturanga")
+	assert.Error(t, err)
+}
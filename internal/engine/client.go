@@ -0,0 +1,332 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package engine
+
+import (
+	"bufio"
+	"context"
+	"cragspider-go/internal/core"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// startTimeout is how long an engine has to answer the start of the conversation.
+	startTimeout = 10 * time.Second
+	// defaultGrace is how long past its budget an engine may take to answer before it's told to stop, and how long
+	// it then has before it's given up on.
+	defaultGrace = time.Second
+	// defaultMaxWait is how long an engine may think about a move when there's no budget.
+	defaultMaxWait = time.Minute
+)
+
+// Engine is a bot that plays by asking another program for its moves over the engine protocol. An engine that
+// takes too long to answer is told to stop, and if it still doesn't answer, or its program exits, the Engine is
+// broken: it kills the program and every move after that is an error.
+type Engine struct {
+	Color   core.Color
+	Name    string        // What the engine calls itself, if it said
+	Grace   time.Duration // How long past its budget the engine may take, and then how long it has to stop
+	MaxWait time.Duration // How long the engine may think when there's no budget
+	in      io.Writer
+	lines   chan string
+	exitErr error        // Why the engine's output ended; only read once lines is closed
+	kill    func()       // Ends the engine's program right away
+	wait    func() error // Waits for the engine's program to end
+	variant string       // The last variant command sent
+	info    string       // The last info the engine sent
+	broken  error        // Why the engine can't be used any more
+	mu      sync.Mutex   // Guards the conversation, which is one request at a time
+}
+
+var (
+	_ core.AgentStrategy      = (*Engine)(nil)
+	_ core.TimedAgentStrategy = (*Engine)(nil)
+	_ io.Closer               = (*Engine)(nil)
+)
+
+// Start runs the command as an engine playing the specified color and waits for it to answer. What the engine
+// writes to stderr is passed through to ours.
+func Start(color core.Color, command string, args ...string) (*Engine, error) {
+	cmd := exec.Command(command, args...) //nolint:gosec
+	cmd.Stderr = os.Stderr
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to engine: %w", err)
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to engine: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("cannot start engine: %w", err)
+	}
+	e := newEngine(color, out, in, cmd.Wait, func() { _ = cmd.Process.Kill() })
+	if err := e.handshake(); err != nil {
+		e.kill()
+		return nil, fmt.Errorf("engine %s: %w", command, err)
+	}
+	return e, nil
+}
+
+// Connect talks to an engine that is already running at the other end of r and w, such as one served in this
+// program for testing. Closing the Engine closes w if it can be closed.
+func Connect(color core.Color, r io.Reader, w io.Writer) (*Engine, error) {
+	closeW := func() {
+		if c, ok := w.(io.Closer); ok {
+			_ = c.Close()
+		}
+	}
+	e := newEngine(color, r, w, nil, closeW)
+	if err := e.handshake(); err != nil {
+		closeW()
+		return nil, fmt.Errorf("engine: %w", err)
+	}
+	return e, nil
+}
+
+// newEngine returns an Engine that reads the engine's lines from r in the background and writes commands to w.
+func newEngine(color core.Color, r io.Reader, w io.Writer, wait func() error, kill func()) *Engine {
+	e := &Engine{
+		Color:   color,
+		Grace:   defaultGrace,
+		MaxWait: defaultMaxWait,
+		in:      w,
+		lines:   make(chan string, 64),
+		kill:    kill,
+		wait:    wait,
+	}
+	go func() {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			e.lines <- scanner.Text()
+		}
+		err := scanner.Err()
+		if e.wait != nil {
+			err = errors.Join(err, e.wait())
+		}
+		e.exitErr = err
+		close(e.lines)
+	}()
+	return e
+}
+
+// handshake starts the conversation and waits until the engine is ready.
+func (e *Engine) handshake() error {
+	if err := e.send("cragspider"); err != nil {
+		return err
+	}
+	deadline := time.NewTimer(startTimeout)
+	defer deadline.Stop()
+	for {
+		line, err := e.next(deadline.C)
+		if err != nil {
+			return fmt.Errorf("no answer to cragspider: %w", err)
+		}
+		if name, ok := strings.CutPrefix(line, "id name "); ok {
+			e.Name = name
+		}
+		if line == "cragspiderok" {
+			return nil
+		}
+	}
+}
+
+// NextMove returns the engine's move, giving it as long as it likes up to MaxWait.
+func (e *Engine) NextMove(board *core.Board) (*core.Action, error) {
+	return e.NextMoveContext(context.Background(), board, 0)
+}
+
+// NextMoveContext asks the engine for its move within the budget. If the context ends first, the engine is told to
+// stop and its answer is returned if it comes within the grace period.
+func (e *Engine) NextMoveContext(ctx context.Context, board *core.Board, budget time.Duration) (*core.Action, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.broken != nil {
+		return nil, e.broken
+	}
+	if err := e.prepare(board); err != nil {
+		return nil, e.fail(err)
+	}
+
+	command := "go"
+	wait := e.MaxWait
+	if budget > 0 {
+		command = fmt.Sprintf("go movetime %d", budget.Milliseconds())
+		wait = budget + e.Grace
+	}
+	if err := e.send(command); err != nil {
+		return nil, e.fail(err)
+	}
+
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+	stopped := false
+	for {
+		var line string
+		var err error
+		if stopped {
+			line, err = e.next(deadline.C)
+		} else {
+			line, err = e.nextOrDone(ctx, deadline.C)
+		}
+		switch {
+		case errors.Is(err, errTimedOut) && !stopped:
+			// Out of time or no longer wanted: ask for an answer now, and wait just a little longer
+			if err := e.send("stop"); err != nil {
+				return nil, e.fail(err)
+			}
+			stopped = true
+			deadline.Reset(e.Grace)
+			continue
+		case errors.Is(err, errTimedOut):
+			return nil, e.fail(fmt.Errorf("no move after being told to stop: %w", err))
+		case err != nil:
+			return nil, e.fail(err)
+		}
+		if info, ok := strings.CutPrefix(line, "info "); ok {
+			e.info = info
+			continue
+		}
+		if move, ok := strings.CutPrefix(line, "bestmove "); ok {
+			return e.action(board, strings.TrimSpace(move))
+		}
+	}
+}
+
+// prepare brings the engine up to date with the variant and position of the board, making sure nothing it sends
+// from an earlier search is mistaken for an answer to this one.
+func (e *Engine) prepare(board *core.Board) error {
+	if cfg := board.Config(); cfg != nil {
+		variant, err := FormatVariant(VariantOf(cfg))
+		if err != nil {
+			return err
+		}
+		if variant != e.variant {
+			if err := e.send(variant); err != nil {
+				return err
+			}
+			e.variant = variant
+		}
+	}
+	if err := e.send("isready"); err != nil {
+		return err
+	}
+	deadline := time.NewTimer(e.Grace)
+	defer deadline.Stop()
+	for {
+		line, err := e.next(deadline.C)
+		if err != nil {
+			return fmt.Errorf("no answer to isready: %w", err)
+		}
+		if line == "readyok" {
+			break
+		}
+	}
+	return e.send(FormatPosition(board, e.Color))
+}
+
+// action turns the engine's bestmove into an action on the board, checking that it's a valid move for the engine.
+func (e *Engine) action(board *core.Board, move string) (*core.Action, error) {
+	if move == "none" {
+		return nil, fmt.Errorf("engine %s has no move", e)
+	}
+	from, to, err := ParseMove(move)
+	if err != nil {
+		return nil, fmt.Errorf("engine %s: %w", e, err)
+	}
+	if !board.IsValid(from) || !board.IsValid(to) {
+		return nil, fmt.Errorf("engine %s played %s, which is off the board", e, move)
+	}
+	piece := board.GetPieceAt(from)
+	if piece == nil || piece.Color != e.Color || !slices.Contains(piece.ValidNextPositions(from, board), to) {
+		return nil, fmt.Errorf("engine %s played %s, which isn't a valid move", e, move)
+	}
+	return &core.Action{Piece: piece, Move: core.Move{to[0] - from[0], to[1] - from[1]}}, nil
+}
+
+// Info returns the last info line the engine sent about its search, without the info command.
+func (e *Engine) Info() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.info
+}
+
+// String returns the engine's name, or its color if it didn't give one.
+func (e *Engine) String() string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return string(e.Color)
+}
+
+// Close tells the engine to quit, and ends it if it doesn't within the grace period.
+func (e *Engine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.broken == nil {
+		_ = e.send("quit")
+		e.broken = fmt.Errorf("engine %s is closed", e)
+	}
+	if c, ok := e.in.(io.Closer); ok {
+		_ = c.Close()
+	}
+	deadline := time.NewTimer(e.Grace)
+	defer deadline.Stop()
+	for {
+		if _, err := e.next(deadline.C); err != nil {
+			if errors.Is(err, errTimedOut) {
+				e.kill()
+			}
+			return nil
+		}
+	}
+}
+
+// errTimedOut means the engine didn't answer in time.
+var errTimedOut = errors.New("timed out")
+
+// next returns the engine's next line, or an error if it exits or the deadline passes first.
+func (e *Engine) next(deadline <-chan time.Time) (string, error) {
+	return e.nextOrDone(context.Background(), deadline)
+}
+
+// nextOrDone is like next, but also gives up when the context ends, which counts as timing out.
+func (e *Engine) nextOrDone(ctx context.Context, deadline <-chan time.Time) (string, error) {
+	select {
+	case line, ok := <-e.lines:
+		if !ok {
+			if e.exitErr != nil {
+				return "", fmt.Errorf("engine exited: %w", e.exitErr)
+			}
+			return "", errors.New("engine exited")
+		}
+		return line, nil
+	case <-deadline:
+		return "", errTimedOut
+	case <-ctx.Done():
+		return "", errTimedOut
+	}
+}
+
+// send writes one command to the engine.
+func (e *Engine) send(line string) error {
+	if _, err := fmt.Fprintln(e.in, line); err != nil {
+		return fmt.Errorf("cannot write to engine: %w", err)
+	}
+	return nil
+}
+
+// fail breaks the engine for good, ending its program, and returns why.
+func (e *Engine) fail(err error) error {
+	e.broken = fmt.Errorf("engine %s: %w", e, err)
+	e.kill()
+	return e.broken
+}
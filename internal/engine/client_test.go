@@ -0,0 +1,203 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package engine
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// helperEnv tells the test binary to act as an engine instead of running the tests, so that Start has a real
+// program to run.
+const helperEnv = "CRAGSPIDER_ENGINE_HELPER"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(helperEnv) {
+	case "serve":
+		if err := testServer(false).Serve(context.Background(), os.Stdin, os.Stdout); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "crash":
+		// Start the conversation, then die when asked for a move
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			switch strings.Fields(scanner.Text() + " ")[0] {
+			case "cragspider":
+				fmt.Println("cragspiderok")
+			case "isready":
+				fmt.Println("readyok")
+			case "go":
+				os.Exit(3)
+			}
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// connect returns an Engine talking to the server in the background.
+func connect(t *testing.T, color core.Color, server *Server) *Engine {
+	t.Helper()
+	commands, toServer := io.Pipe()
+	fromServer, answers := io.Pipe()
+	go func() {
+		_ = server.Serve(context.Background(), commands, answers)
+		_ = answers.Close()
+	}()
+	e, err := Connect(color, fromServer, toServer)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = e.Close() })
+	return e
+}
+
+// scripted returns an Engine talking to a fake engine that answers each command with whatever respond returns.
+func scripted(t *testing.T, respond func(command string) []string) *Engine {
+	t.Helper()
+	commands, toEngine := io.Pipe()
+	fromEngine, answers := io.Pipe()
+	go func() {
+		defer answers.Close()
+		scanner := bufio.NewScanner(commands)
+		for scanner.Scan() {
+			for _, line := range respond(scanner.Text()) {
+				if _, err := fmt.Fprintln(answers, line); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	e, err := Connect(core.White, fromEngine, toEngine)
+	require.NoError(t, err)
+	e.Grace = 100 * time.Millisecond
+	t.Cleanup(func() { _ = e.Close() })
+	return e
+}
+
+// answering returns a fake engine's answers to the handshake and isready, with bestmove as its answer to go.
+func answering(bestmove []string) func(string) []string {
+	return func(command string) []string {
+		switch {
+		case command == "cragspider":
+			return []string{"id name Fake", "cragspiderok"}
+		case command == "isready":
+			return []string{"readyok"}
+		case strings.HasPrefix(command, "go"):
+			return bestmove
+		}
+		return nil
+	}
+}
+
+func TestEngine_PlaysAGame(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	white := connect(t, core.White, testServer(false))
+	black := connect(t, core.Black, testServer(false))
+	assert.Equal(t, "First Move", white.Name)
+
+	engines := map[core.Color]*Engine{core.White: white, core.Black: black}
+	for range 10 {
+		action, err := engines[game.ActiveColor].NextMoveContext(context.Background(), game.Board, time.Second)
+		require.NoError(t, err)
+		require.NoError(t, game.Play(action), "the engine's move should be valid")
+	}
+	assert.Len(t, game.Moves, 10)
+}
+
+func TestEngine_ReadsInfo(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	e := scripted(t, answering([]string{"info depth 3 score 0.5", "something else", "bestmove 9,0-8,0"}))
+
+	action, err := e.NextMove(game.Board)
+	require.NoError(t, err)
+	assert.Equal(t, core.Action{Piece: game.Board.GetPieceAt(core.Position{9, 0}), Move: core.Move{-1, 0}}, *action)
+	assert.Equal(t, "depth 3 score 0.5", e.Info())
+	assert.Equal(t, "Fake", e.String())
+}
+
+func TestEngine_InvalidMoves(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	for _, move := range []string{"none", "nonsense", "9,0-12,0", "5,5-4,5", "0,0-1,0", "9,0-5,0"} {
+		e := scripted(t, answering([]string{"bestmove " + move}))
+		_, err := e.NextMove(game.Board)
+		assert.Error(t, err, move)
+	}
+}
+
+func TestEngine_Timeout(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+
+	// An engine that answers stop is fine
+	answered := answering(nil)
+	e := scripted(t, func(command string) []string {
+		if command == "stop" {
+			return []string{"bestmove 9,0-8,0"}
+		}
+		return answered(command)
+	})
+	action, err := e.NextMoveContext(context.Background(), game.Board, 50*time.Millisecond)
+	require.NoError(t, err, "the engine answered when told to stop")
+	assert.NotNil(t, action)
+
+	// One that doesn't is broken for good
+	e = scripted(t, answering(nil))
+	start := time.Now()
+	_, err = e.NextMoveContext(context.Background(), game.Board, 50*time.Millisecond)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second, "should give up soon after the budget and grace")
+	_, err = e.NextMove(game.Board)
+	assert.Error(t, err, "a broken engine stays broken")
+
+	// Cancelling the context stops the engine too
+	e = scripted(t, answering(nil))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = e.NextMoveContext(ctx, game.Board, 0)
+	assert.Error(t, err)
+}
+
+func TestStart(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+
+	t.Setenv(helperEnv, "serve")
+	e, err := Start(core.White, os.Args[0])
+	require.NoError(t, err)
+	assert.Equal(t, "First Move", e.Name)
+	action, err := e.NextMoveContext(context.Background(), game.Board, time.Second)
+	require.NoError(t, err)
+	assert.NoError(t, game.Play(action))
+	assert.NoError(t, e.Close())
+	_, err = e.NextMove(game.Board)
+	assert.Error(t, err, "a closed engine can't move")
+}
+
+func TestStart_Crash(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+
+	t.Setenv(helperEnv, "crash")
+	e, err := Start(core.White, os.Args[0])
+	require.NoError(t, err)
+	defer e.Close()
+	_, err = e.NextMoveContext(context.Background(), game.Board, time.Second)
+	assert.ErrorContains(t, err, "exited")
+
+	_, err = Start(core.White, "/nonexistent/engine")
+	assert.Error(t, err)
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Package engine lets Cragspider engines run as separate programs that talk over stdin and stdout, so that engines
+// written by other teams, in other languages, can play, and ours can play in their tools. The protocol is modeled
+// on chess's UCI. Every message is one line of text, words separated by spaces.
+//
+// The game sends the engine:
+//
+//	cragspider                 Start of the conversation. The engine answers with optional "id name <name>" and
+//	                           "id author <author>" lines, then "cragspiderok".
+//	isready                    The engine answers "readyok" once it has dealt with everything sent before.
+//	newgame                    The next position is from a new game; forget anything learned in the last one.
+//	variant <json>             The rules for the positions to come, as {"rows":10,"columns":10,"pieces":
+//	                           [{"name":"warrior","moves":[[[1,0],[1,0]],...]}]}. Each piece's moves are paths of
+//	                           [row,col] steps that it walks until it leaves the board or reaches a piece, which it
+//	                           may capture if it's the opponent's. Until a variant is given, the standard game is.
+//	position <color> <piece>...  The position to search, with color to move. Each piece is written
+//	                           color:name@row,col, such as white:warrior@9,0.
+//	go [movetime <ms>]         Search the position, for at most the given time if there is one.
+//	stop                       Answer the search in progress as soon as possible.
+//	quit                       Exit.
+//
+// The engine sends the game:
+//
+//	bestmove <row,col>-<row,col>  The move it found, from one square to another, such as 9,0-7,0, or
+//	                              "bestmove none" if the side to move has no move.
+//	info <anything>               Whatever the engine wants to say about its search, which the game may log.
+//
+// Anything else is ignored by both sides.
+package engine
+
+import (
+	"cragspider-go/internal/core"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Variant is the rules an engine needs to know to play: the board's size and how each piece moves.
+type Variant struct {
+	Rows    int            `json:"rows"`
+	Columns int            `json:"columns"`
+	Pieces  []VariantPiece `json:"pieces"`
+}
+
+// VariantPiece is how one kind of piece moves.
+type VariantPiece struct {
+	Name  string        `json:"name"`
+	Moves [][]core.Move `json:"moves"`
+}
+
+// VariantOf returns the variant of the game configuration.
+func VariantOf(cfg *core.GameConfig) Variant {
+	v := Variant{Rows: cfg.Board.Rows, Columns: cfg.Board.Columns}
+	for _, p := range cfg.Pieces {
+		v.Pieces = append(v.Pieces, VariantPiece{Name: p.Name, Moves: p.Moves})
+	}
+	return v
+}
+
+// GameConfig returns a game configuration for the variant with the given pieces on the board.
+func (v Variant) GameConfig(pieces []PlacedPiece) *core.GameConfig {
+	cfg := &core.GameConfig{Board: core.BoardConfig{Rows: v.Rows, Columns: v.Columns}}
+	for _, p := range v.Pieces {
+		cfg.Pieces = append(cfg.Pieces, core.PieceConfig{Name: p.Name, Moves: p.Moves})
+	}
+	for _, p := range pieces {
+		pos := core.BoardPosition{Name: p.Name, Position: p.Position}
+		if p.Color == core.White {
+			cfg.Board.White = append(cfg.Board.White, pos)
+		} else {
+			cfg.Board.Black = append(cfg.Board.Black, pos)
+		}
+	}
+	return cfg
+}
+
+// FormatVariant returns the variant command for the variant.
+func FormatVariant(v Variant) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal variant: %w", err)
+	}
+	return "variant " + string(data), nil
+}
+
+// ParseVariant parses the JSON that follows the variant command.
+func ParseVariant(args string) (Variant, error) {
+	var v Variant
+	if err := json.Unmarshal([]byte(args), &v); err != nil {
+		return Variant{}, fmt.Errorf("invalid variant: %w", err)
+	}
+	if v.Rows <= 0 || v.Columns <= 0 {
+		return Variant{}, fmt.Errorf("invalid variant board size %dx%d", v.Rows, v.Columns)
+	}
+	return v, nil
+}
+
+// PlacedPiece is a piece on a square, as a position command lists it.
+type PlacedPiece struct {
+	Color    core.Color
+	Name     string
+	Position core.Position
+}
+
+// String returns the piece the way a position command writes it.
+func (p PlacedPiece) String() string {
+	return fmt.Sprintf("%s:%s@%s", p.Color, p.Name, formatSquare(p.Position))
+}
+
+// FormatPosition returns the position command for the board with the specified color to move.
+func FormatPosition(board *core.Board, toMove core.Color) string {
+	words := []string{"position", string(toMove)}
+	for row := range board.Rows {
+		for col := range board.Columns {
+			if p := board.GetPieceAt(core.Position{row, col}); p != nil {
+				words = append(words, PlacedPiece{Color: p.Color, Name: p.Name, Position: core.Position{row, col}}.String())
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// ParsePosition parses the words that follow the position command into the color to move and the pieces.
+func ParsePosition(args []string) (core.Color, []PlacedPiece, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("position needs a color to move")
+	}
+	toMove, err := parseColor(args[0])
+	if err != nil {
+		return "", nil, err
+	}
+	var pieces []PlacedPiece
+	for _, word := range args[1:] {
+		color, rest, ok := strings.Cut(word, ":")
+		name, square, ok2 := strings.Cut(rest, "@")
+		if !ok || !ok2 || name == "" {
+			return "", nil, fmt.Errorf("piece '%s' should be written as color:name@row,col", word)
+		}
+		c, err := parseColor(color)
+		if err != nil {
+			return "", nil, err
+		}
+		pos, err := parseSquare(square)
+		if err != nil {
+			return "", nil, err
+		}
+		pieces = append(pieces, PlacedPiece{Color: c, Name: name, Position: pos})
+	}
+	return toMove, pieces, nil
+}
+
+// FormatMove returns a move the way a bestmove command writes it.
+func FormatMove(from, to core.Position) string {
+	return formatSquare(from) + "-" + formatSquare(to)
+}
+
+// ParseMove parses a move written by FormatMove.
+func ParseMove(word string) (from, to core.Position, err error) {
+	f, t, ok := strings.Cut(word, "-")
+	if !ok {
+		return from, to, fmt.Errorf("move '%s' should be written as row,col-row,col", word)
+	}
+	if from, err = parseSquare(f); err != nil {
+		return from, to, err
+	}
+	if to, err = parseSquare(t); err != nil {
+		return from, to, err
+	}
+	return from, to, nil
+}
+
+// formatSquare returns a square written as row,col.
+func formatSquare(pos core.Position) string {
+	return fmt.Sprintf("%d,%d", pos[0], pos[1])
+}
+
+// parseSquare parses a square written as row,col.
+func parseSquare(word string) (core.Position, error) {
+	r, c, ok := strings.Cut(word, ",")
+	row, err := strconv.Atoi(r)
+	col, err2 := strconv.Atoi(c)
+	if !ok || err != nil || err2 != nil {
+		return core.Position{}, fmt.Errorf("square '%s' should be written as row,col", word)
+	}
+	return core.Position{row, col}, nil
+}
+
+// parseColor parses white or black.
+func parseColor(word string) (core.Color, error) {
+	switch c := core.Color(word); c {
+	case core.White, core.Black:
+		return c, nil
+	default:
+		return "", fmt.Errorf("invalid color '%s'", word)
+	}
+}
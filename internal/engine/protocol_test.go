@@ -0,0 +1,87 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatPosition(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+
+	line := FormatPosition(game.Board, core.Black)
+	words := strings.Fields(line)
+	require.Greater(t, len(words), 2)
+	assert.Equal(t, "position", words[0])
+	assert.Contains(t, words, "white:warrior@9,0")
+
+	toMove, pieces, err := ParsePosition(words[1:])
+	require.NoError(t, err)
+	assert.Equal(t, core.Black, toMove)
+	assert.Len(t, pieces, len(game.Board.GetPiecesByColor(core.White))+len(game.Board.GetPiecesByColor(core.Black)))
+
+	// The pieces make the same board again
+	cfg, err := core.GetConfig()
+	require.NoError(t, err)
+	rebuilt, err := core.NewGameWithConfig(VariantOf(cfg).GameConfig(pieces))
+	require.NoError(t, err)
+	assert.Equal(t, line, FormatPosition(rebuilt.Board, core.Black))
+}
+
+func TestParsePosition_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no color", args: nil},
+		{name: "bad color to move", args: []string{"green"}},
+		{name: "bad piece color", args: []string{"white", "green:warrior@1,1"}},
+		{name: "no square", args: []string{"white", "white:warrior"}},
+		{name: "no name", args: []string{"white", "white:@1,1"}},
+		{name: "bad square", args: []string{"white", "white:warrior@1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParsePosition(tt.args)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseMove(t *testing.T) {
+	from, to, err := ParseMove(FormatMove(core.Position{9, 0}, core.Position{7, 10}))
+	require.NoError(t, err)
+	assert.Equal(t, core.Position{9, 0}, from)
+	assert.Equal(t, core.Position{7, 10}, to)
+
+	for _, bad := range []string{"", "9,0", "9,0-7", "a,b-1,2", "9,0_7,0"} {
+		_, _, err := ParseMove(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestVariant(t *testing.T) {
+	cfg, err := core.GetConfig()
+	require.NoError(t, err)
+	line, err := FormatVariant(VariantOf(cfg))
+	require.NoError(t, err)
+	command, args, _ := strings.Cut(line, " ")
+	assert.Equal(t, "variant", command)
+	assert.NotContains(t, args, "\n", "the variant should fit on one line")
+
+	v, err := ParseVariant(args)
+	require.NoError(t, err)
+	assert.Equal(t, VariantOf(cfg), v)
+
+	_, err = ParseVariant(`{"rows":0,"columns":4}`)
+	assert.Error(t, err)
+	_, err = ParseVariant("not json")
+	assert.Error(t, err)
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package engine
+
+import (
+	"bufio"
+	"context"
+	"cragspider-go/internal/core"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server speaks the engine protocol on behalf of one of our bots, so that other programs can play it.
+type Server struct {
+	Name   string
+	Author string
+	// NewStrategy returns the bot that plays the specified color. It's called the first time each color is to move
+	// in a game, and again after a newgame or variant command.
+	NewStrategy func(color core.Color) (core.TimedAgentStrategy, error)
+}
+
+// session is the state of one conversation with a game.
+type session struct {
+	server     *Server
+	out        io.Writer
+	outMu      sync.Mutex
+	variant    Variant
+	board      *core.Board
+	toMove     core.Color
+	strategies map[core.Color]core.TimedAgentStrategy
+	stop       context.CancelFunc // Stops the search in progress, if there is one
+	searching  sync.WaitGroup
+}
+
+// Serve reads commands from r and writes answers to w until it reads quit, r runs out or the context ends.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	cfg, err := core.GetConfig()
+	if err != nil {
+		return err
+	}
+	sess := &session{server: s, out: w, variant: VariantOf(cfg), strategies: make(map[core.Color]core.TimedAgentStrategy)}
+	defer sess.stopSearch()
+
+	lines := make(chan string)
+	scanErr := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		defer close(lines)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				scanErr <- ctx.Err()
+				return
+			}
+		}
+		scanErr <- scanner.Err()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case line, ok := <-lines:
+			if !ok {
+				return <-scanErr
+			}
+			if quit := sess.handle(ctx, line); quit {
+				return nil
+			}
+		}
+	}
+}
+
+// handle carries out one command, returning true if it was quit.
+func (s *session) handle(ctx context.Context, line string) bool {
+	command, args, _ := strings.Cut(strings.TrimSpace(line), " ")
+	switch command {
+	case "cragspider":
+		if s.server.Name != "" {
+			s.send("id name " + s.server.Name)
+		}
+		if s.server.Author != "" {
+			s.send("id author " + s.server.Author)
+		}
+		s.send("cragspiderok")
+	case "isready":
+		s.send("readyok")
+	case "newgame":
+		s.stopSearch()
+		clear(s.strategies)
+	case "variant":
+		s.stopSearch()
+		v, err := ParseVariant(args)
+		if err != nil {
+			s.send("info string " + err.Error())
+			return false
+		}
+		s.variant, s.board = v, nil
+		clear(s.strategies)
+	case "position":
+		s.stopSearch()
+		if err := s.setPosition(strings.Fields(args)); err != nil {
+			s.board = nil
+			s.send("info string " + err.Error())
+		}
+	case "go":
+		s.stopSearch()
+		s.startSearch(ctx, strings.Fields(args))
+	case "stop":
+		s.stopSearch()
+	case "quit":
+		return true
+	case "":
+	default:
+		s.send("info string unknown command " + command)
+	}
+	return false
+}
+
+// setPosition sets up the board from the words of a position command.
+func (s *session) setPosition(args []string) error {
+	toMove, pieces, err := ParsePosition(args)
+	if err != nil {
+		return err
+	}
+	game, err := core.NewGameWithConfig(s.variant.GameConfig(pieces))
+	if err != nil {
+		return err
+	}
+	s.board, s.toMove = game.Board, toMove
+	return nil
+}
+
+// startSearch starts searching the position in the background, answering with bestmove when it's done.
+func (s *session) startSearch(ctx context.Context, args []string) {
+	var budget time.Duration
+	if len(args) == 2 && args[0] == "movetime" {
+		ms, err := strconv.Atoi(args[1])
+		if err != nil || ms < 0 {
+			s.send("info string invalid movetime " + args[1])
+		} else {
+			budget = time.Duration(ms) * time.Millisecond
+		}
+	}
+	if s.board == nil {
+		s.send("info string no position to search")
+		s.send("bestmove none")
+		return
+	}
+	strategy, err := s.strategy(s.toMove)
+	if err != nil {
+		s.send("info string " + err.Error())
+		s.send("bestmove none")
+		return
+	}
+
+	board, toMove := s.board, s.toMove
+	ctx, s.stop = context.WithCancel(ctx)
+	s.searching.Add(1)
+	go func() {
+		defer s.searching.Done()
+		if !board.HasValidActions(toMove) {
+			s.send("bestmove none")
+			return
+		}
+		action, err := strategy.NextMoveContext(ctx, board, budget)
+		if err == nil && action == nil {
+			err = fmt.Errorf("no move found")
+		}
+		if err != nil {
+			s.send("info string " + err.Error())
+			s.send("bestmove none")
+			return
+		}
+		from, err := board.PieceLocation(action.Piece)
+		if err != nil {
+			s.send("info string " + err.Error())
+			s.send("bestmove none")
+			return
+		}
+		s.send("bestmove " + FormatMove(from, from.Add(action.Move)))
+	}()
+}
+
+// stopSearch stops the search in progress, if there is one, and waits for it to answer.
+func (s *session) stopSearch() {
+	if s.stop != nil {
+		s.stop()
+		s.stop = nil
+	}
+	s.searching.Wait()
+}
+
+// strategy returns the bot playing color in this game.
+func (s *session) strategy(color core.Color) (core.TimedAgentStrategy, error) {
+	if strategy, ok := s.strategies[color]; ok {
+		return strategy, nil
+	}
+	strategy, err := s.server.NewStrategy(color)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create %s player: %w", color, err)
+	}
+	s.strategies[color] = strategy
+	return strategy, nil
+}
+
+// send writes one line to the game.
+func (s *session) send(line string) {
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	_, _ = fmt.Fprintln(s.out, line)
+}
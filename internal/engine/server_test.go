@@ -0,0 +1,99 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package engine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// firstMove is a bot that plays its first valid action, or waits for the context to end if told to hang.
+type firstMove struct {
+	color core.Color
+	hang  bool
+}
+
+func (f firstMove) NextMoveContext(ctx context.Context, board *core.Board, _ time.Duration) (*core.Action, error) {
+	if f.hang {
+		<-ctx.Done()
+	}
+	actions := board.ValidActions(f.color)
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("no valid moves")
+	}
+	return &actions[0], nil
+}
+
+// testServer returns a server for the firstMove bot.
+func testServer(hang bool) *Server {
+	return &Server{
+		Name:   "First Move",
+		Author: "Tests",
+		NewStrategy: func(color core.Color) (core.TimedAgentStrategy, error) {
+			return firstMove{color: color, hang: hang}, nil
+		},
+	}
+}
+
+// serve runs the server over the commands and returns what it answered, one line each.
+func serve(t *testing.T, server *Server, commands ...string) []string {
+	t.Helper()
+	var out bytes.Buffer
+	err := server.Serve(context.Background(), strings.NewReader(strings.Join(commands, "\n")+"\n"), &out)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSpace(out.String()), "\n")
+}
+
+func TestServer_Handshake(t *testing.T) {
+	lines := serve(t, testServer(false), "cragspider", "isready", "quit")
+	assert.Equal(t, []string{"id name First Move", "id author Tests", "cragspiderok", "readyok"}, lines)
+}
+
+func TestServer_Go(t *testing.T) {
+	lines := serve(t, testServer(false),
+		"position white white:warrior@9,0 black:padwar@7,5",
+		"go movetime 100",
+		"quit")
+	require.Len(t, lines, 1)
+	assert.Equal(t, "bestmove 9,0-9,1", lines[0], "should answer with the bot's move")
+
+	lines = serve(t, testServer(false), "position black white:warrior@9,0", "go")
+	assert.Equal(t, []string{"bestmove none"}, lines, "no pieces means no move")
+
+	lines = serve(t, testServer(false), "go")
+	assert.Equal(t, "bestmove none", lines[len(lines)-1], "there's no position to search")
+}
+
+func TestServer_StopsSearch(t *testing.T) {
+	lines := serve(t, testServer(true), "position white white:warrior@9,0 black:padwar@7,5", "go", "stop")
+	assert.Equal(t, []string{"bestmove 9,0-9,1"}, lines, "stop should make the bot answer")
+}
+
+func TestServer_Variant(t *testing.T) {
+	lines := serve(t, testServer(false),
+		`variant {"rows":2,"columns":2,"pieces":[{"name":"rook","moves":[[[0,1]],[[1,0]]]}]}`,
+		"position white white:rook@0,0 black:rook@1,1",
+		"go")
+	assert.Equal(t, []string{"bestmove 0,0-0,1"}, lines)
+
+	lines = serve(t, testServer(false), "variant nonsense", "position white white:warrior@9,0", "go")
+	assert.Contains(t, lines[0], "invalid variant", "a bad variant is reported")
+	assert.Equal(t, "bestmove 9,0-9,1", lines[len(lines)-1], "and the last good one is kept")
+}
+
+func TestServer_Errors(t *testing.T) {
+	lines := serve(t, testServer(false), "dance", "position white white:dragon@0,0", "go")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "info string unknown command dance", lines[0])
+	assert.Contains(t, lines[1], "dragon")
+	assert.Equal(t, "bestmove none", lines[3])
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package input
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"gopkg.in/yaml.v3"
+)
+
+// Key is a keyboard key, named in bindings files after the key.
+type Key int32
+
+// MouseButton is a mouse button, named left, right or middle in bindings files.
+type MouseButton int32
+
+// GamepadButton is a gamepad button, named in bindings files after the button on an Xbox controller.
+type GamepadButton int32
+
+// Binding is every key, mouse button and gamepad button that performs an action.
+type Binding struct {
+	Keys    []Key           `yaml:"keys,omitempty"`
+	Mouse   []MouseButton   `yaml:"mouse,omitempty"`
+	Gamepad []GamepadButton `yaml:"gamepad,omitempty"`
+}
+
+// Bindings maps each action to what performs it.
+type Bindings map[Action]Binding
+
+// bindingsFile is the layout of a bindings file.
+type bindingsFile struct {
+	Bindings Bindings `yaml:"bindings"`
+}
+
+var (
+	defaultBindings     Bindings
+	defaultBindingsErr  error
+	defaultBindingsOnce sync.Once
+)
+
+//go:embed bindings.yml
+var defaultBindingsData []byte
+
+// DefaultBindings returns the game's standard controls, from the embedded YAML file. The bindings returned are a copy
+// that can be changed freely.
+func DefaultBindings() (Bindings, error) {
+	defaultBindingsOnce.Do(func() {
+		defaultBindings, defaultBindingsErr = parseBindings(defaultBindingsData)
+		if defaultBindingsErr != nil {
+			defaultBindingsErr = fmt.Errorf("failed to load default bindings: %w", defaultBindingsErr)
+		}
+	})
+	if defaultBindingsErr != nil {
+		return nil, defaultBindingsErr
+	}
+	return maps.Clone(defaultBindings), nil
+}
+
+// DefaultPath returns where the player's controls are stored.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find config directory: %w", err)
+	}
+	return filepath.Join(dir, "cragspider", "controls.yml"), nil
+}
+
+// LoadBindings returns the standard controls, with the actions the named file binds rebound as it says. A file that
+// doesn't exist leaves the standard controls as they are.
+func LoadBindings(path string) (Bindings, error) {
+	bindings, err := DefaultBindings()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec
+	if errors.Is(err, fs.ErrNotExist) {
+		return bindings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read controls: %w", err)
+	}
+	overrides, err := parseBindings(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load controls from %s: %w", path, err)
+	}
+	maps.Copy(bindings, overrides)
+	return bindings, nil
+}
+
+// parseBindings reads a bindings file, checking that every action it binds is one the game has.
+func parseBindings(data []byte) (Bindings, error) {
+	var file bindingsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bindings: %w", err)
+	}
+	for action := range file.Bindings {
+		if !slices.Contains(Actions, action) {
+			return nil, fmt.Errorf("unknown action %q", action)
+		}
+	}
+	if file.Bindings == nil {
+		file.Bindings = make(Bindings)
+	}
+	return file.Bindings, nil
+}
+
+// keyNames names the keys that aren't letters, digits, function keys or on the keypad.
+var keyNames = map[string]Key{
+	"up":        rl.KeyUp,
+	"down":      rl.KeyDown,
+	"left":      rl.KeyLeft,
+	"right":     rl.KeyRight,
+	"enter":     rl.KeyEnter,
+	"space":     rl.KeySpace,
+	"escape":    rl.KeyEscape,
+	"backspace": rl.KeyBackspace,
+	"tab":       rl.KeyTab,
+	"delete":    rl.KeyDelete,
+	"home":      rl.KeyHome,
+	"end":       rl.KeyEnd,
+	"page_up":   rl.KeyPageUp,
+	"page_down": rl.KeyPageDown,
+	"kp_enter":  rl.KeyKpEnter,
+}
+
+// init names the letters, digits, function keys and keypad digits.
+func init() {
+	for i := range 26 {
+		keyNames[string(rune('a'+i))] = Key(rl.KeyA + i)
+	}
+	for i := range 10 {
+		keyNames[fmt.Sprint(i)] = Key(rl.KeyZero + i)
+		keyNames[fmt.Sprintf("kp%d", i)] = Key(rl.KeyKp0 + i)
+	}
+	for i := range 12 {
+		keyNames[fmt.Sprintf("f%d", i+1)] = Key(rl.KeyF1 + i)
+	}
+}
+
+// mouseButtonNames names the mouse buttons.
+var mouseButtonNames = map[string]MouseButton{
+	"left":   MouseButton(rl.MouseButtonLeft),
+	"right":  MouseButton(rl.MouseButtonRight),
+	"middle": MouseButton(rl.MouseButtonMiddle),
+}
+
+// gamepadButtonNames names the gamepad buttons.
+var gamepadButtonNames = map[string]GamepadButton{
+	"dpad_up":    rl.GamepadButtonLeftFaceUp,
+	"dpad_down":  rl.GamepadButtonLeftFaceDown,
+	"dpad_left":  rl.GamepadButtonLeftFaceLeft,
+	"dpad_right": rl.GamepadButtonLeftFaceRight,
+	"a":          rl.GamepadButtonRightFaceDown,
+	"b":          rl.GamepadButtonRightFaceRight,
+	"x":          rl.GamepadButtonRightFaceLeft,
+	"y":          rl.GamepadButtonRightFaceUp,
+	"lb":         rl.GamepadButtonLeftTrigger1,
+	"rb":         rl.GamepadButtonRightTrigger1,
+	"lt":         rl.GamepadButtonLeftTrigger2,
+	"rt":         rl.GamepadButtonRightTrigger2,
+	"back":       rl.GamepadButtonMiddleLeft,
+	"start":      rl.GamepadButtonMiddleRight,
+}
+
+// parseName returns what the name stands for in the names, ignoring case.
+func parseName[T comparable](kind string, names map[string]T, text []byte) (T, error) {
+	value, ok := names[strings.ToLower(string(text))]
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("unknown %s %q", kind, text)
+	}
+	return value, nil
+}
+
+// formatName returns the name of the value in the names.
+func formatName[T comparable](kind string, names map[string]T, value T) ([]byte, error) {
+	for name, v := range names {
+		if v == value {
+			return []byte(name), nil
+		}
+	}
+	return nil, fmt.Errorf("unnamed %s %v", kind, value)
+}
+
+// UnmarshalText reads a key from its name.
+func (k *Key) UnmarshalText(text []byte) error {
+	key, err := parseName("key", keyNames, text)
+	*k = key
+	return err
+}
+
+// MarshalText writes the key's name.
+func (k Key) MarshalText() ([]byte, error) {
+	return formatName("key", keyNames, k)
+}
+
+// UnmarshalText reads a mouse button from its name.
+func (b *MouseButton) UnmarshalText(text []byte) error {
+	button, err := parseName("mouse button", mouseButtonNames, text)
+	*b = button
+	return err
+}
+
+// MarshalText writes the mouse button's name.
+func (b MouseButton) MarshalText() ([]byte, error) {
+	return formatName("mouse button", mouseButtonNames, b)
+}
+
+// UnmarshalText reads a gamepad button from its name.
+func (b *GamepadButton) UnmarshalText(text []byte) error {
+	button, err := parseName("gamepad button", gamepadButtonNames, text)
+	*b = button
+	return err
+}
+
+// MarshalText writes the gamepad button's name.
+func (b GamepadButton) MarshalText() ([]byte, error) {
+	return formatName("gamepad button", gamepadButtonNames, b)
+}
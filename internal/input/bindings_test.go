@@ -0,0 +1,94 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDefaultBindings(t *testing.T) {
+	bindings, err := DefaultBindings()
+	require.NoError(t, err)
+	for _, action := range Actions {
+		binding, ok := bindings[action]
+		require.True(t, ok, "%s isn't bound", action)
+		assert.NotEmpty(t, binding.Keys, "%s has no key", action)
+		assert.NotEmpty(t, binding.Gamepad, "%s has no gamepad button", action)
+	}
+	assert.Equal(t, []Key{rl.KeyEnter, rl.KeySpace, rl.KeyKpEnter}, bindings[Select].Keys)
+	assert.Equal(t, []MouseButton{MouseButton(rl.MouseButtonLeft)}, bindings[Select].Mouse)
+
+	bindings[Select] = Binding{}
+	again, err := DefaultBindings()
+	require.NoError(t, err)
+	assert.NotEmpty(t, again[Select].Keys, "changing the bindings returned doesn't change the defaults")
+}
+
+func TestLoadBindings(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		check   func(t *testing.T, bindings Bindings)
+		wantErr string
+	}{
+		{
+			name: "rebinds the actions listed",
+			file: "bindings:\n  undo:\n    keys: [ z, Backspace ]\n  select:\n    gamepad: [ rb ]\n",
+			check: func(t *testing.T, bindings Bindings) {
+				assert.Equal(t, []Key{rl.KeyZ, rl.KeyBackspace}, bindings[Undo].Keys)
+				assert.Empty(t, bindings[Undo].Gamepad, "an action listed is bound only as the file says")
+				assert.Equal(t, Binding{Gamepad: []GamepadButton{rl.GamepadButtonRightTrigger1}}, bindings[Select])
+				assert.Equal(t, []Key{rl.KeyH}, bindings[Hint].Keys, "the rest keep their standard bindings")
+			},
+		},
+		{
+			name: "function and keypad keys",
+			file: "bindings:\n  menu:\n    keys: [ f10, kp0, 7 ]\n",
+			check: func(t *testing.T, bindings Bindings) {
+				assert.Equal(t, []Key{rl.KeyF10, rl.KeyKp0, rl.KeySeven}, bindings[Menu].Keys)
+			},
+		},
+		{name: "unknown key", file: "bindings:\n  undo:\n    keys: [ hyper ]\n", wantErr: `unknown key "hyper"`},
+		{name: "unknown button", file: "bindings:\n  undo:\n    gamepad: [ z ]\n", wantErr: "unknown gamepad button"},
+		{name: "unknown action", file: "bindings:\n  jump:\n    keys: [ j ]\n", wantErr: `unknown action "jump"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "controls.yml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.file), 0o600))
+			bindings, err := LoadBindings(path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, bindings)
+		})
+	}
+}
+
+func TestLoadBindings_MissingFile(t *testing.T) {
+	bindings, err := LoadBindings(filepath.Join(t.TempDir(), "controls.yml"))
+	require.NoError(t, err)
+	defaults, err := DefaultBindings()
+	require.NoError(t, err)
+	assert.Equal(t, defaults, bindings)
+}
+
+func TestBinding_RoundTrip(t *testing.T) {
+	bindings, err := DefaultBindings()
+	require.NoError(t, err)
+	data, err := yaml.Marshal(bindingsFile{Bindings: bindings})
+	require.NoError(t, err)
+	again, err := parseBindings(data)
+	require.NoError(t, err)
+	assert.Equal(t, bindings, again)
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package input
+
+import (
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Action is something a player can do, whichever key or button they do it with.
+type Action string
+
+const (
+	// CursorUp moves the board cursor up a square.
+	CursorUp Action = "cursor_up"
+	// CursorDown moves the board cursor down a square.
+	CursorDown Action = "cursor_down"
+	// CursorLeft moves the board cursor left a square.
+	CursorLeft Action = "cursor_left"
+	// CursorRight moves the board cursor right a square.
+	CursorRight Action = "cursor_right"
+	// Select picks up the piece under the cursor, or moves the piece picked up to the cursor.
+	Select Action = "select"
+	// Cancel puts down the piece picked up.
+	Cancel Action = "cancel"
+	// Undo takes back the player's last move.
+	Undo Action = "undo"
+	// Hint asks for the best move in the position.
+	Hint Action = "hint"
+	// Menu pauses the game, or resumes it.
+	Menu Action = "menu"
+	// Fullscreen switches the game between a window and fullscreen, whichever scene is showing.
+	Fullscreen Action = "fullscreen"
+)
+
+// Actions lists every action, in the order they're listed in the controls.
+var Actions = []Action{CursorUp, CursorDown, CursorLeft, CursorRight, Select, Cancel, Undo, Hint, Menu, Fullscreen}
+
+// repeats returns true if holding a key down performs the action over and over, like moving the cursor.
+func (a Action) repeats() bool {
+	switch a {
+	case CursorUp, CursorDown, CursorLeft, CursorRight:
+		return true
+	default:
+		return false
+	}
+}
+
+// Source is the kind of device an action was performed with.
+type Source int
+
+const (
+	// Keyboard is an action performed with a key.
+	Keyboard Source = iota
+	// Mouse is an action performed with a mouse button, where the mouse pointer is.
+	Mouse
+	// Gamepad is an action performed with a gamepad button.
+	Gamepad
+)
+
+// Device reports which keys and buttons were pressed this frame, where the mouse is and what was typed. The game
+// reads raylib's devices, and tests a fake.
+type Device interface {
+	// KeyPressed returns true if the key was pressed this frame, or with repeat, if it's being held down and has
+	// repeated this frame.
+	KeyPressed(key Key, repeat bool) bool
+	// MousePressed returns true if the mouse button was pressed this frame.
+	MousePressed(button MouseButton) bool
+	// GamepadPressed returns true if the button was pressed this frame on the first gamepad.
+	GamepadPressed(button GamepadButton) bool
+	// MousePosition returns where the mouse pointer is in the window.
+	MousePosition() rl.Vector2
+	// MouseDelta returns how far the mouse pointer moved since the last frame.
+	MouseDelta() rl.Vector2
+	// CharPressed returns the next character typed this frame, or zero once there are no more.
+	CharPressed() rune
+}
+
+// Controls turns the keys and buttons pressed on a device into the actions they're bound to. A nil Controls
+// performs no actions.
+type Controls struct {
+	device   Device
+	bindings Bindings
+}
+
+// NewControls returns controls that read the device with the bindings.
+func NewControls(device Device, bindings Bindings) *Controls {
+	return &Controls{device: device, bindings: bindings}
+}
+
+// Pressed returns true if the action was performed this frame.
+func (c *Controls) Pressed(action Action) bool {
+	_, ok := c.PressedWith(action)
+	return ok
+}
+
+// PressedWith returns whether the action was performed this frame, and with which kind of device. Keys are checked
+// first, then mouse buttons, then gamepad buttons.
+func (c *Controls) PressedWith(action Action) (Source, bool) {
+	if c == nil {
+		return Keyboard, false
+	}
+	binding := c.bindings[action]
+	for _, key := range binding.Keys {
+		if c.device.KeyPressed(key, action.repeats()) {
+			return Keyboard, true
+		}
+	}
+	for _, button := range binding.Mouse {
+		if c.device.MousePressed(button) {
+			return Mouse, true
+		}
+	}
+	for _, button := range binding.Gamepad {
+		if c.device.GamepadPressed(button) {
+			return Gamepad, true
+		}
+	}
+	return Keyboard, false
+}
+
+// Mouse returns where the mouse pointer is in the window.
+func (c *Controls) Mouse() rl.Vector2 {
+	if c == nil {
+		return rl.Vector2{}
+	}
+	return c.device.MousePosition()
+}
+
+// MouseMoved returns true if the mouse pointer moved since the last frame.
+func (c *Controls) MouseMoved() bool {
+	if c == nil {
+		return false
+	}
+	delta := c.device.MouseDelta()
+	return delta.X != 0 || delta.Y != 0
+}
+
+// Typed returns the next character typed this frame, or zero once there are no more. Characters are read as text,
+// whatever their keys are bound to.
+func (c *Controls) Typed() rune {
+	if c == nil {
+		return 0
+	}
+	return c.device.CharPressed()
+}
+
+// KeyName returns the name of the first key bound to the action, as it's shown to players, or "" if no key is.
+func (c *Controls) KeyName(action Action) string {
+	if c == nil || len(c.bindings[action].Keys) == 0 {
+		return ""
+	}
+	name, err := c.bindings[action].Keys[0].MarshalText()
+	if err != nil {
+		return ""
+	}
+	return strings.ToUpper(string(name))
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package input
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDevice reports the keys and buttons it's told were pressed, the keys held down long enough to repeat, where the
+// mouse is and how far it moved, and the characters typed.
+type fakeDevice struct {
+	keys     map[Key]bool
+	repeated map[Key]bool
+	mouse    map[MouseButton]bool
+	gamepad  map[GamepadButton]bool
+	position rl.Vector2
+	delta    rl.Vector2
+	typed    []rune
+}
+
+func (d *fakeDevice) KeyPressed(key Key, repeat bool) bool {
+	return d.keys[key] || (repeat && d.repeated[key])
+}
+
+func (d *fakeDevice) MousePressed(button MouseButton) bool { return d.mouse[button] }
+
+func (d *fakeDevice) GamepadPressed(button GamepadButton) bool { return d.gamepad[button] }
+
+func (d *fakeDevice) MousePosition() rl.Vector2 { return d.position }
+
+func (d *fakeDevice) MouseDelta() rl.Vector2 { return d.delta }
+
+func (d *fakeDevice) CharPressed() rune {
+	if len(d.typed) == 0 {
+		return 0
+	}
+	char := d.typed[0]
+	d.typed = d.typed[1:]
+	return char
+}
+
+func defaultControls(t *testing.T, device Device) *Controls {
+	bindings, err := DefaultBindings()
+	require.NoError(t, err)
+	return NewControls(device, bindings)
+}
+
+func TestControls_PressedWith(t *testing.T) {
+	tests := []struct {
+		name       string
+		device     fakeDevice
+		action     Action
+		wantSource Source
+		wantOK     bool
+	}{
+		{"arrow key", fakeDevice{keys: map[Key]bool{rl.KeyUp: true}}, CursorUp, Keyboard, true},
+		{"second key", fakeDevice{keys: map[Key]bool{rl.KeyW: true}}, CursorUp, Keyboard, true},
+		{"another action's key", fakeDevice{keys: map[Key]bool{rl.KeyDown: true}}, CursorUp, Keyboard, false},
+		{"held cursor key repeats", fakeDevice{repeated: map[Key]bool{rl.KeyLeft: true}}, CursorLeft, Keyboard, true},
+		{"held undo key doesn't", fakeDevice{repeated: map[Key]bool{rl.KeyU: true}}, Undo, Keyboard, false},
+		{"mouse click", fakeDevice{mouse: map[MouseButton]bool{MouseButton(rl.MouseButtonLeft): true}}, Select,
+			Mouse, true},
+		{"right click cancels", fakeDevice{mouse: map[MouseButton]bool{MouseButton(rl.MouseButtonRight): true}},
+			Cancel, Mouse, true},
+		{"gamepad", fakeDevice{gamepad: map[GamepadButton]bool{rl.GamepadButtonRightFaceDown: true}}, Select,
+			Gamepad, true},
+		{"start pauses", fakeDevice{gamepad: map[GamepadButton]bool{rl.GamepadButtonMiddleRight: true}}, Menu,
+			Gamepad, true},
+		{"nothing pressed", fakeDevice{}, Select, Keyboard, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, ok := defaultControls(t, &tt.device).PressedWith(tt.action)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantSource, source)
+		})
+	}
+}
+
+func TestControls_KeysBeforeMouse(t *testing.T) {
+	device := &fakeDevice{
+		keys:  map[Key]bool{rl.KeyEnter: true},
+		mouse: map[MouseButton]bool{MouseButton(rl.MouseButtonLeft): true},
+	}
+	source, ok := defaultControls(t, device).PressedWith(Select)
+	assert.True(t, ok)
+	assert.Equal(t, Keyboard, source)
+}
+
+func TestControls_Nil(t *testing.T) {
+	var c *Controls
+	assert.False(t, c.Pressed(Select))
+	assert.Empty(t, c.KeyName(Select))
+	assert.Equal(t, rl.Vector2{}, c.Mouse())
+	assert.False(t, c.MouseMoved())
+	assert.Zero(t, c.Typed())
+}
+
+func TestControls_MouseAndTyping(t *testing.T) {
+	device := &fakeDevice{position: rl.Vector2{X: 12, Y: 34}, typed: []rune("hi")}
+	c := defaultControls(t, device)
+	assert.Equal(t, rl.Vector2{X: 12, Y: 34}, c.Mouse())
+	assert.False(t, c.MouseMoved())
+	device.delta = rl.Vector2{Y: -1}
+	assert.True(t, c.MouseMoved())
+
+	assert.Equal(t, 'h', c.Typed())
+	assert.Equal(t, 'i', c.Typed())
+	assert.Zero(t, c.Typed(), "once everything typed has been read, there's nothing more")
+}
+
+func TestControls_KeyName(t *testing.T) {
+	c := defaultControls(t, &fakeDevice{})
+	assert.Equal(t, "H", c.KeyName(Hint))
+	assert.Equal(t, "U", c.KeyName(Undo))
+	assert.Equal(t, "P", c.KeyName(Menu))
+
+	c = NewControls(&fakeDevice{}, Bindings{Hint: {Gamepad: []GamepadButton{rl.GamepadButtonRightFaceUp}}})
+	assert.Empty(t, c.KeyName(Hint), "an action without a key has no key to name")
+}
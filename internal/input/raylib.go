@@ -0,0 +1,41 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package input
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// RaylibDevice reads the keyboard, mouse and first gamepad through raylib.
+type RaylibDevice struct{}
+
+// firstGamepad is the gamepad the game is played with.
+const firstGamepad = 0
+
+// KeyPressed returns true if the key was pressed this frame, or with repeat, if it repeated this frame.
+func (RaylibDevice) KeyPressed(key Key, repeat bool) bool {
+	return rl.IsKeyPressed(int32(key)) || (repeat && rl.IsKeyPressedRepeat(int32(key)))
+}
+
+// MousePressed returns true if the mouse button was pressed this frame.
+func (RaylibDevice) MousePressed(button MouseButton) bool {
+	return rl.IsMouseButtonPressed(rl.MouseButton(button))
+}
+
+// GamepadPressed returns true if the button was pressed this frame on the first gamepad, if there is one.
+func (RaylibDevice) GamepadPressed(button GamepadButton) bool {
+	return rl.IsGamepadAvailable(firstGamepad) && rl.IsGamepadButtonPressed(firstGamepad, int32(button))
+}
+
+// MousePosition returns where the mouse pointer is in the window.
+func (RaylibDevice) MousePosition() rl.Vector2 {
+	return rl.GetMousePosition()
+}
+
+// MouseDelta returns how far the mouse pointer moved since the last frame.
+func (RaylibDevice) MouseDelta() rl.Vector2 {
+	return rl.GetMouseDelta()
+}
+
+// CharPressed returns the next character typed this frame, or zero once there are no more.
+func (RaylibDevice) CharPressed() rune {
+	return rl.GetCharPressed()
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Package rating keeps Elo ratings for AI personalities and local human profiles, updated from the results of
+// their games and stored on disk between runs.
+package rating
+
+import (
+	"cmp"
+	"cragspider-go/internal/core"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// InitialElo is the rating given to a player before their first game.
+	InitialElo = 1500.0
+	// DefaultK is how far a single game can move a rating.
+	DefaultK = 32.0
+)
+
+// AIKey returns the key under which the named AI personality is rated.
+func AIKey(name string) string {
+	return "ai:" + name
+}
+
+// humanPrefix starts the keys of local human profiles.
+const humanPrefix = "human:"
+
+// HumanKey returns the key under which the named local human profile is rated.
+func HumanKey(profile string) string {
+	return humanPrefix + profile
+}
+
+// Rating is a player's Elo rating and the record it was built from.
+type Rating struct {
+	Key    string  `json:"key"`
+	Elo    float64 `json:"elo"`
+	Wins   int     `json:"wins"`
+	Draws  int     `json:"draws"`
+	Losses int     `json:"losses"`
+}
+
+// Games returns the number of rated games the player has played.
+func (r Rating) Games() int {
+	return r.Wins + r.Draws + r.Losses
+}
+
+// Change is how a game moved both players' ratings.
+type Change struct {
+	White, Black float64
+}
+
+// Table holds the ratings of every player that has played a rated game.
+type Table struct {
+	K       float64
+	ratings map[string]*Rating
+}
+
+// NewTable returns an empty table using the default K factor.
+func NewTable() *Table {
+	return &Table{K: DefaultK, ratings: make(map[string]*Rating)}
+}
+
+// DefaultPath returns where ratings are stored for the current user.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find config directory: %w", err)
+	}
+	return filepath.Join(dir, "cragspider", "ratings.json"), nil
+}
+
+// Load reads the ratings table from the named file. A file that doesn't exist yet gives an empty table.
+func Load(path string) (*Table, error) {
+	t := NewTable()
+	data, err := os.ReadFile(path) //nolint:gosec
+	if errors.Is(err, fs.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ratings: %w", err)
+	}
+	var ratings []Rating
+	if err := json.Unmarshal(data, &ratings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ratings: %w", err)
+	}
+	for _, r := range ratings {
+		t.ratings[r.Key] = &r
+	}
+	return t, nil
+}
+
+// Save writes the ratings table to the named file, creating its directory if needed.
+func (t *Table) Save(path string) error {
+	data, err := json.MarshalIndent(t.All(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal ratings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create ratings directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write ratings: %w", err)
+	}
+	return nil
+}
+
+// Get returns the rating for the key. Players who haven't played yet have the initial rating.
+func (t *Table) Get(key string) Rating {
+	if r, ok := t.ratings[key]; ok {
+		return *r
+	}
+	return Rating{Key: key, Elo: InitialElo}
+}
+
+// All returns every rating in the table, highest first.
+func (t *Table) All() []Rating {
+	all := make([]Rating, 0, len(t.ratings))
+	for _, r := range t.ratings {
+		all = append(all, *r)
+	}
+	slices.SortFunc(all, func(a, b Rating) int {
+		if a.Elo != b.Elo {
+			return -cmp.Compare(a.Elo, b.Elo)
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return all
+}
+
+// Profiles returns the names of the local human profiles that have played a rated game, in alphabetical order.
+func (t *Table) Profiles() []string {
+	var profiles []string
+	for key := range t.ratings {
+		if profile, ok := strings.CutPrefix(key, humanPrefix); ok {
+			profiles = append(profiles, profile)
+		}
+	}
+	slices.Sort(profiles)
+	return profiles
+}
+
+// Record updates both players' ratings with the result of a game between them and returns how far each moved.
+// A player who played themselves learns nothing from it, so their rating is left alone.
+func (t *Table) Record(white, black string, result core.Result) (Change, error) {
+	var whiteScore float64
+	switch result {
+	case core.WhiteWins:
+		whiteScore = 1
+	case core.BlackWins:
+		whiteScore = 0
+	case core.Draw:
+		whiteScore = 0.5
+	default:
+		return Change{}, fmt.Errorf("cannot rate a game with result %s", result)
+	}
+	if white == black {
+		return Change{}, nil
+	}
+
+	w, b := t.get(white), t.get(black)
+	delta := t.K * (whiteScore - Expected(w.Elo, b.Elo))
+	w.Elo += delta
+	b.Elo -= delta
+	switch whiteScore {
+	case 1:
+		w.Wins++
+		b.Losses++
+	case 0:
+		w.Losses++
+		b.Wins++
+	default:
+		w.Draws++
+		b.Draws++
+	}
+	return Change{White: delta, Black: -delta}, nil
+}
+
+// get returns the stored rating for the key, adding a new one if needed.
+func (t *Table) get(key string) *Rating {
+	if r, ok := t.ratings[key]; ok {
+		return r
+	}
+	r := &Rating{Key: key, Elo: InitialElo}
+	t.ratings[key] = r
+	return r
+}
+
+// Expected returns the score a player rated a is expected to make against a player rated b, from 0 to 1.
+func Expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package rating
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpected(t *testing.T) {
+	assert.InDelta(t, 0.5, Expected(1500, 1500), 1e-9)
+	assert.InDelta(t, 1.0, Expected(1900, 1500)+Expected(1500, 1900), 1e-9)
+	assert.InDelta(t, 0.909, Expected(1900, 1500), 0.001, "400 points is ten to one odds")
+}
+
+func TestTable_Record(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     core.Result
+		whiteDelta float64
+		white      Rating
+		black      Rating
+	}{
+		{"white wins", core.WhiteWins, 16, Rating{Key: "w", Elo: 1516, Wins: 1}, Rating{Key: "b", Elo: 1484, Losses: 1}},
+		{"black wins", core.BlackWins, -16, Rating{Key: "w", Elo: 1484, Losses: 1}, Rating{Key: "b", Elo: 1516, Wins: 1}},
+		{"draw", core.Draw, 0, Rating{Key: "w", Elo: 1500, Draws: 1}, Rating{Key: "b", Elo: 1500, Draws: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable()
+			change, err := table.Record("w", "b", tt.result)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.whiteDelta, change.White, 1e-9)
+			assert.InDelta(t, -tt.whiteDelta, change.Black, 1e-9)
+			assert.Equal(t, tt.white, table.Get("w"))
+			assert.Equal(t, tt.black, table.Get("b"))
+		})
+	}
+}
+
+func TestTable_RecordUpset(t *testing.T) {
+	table := NewTable()
+	for range 5 {
+		_, err := table.Record("strong", "weak", core.WhiteWins)
+		require.NoError(t, err)
+	}
+	expectedWin, err := table.Record("strong", "weak", core.WhiteWins)
+	require.NoError(t, err)
+	upset, err := table.Record("strong", "weak", core.BlackWins)
+	require.NoError(t, err)
+	assert.Greater(t, upset.Black, expectedWin.White, "beating a stronger player gains more")
+}
+
+func TestTable_RecordInvalid(t *testing.T) {
+	table := NewTable()
+	_, err := table.Record("w", "b", core.InProgress)
+	assert.Error(t, err)
+
+	change, err := table.Record("w", "w", core.WhiteWins)
+	require.NoError(t, err)
+	assert.Equal(t, Change{}, change)
+	assert.Empty(t, table.All(), "playing yourself isn't rated")
+}
+
+func TestTable_Profiles(t *testing.T) {
+	table := NewTable()
+	assert.Empty(t, table.Profiles())
+
+	_, err := table.Record(HumanKey("tars"), AIKey("strategist"), core.WhiteWins)
+	require.NoError(t, err)
+	_, err = table.Record(AIKey("doofus"), HumanKey("dejah"), core.Draw)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dejah", "tars"}, table.Profiles())
+}
+
+func TestTable_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "ratings.json")
+	table := NewTable()
+	_, err := table.Record(AIKey("strategist"), HumanKey("jon"), core.BlackWins)
+	require.NoError(t, err)
+	require.NoError(t, table.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, table.All(), loaded.All())
+	assert.Equal(t, "human:jon", loaded.All()[0].Key)
+}
+
+func TestLoad(t *testing.T) {
+	table, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.Empty(t, table.All())
+	assert.Equal(t, InitialElo, table.Get("anyone").Elo)
+
+	path := filepath.Join(t.TempDir(), "bad.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+	_, err = Load(path)
+	assert.Error(t, err)
+}
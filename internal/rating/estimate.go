@@ -0,0 +1,134 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package rating
+
+import (
+	"cmp"
+	"cragspider-go/internal/core"
+	"math"
+	"slices"
+)
+
+const (
+	// eloScale converts a rating difference into the natural log of the odds of winning.
+	eloScale = 400 / math.Ln10
+	// estimateIterations is how many rounds of refinement the estimate gets, far more than it needs to settle.
+	estimateIterations = 500
+	// z95 is how many standard errors either side of an estimate make a 95% confidence interval.
+	z95 = 1.96
+)
+
+// GameResult is the result of one game, for estimating ratings from a set of games.
+type GameResult struct {
+	White, Black string
+	Result       core.Result
+}
+
+// Estimate is a player's estimated rating with a 95% confidence interval.
+type Estimate struct {
+	Key       string
+	Elo       float64
+	Low, High float64
+	Games     int
+}
+
+// EstimateRatings returns the ratings that best explain the results of a set of games, such as a tournament, with
+// a 95% confidence interval for each. Unlike a Table, which moves a little with each game as it comes, this
+// weighs every game equally however it was ordered. Each player is credited with one extra draw against an
+// InitialElo opponent, which keeps a player who won or lost every game from running off to infinity. Estimates
+// are returned highest first.
+func EstimateRatings(games []GameResult) []Estimate {
+	index := make(map[string]int)
+	var keys []string
+	add := func(key string) int {
+		if i, ok := index[key]; ok {
+			return i
+		}
+		index[key] = len(keys)
+		keys = append(keys, key)
+		return len(keys) - 1
+	}
+
+	// Count each player's points and how many times each pair met
+	type pairing struct{ a, b, games int }
+	var pairings []pairing
+	var points []float64
+	played := make(map[[2]int]int)
+	for _, game := range games {
+		if game.White == game.Black {
+			continue
+		}
+		var whitePoints float64
+		switch game.Result {
+		case core.WhiteWins:
+			whitePoints = 1
+		case core.BlackWins:
+			whitePoints = 0
+		case core.Draw:
+			whitePoints = 0.5
+		default:
+			continue
+		}
+		w, b := add(game.White), add(game.Black)
+		for len(points) < len(keys) {
+			points = append(points, 0.5) // The extra draw against an InitialElo opponent
+		}
+		points[w] += whitePoints
+		points[b] += 1 - whitePoints
+		played[[2]int{min(w, b), max(w, b)}]++
+	}
+	for key, n := range played {
+		pairings = append(pairings, pairing{a: key[0], b: key[1], games: n})
+	}
+	slices.SortFunc(pairings, func(x, y pairing) int {
+		return cmp.Or(cmp.Compare(x.a, y.a), cmp.Compare(x.b, y.b))
+	})
+
+	// Newton's method, one player at a time: nudge each rating until the points it expects match the points scored
+	elo := make([]float64, len(keys))
+	for i := range elo {
+		elo[i] = InitialElo
+	}
+	information := make([]float64, len(keys))
+	for range estimateIterations {
+		for i := range keys {
+			expected, info := priorDraw(elo[i])
+			for _, p := range pairings {
+				var opponent int
+				switch i {
+				case p.a:
+					opponent = p.b
+				case p.b:
+					opponent = p.a
+				default:
+					continue
+				}
+				e := Expected(elo[i], elo[opponent])
+				expected += float64(p.games) * e
+				info += float64(p.games) * e * (1 - e)
+			}
+			elo[i] += eloScale * (points[i] - expected) / info
+			information[i] = info
+		}
+	}
+
+	estimates := make([]Estimate, len(keys))
+	for i, key := range keys {
+		margin := z95 * eloScale / math.Sqrt(information[i])
+		estimates[i] = Estimate{Key: key, Elo: elo[i], Low: elo[i] - margin, High: elo[i] + margin}
+	}
+	for _, p := range pairings {
+		estimates[p.a].Games += p.games
+		estimates[p.b].Games += p.games
+	}
+	slices.SortStableFunc(estimates, func(a, b Estimate) int {
+		return -cmp.Compare(a.Elo, b.Elo)
+	})
+	return estimates
+}
+
+// priorDraw returns the expected points and information from the extra draw each player is credited with.
+func priorDraw(elo float64) (float64, float64) {
+	e := Expected(elo, InitialElo)
+	return e, e * (1 - e)
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package rating
+
+import (
+	"testing"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateRatings(t *testing.T) {
+	var games []GameResult
+	for range 30 {
+		games = append(games,
+			GameResult{White: "a", Black: "b", Result: core.WhiteWins},
+			GameResult{White: "b", Black: "a", Result: core.Draw},
+			GameResult{White: "a", Black: "c", Result: core.Draw},
+		)
+	}
+	estimates := EstimateRatings(games)
+	require.Len(t, estimates, 3)
+	assert.Equal(t, "a", estimates[0].Key)
+
+	byKey := make(map[string]Estimate)
+	for _, e := range estimates {
+		byKey[e.Key] = e
+	}
+	assert.Equal(t, 90, byKey["a"].Games)
+	assert.Equal(t, 60, byKey["b"].Games)
+	assert.InDelta(t, byKey["a"].Elo, byKey["c"].Elo, 5, "a and c drew every game")
+	// a scored 75% against b, which is about 191 points
+	assert.InDelta(t, 191, byKey["a"].Elo-byKey["b"].Elo, 15)
+	assert.Less(t, byKey["a"].High-byKey["a"].Low, byKey["c"].High-byKey["c"].Low,
+		"more games give a narrower interval")
+}
+
+func TestEstimateRatings_PerfectScore(t *testing.T) {
+	games := []GameResult{
+		{White: "a", Black: "b", Result: core.WhiteWins},
+		{White: "b", Black: "a", Result: core.BlackWins},
+		{White: "a", Black: "a", Result: core.WhiteWins},
+		{White: "a", Black: "b", Result: core.InProgress},
+	}
+	estimates := EstimateRatings(games)
+	require.Len(t, estimates, 2)
+	assert.Equal(t, "a", estimates[0].Key)
+	assert.Equal(t, 2, estimates[0].Games, "self-play and unfinished games are ignored")
+	assert.Less(t, estimates[0].Elo, 2500.0, "a perfect score stays finite")
+	assert.Greater(t, estimates[0].High, estimates[0].Low)
+}
+
+func TestEstimateRatings_Empty(t *testing.T) {
+	assert.Empty(t, EstimateRatings(nil))
+}
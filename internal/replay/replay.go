@@ -0,0 +1,108 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Package replay saves finished games to disk with everything needed to play them back: who played, which variant
+// they played, how it ended, and every move.
+package replay
+
+import (
+	"cragspider-go/internal/core"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Replay is a finished game as saved to disk.
+type Replay struct {
+	Variant string            `json:"variant"`
+	White   string            `json:"white"`
+	Black   string            `json:"black"`
+	Result  core.Result       `json:"result"`
+	Reason  string            `json:"reason"`
+	Moves   []core.MoveRecord `json:"moves"`
+	Played  time.Time         `json:"played"`
+}
+
+// New returns a replay of the game, played on the named variant and finished at the given time.
+func New(game *core.Game, variant string, played time.Time) *Replay {
+	outcome := game.Outcome()
+	return &Replay{
+		Variant: variant,
+		White:   game.GetPlayer(core.White).String(),
+		Black:   game.GetPlayer(core.Black).String(),
+		Result:  outcome.Result,
+		Reason:  outcome.Reason,
+		Moves:   append([]core.MoveRecord(nil), game.Moves...),
+		Played:  played,
+	}
+}
+
+// DefaultDir returns the directory replays are saved to when no other is given.
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find config directory: %w", err)
+	}
+	return filepath.Join(dir, "cragspider", "replays"), nil
+}
+
+// FileName returns the name the replay is saved under in a directory of replays, from when it was played.
+func (r *Replay) FileName() string {
+	return r.Played.Format("20060102-150405") + ".json"
+}
+
+// Load reads a replay from the named file.
+func Load(path string) (*Replay, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replay: %w", err)
+	}
+	var r Replay
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal replay: %w", err)
+	}
+	return &r, nil
+}
+
+// Save writes the replay to the named file, creating its directory if needed.
+func (r *Replay) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal replay: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create replay directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write replay: %w", err)
+	}
+	return nil
+}
+
+// Game plays the replay's moves back on a new game of its variant, between human players, and returns the game as
+// it stood after the last move.
+func (r *Replay) Game() (*core.Game, error) {
+	variant := r.Variant
+	if variant == "" {
+		variant = core.StandardVariant
+	}
+	cfg, err := core.GetVariantConfig(variant)
+	if err != nil {
+		return nil, err
+	}
+	game, err := core.NewGameWithConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for i, move := range r.Moves {
+		piece := game.Board.GetPieceAt(move.From)
+		if piece == nil {
+			return nil, fmt.Errorf("cannot replay move %d (%s): no piece at %s", i+1, move, move.From)
+		}
+		if err := game.Play(&core.Action{Piece: piece, Move: move.Move()}); err != nil {
+			return nil, fmt.Errorf("cannot replay move %d (%s): %w", i+1, move, err)
+		}
+	}
+	return game, nil
+}
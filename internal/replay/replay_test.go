@@ -0,0 +1,87 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// playedGame returns a game on the named variant with the first valid action played for a few moves.
+func playedGame(t *testing.T, variant string, moves int) *core.Game {
+	cfg, err := core.GetVariantConfig(variant)
+	require.NoError(t, err)
+	game, err := core.NewGameWithConfig(cfg)
+	require.NoError(t, err)
+	for range moves {
+		actions := game.Board.ValidActions(game.ActiveColor)
+		require.NotEmpty(t, actions)
+		require.NoError(t, game.Play(&actions[0]))
+	}
+	return game
+}
+
+func TestReplay_RoundTrip(t *testing.T) {
+	game := playedGame(t, "skirmish", 6)
+	played := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	r := New(game, "skirmish", played)
+	assert.Equal(t, "Human", r.White)
+	assert.Equal(t, core.InProgress, r.Result)
+	assert.Len(t, r.Moves, 6)
+	assert.Equal(t, "20250314-150926.json", r.FileName())
+
+	path := filepath.Join(t.TempDir(), "replays", r.FileName())
+	require.NoError(t, r.Save(path))
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, r, loaded)
+
+	replayed, err := loaded.Game()
+	require.NoError(t, err)
+	assert.Equal(t, game.Moves, replayed.Moves)
+	assert.Equal(t, game.Board.Hash(game.ActiveColor), replayed.Board.Hash(replayed.ActiveColor))
+}
+
+func TestReplay_Game(t *testing.T) {
+	tests := []struct {
+		name    string
+		replay  Replay
+		wantErr string
+	}{
+		{"no variant plays the standard game", Replay{}, ""},
+		{"unknown variant", Replay{Variant: "nonesuch"}, "not found"},
+		{"no piece to move", Replay{Moves: []core.MoveRecord{{From: core.Position{4, 4}, To: core.Position{5, 4}}}},
+			"no piece at"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := tt.replay.Game()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			standard, err := core.GetVariant(core.StandardVariant)
+			require.NoError(t, err)
+			assert.Equal(t, standard.Board, game.Config().Board)
+		})
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Load(filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "failed to read replay")
+
+	path := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = Load(path)
+	assert.ErrorContains(t, err, "failed to unmarshal replay")
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"cragspider-go/internal/ai"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/input"
+	"cragspider-go/pkg/random"
+	"fmt"
+	"image/color"
+	"math/rand"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// AttractMode is the title screen. Behind the title, AI personalities play demonstration games against each other
+// on random variants, one after another, until a control is pressed to start playing.
+type AttractMode struct {
+	width, height int
+	controls      *input.Controls
+	rng           *rand.Rand
+	demo          *Playfield // The demonstration game being played or shown, or the last one during the title card
+	caption       string     // Who is playing the demonstration game, and which variant
+	phase         attractPhase
+	phaseStart    time.Time // When the phase started, or zero until the first update
+	now           time.Time // When the scene was last updated, for blinking the prompt
+}
+
+// attractPhase is what the attract mode is showing.
+type attractPhase int
+
+const (
+	// titleCard shows the title alone, before the next demonstration game starts.
+	titleCard attractPhase = iota
+	// demoPlaying shows a demonstration game being played behind the title.
+	demoPlaying
+	// demoOver shows how the demonstration game ended, before going back to the title card.
+	demoOver
+)
+
+const (
+	// titleCardTime is how long the title is shown alone between demonstration games.
+	titleCardTime = 3 * time.Second
+	// demoOverTime is how long a finished demonstration game stays on screen.
+	demoOverTime = 5 * time.Second
+	// demoMoveDelay is how long a demonstration game's pieces are shown selected before they move.
+	demoMoveDelay = 400 * time.Millisecond
+	// blinkPeriod is how long the prompt to start is shown, then hidden, as it blinks.
+	blinkPeriod = 600 * time.Millisecond
+)
+
+var (
+	_ Scene      = (*AttractMode)(nil)
+	_ controlled = (*AttractMode)(nil)
+)
+
+// NewAttractMode returns the attract mode scene.
+func NewAttractMode() *AttractMode {
+	return &AttractMode{}
+}
+
+// useControls lets any of the controls leave the title screen.
+func (a *AttractMode) useControls(controls *input.Controls) {
+	a.controls = controls
+}
+
+// Init initializes the attract mode scene with the given width and height, starting on the title card. The title
+// card's time starts with the first update.
+func (a *AttractMode) Init(width, height int) {
+	a.width, a.height = width, height
+	a.rng = random.New(time.Now().UnixNano())
+	a.phase = titleCard
+	a.phaseStart = time.Time{}
+}
+
+// Resize fits the title and the demonstration game to a window of the given size.
+func (a *AttractMode) Resize(width, height int) {
+	a.width, a.height = width, height
+	if a.demo != nil {
+		a.demo.Resize(width, height)
+	}
+}
+
+// Update shows the title and demonstration games until any of the controls but fullscreen is pressed to go to the
+// menu.
+func (a *AttractMode) Update(now time.Time) Change {
+	for _, action := range input.Actions {
+		if action != input.Fullscreen && a.controls.Pressed(action) {
+			return Switch(NewMenu(DefaultSetup()), Slide)
+		}
+	}
+	a.now = now
+	if a.phaseStart.IsZero() {
+		a.phaseStart = now
+	}
+	a.step(now)
+	return Stay()
+}
+
+// step moves the attract mode along to the given time: from the title card into a new demonstration game, through
+// the game, and back to the title card a while after it ends.
+func (a *AttractMode) step(now time.Time) {
+	switch a.phase {
+	case titleCard:
+		if now.Sub(a.phaseStart) < titleCardTime {
+			return
+		}
+		if err := a.startDemo(); err != nil {
+			rl.TraceLog(rl.LogWarning, "demonstration game unavailable: %v", err)
+			a.phaseStart = now
+			return
+		}
+		a.phase, a.phaseStart = demoPlaying, now
+	case demoPlaying:
+		a.demo.advance(now)
+		a.demo.update()
+		if a.demo.game.Over() && !a.demo.animating() {
+			a.phase, a.phaseStart = demoOver, now
+		}
+	case demoOver:
+		if now.Sub(a.phaseStart) < demoOverTime {
+			return
+		}
+		a.demo.closeGame()
+		a.phase, a.phaseStart = titleCard, now
+	}
+}
+
+// startDemo sets up a new demonstration game between random personalities on a random variant, replacing the last.
+func (a *AttractMode) startDemo() error {
+	setup, err := chooseDemo(a.rng)
+	if err != nil {
+		return err
+	}
+	base, err := core.GetConfig()
+	if err != nil {
+		return err
+	}
+	whitePlayer, err := ai.NewAIPlayer(setup.white, core.White)
+	if err != nil {
+		return err
+	}
+	blackPlayer, err := ai.NewAIPlayer(setup.black, core.Black)
+	if err != nil {
+		return err
+	}
+	g, err := core.NewGameWithConfigAndPlayers(setup.variant.GameConfig(base), whitePlayer, blackPlayer)
+	if err != nil {
+		return err
+	}
+
+	demo := &Playfield{setup: GameSetup{Animation: NormalAnimation}}
+	demo.initGame(a.width, a.height, g)
+	demo.aiDelay = demoMoveDelay
+	a.demo = demo
+	a.caption = fmt.Sprintf("%s vs %s on %s", whitePlayer.Name, blackPlayer.Name, setup.variant)
+	return nil
+}
+
+// demoSetup is who plays a demonstration game, and on which variant.
+type demoSetup struct {
+	variant      core.Variant
+	white, black string
+}
+
+// chooseDemo picks a variant and two personalities for a demonstration game at random.
+func chooseDemo(rng *rand.Rand) (demoSetup, error) {
+	variants, err := core.GetVariants()
+	if err != nil {
+		return demoSetup{}, err
+	}
+	aiConfig, err := ai.GetAIConfig()
+	if err != nil {
+		return demoSetup{}, err
+	}
+	var names []string
+	for _, player := range aiConfig.Players {
+		// Engines need a program of their own, which may not be installed
+		if player.Strategy != ai.EngineStrategy {
+			names = append(names, player.Name)
+		}
+	}
+	if len(names) == 0 {
+		return demoSetup{}, fmt.Errorf("no AI personalities to play a demonstration game")
+	}
+	return demoSetup{
+		variant: random.ChoiceFrom(rng, variants),
+		white:   random.ChoiceFrom(rng, names),
+		black:   random.ChoiceFrom(rng, names),
+	}, nil
+}
+
+// Draw draws the demonstration game, if one is showing, with the title over it.
+func (a *AttractMode) Draw() {
+	if a.phase == titleCard {
+		rl.DrawRectangle(0, 0, int32(a.width), int32(a.height), rl.Black)
+	} else {
+		rl.DrawRectangle(0, 0, int32(a.width), int32(a.height), rl.RayWhite)
+		a.demo.draw()
+		rl.DrawRectangle(0, 0, int32(a.width), int32(a.height), rl.Fade(rl.Black, 0.4))
+	}
+	a.renderTitle(a.now)
+}
+
+// renderTitle draws the title, a blinking prompt to start and, during a demonstration game, who is playing it.
+func (a *AttractMode) renderTitle(now time.Time) {
+	const (
+		titleSize   = 120
+		promptSize  = 36
+		captionSize = 24
+	)
+	centerX := int32(a.width / 2)
+	y := int32(a.height / 4)
+	drawCentered("CRAGSPIDER", centerX, y, titleSize, rl.RayWhite)
+
+	if now.UnixMilli()/blinkPeriod.Milliseconds()%2 == 0 {
+		drawCentered(a.prompt(), centerX, int32(a.height*3/4), promptSize, rl.RayWhite)
+	}
+	if a.phase != titleCard {
+		drawCentered(a.caption, centerX, int32(a.height)-2*captionSize, captionSize, rl.LightGray)
+	}
+}
+
+// prompt returns what the title asks the player to press to start, naming the select key if there is one.
+func (a *AttractMode) prompt() string {
+	if key := a.controls.KeyName(input.Select); key != "" {
+		return fmt.Sprintf("Press %s to start", key)
+	}
+	return "Press any button to start"
+}
+
+// drawCentered draws text centered horizontally on x.
+func drawCentered(text string, x, y, fontSize int32, tint color.RGBA) {
+	rl.DrawText(text, x-rl.MeasureText(text, fontSize)/2, y, fontSize, tint)
+}
+
+// Close ends the demonstration game and cleans up resources.
+func (a *AttractMode) Close() {
+	if a.demo == nil {
+		return
+	}
+	if a.phase == titleCard {
+		// The last game was closed when it finished, but its sprites are still loaded
+		a.demo.unloadSprites()
+		return
+	}
+	a.demo.Close()
+}
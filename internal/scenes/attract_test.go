@@ -0,0 +1,137 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"testing"
+	"time"
+
+	"cragspider-go/internal/ai"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/input"
+	"cragspider-go/pkg/random"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChooseDemo(t *testing.T) {
+	variants, err := core.GetVariants()
+	require.NoError(t, err)
+	aiConfig, err := ai.GetAIConfig()
+	require.NoError(t, err)
+
+	rng := random.New(1)
+	seen := make(map[string]bool)
+	for range 50 {
+		setup, err := chooseDemo(rng)
+		require.NoError(t, err)
+		assert.Contains(t, variants, setup.variant)
+		seen[setup.variant.Name] = true
+		for _, name := range []string{setup.white, setup.black} {
+			player, err := aiConfig.GetPlayerConfig(name)
+			require.NoError(t, err)
+			assert.NotEqual(t, ai.EngineStrategy, player.Strategy, "engines shouldn't play demonstration games")
+		}
+	}
+	assert.Len(t, seen, len(variants), "every variant should come up")
+}
+
+func TestAttractMode_AnyControlStarts(t *testing.T) {
+	tests := []struct {
+		name   string
+		device fakeDevice
+		want   changeOp
+	}{
+		{"nothing pressed", fakeDevice{}, stayOp},
+		{"unbound key", fakeDevice{keys: map[input.Key]bool{rl.KeyF7: true}}, stayOp},
+		{"fullscreen", fakeDevice{keys: map[input.Key]bool{rl.KeyF11: true}}, stayOp},
+		{"select", fakeDevice{keys: map[input.Key]bool{rl.KeyEnter: true}}, switchOp},
+		{"another control", fakeDevice{keys: map[input.Key]bool{rl.KeyH: true}}, switchOp},
+		{"click", fakeDevice{mouse: map[input.MouseButton]bool{input.MouseButton(rl.MouseButtonLeft): true}}, switchOp},
+		{"gamepad", fakeDevice{gamepad: map[input.GamepadButton]bool{rl.GamepadButtonMiddleRight: true}}, switchOp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			a := &AttractMode{width: 1920, height: 1080, rng: random.New(1), phaseStart: now}
+			a.useControls(defaultControls(t, &tt.device))
+			change := a.Update(now)
+			assert.Equal(t, tt.want, change.op)
+			if tt.want == switchOp {
+				assert.IsType(t, &Menu{}, change.scene)
+			}
+		})
+	}
+}
+
+func TestAttractMode_Prompt(t *testing.T) {
+	a := &AttractMode{}
+	assert.Equal(t, "Press any button to start", a.prompt())
+	a.useControls(defaultControls(t, &fakeDevice{}))
+	assert.Equal(t, "Press ENTER to start", a.prompt())
+}
+
+func TestAttractMode_TitleCardStartsWithFirstUpdate(t *testing.T) {
+	a := NewAttractMode()
+	a.Init(1920, 1080)
+	start := time.Now().Add(time.Hour)
+	assert.Equal(t, Stay(), a.Update(start))
+	assert.Equal(t, titleCard, a.phase, "the time before the first update doesn't count")
+	assert.Equal(t, start, a.phaseStart)
+
+	a.Update(start.Add(titleCardTime - time.Millisecond))
+	assert.Equal(t, titleCard, a.phase)
+	assert.Nil(t, a.demo)
+}
+
+func TestAttractMode_StartsDemoAfterTitleCard(t *testing.T) {
+	start := time.Now()
+	a := &AttractMode{width: 1920, height: 1080, rng: random.New(1), phaseStart: start}
+
+	a.step(start.Add(titleCardTime - time.Millisecond))
+	assert.Equal(t, titleCard, a.phase, "the title card should show for a while first")
+	assert.Nil(t, a.demo)
+
+	a.step(start.Add(titleCardTime))
+	require.Equal(t, demoPlaying, a.phase)
+	require.NotNil(t, a.demo)
+	defer a.demo.closeGame()
+	assert.True(t, a.demo.game.GetPlayer(core.White).IsAI())
+	assert.True(t, a.demo.game.GetPlayer(core.Black).IsAI())
+	assert.Equal(t, demoMoveDelay, a.demo.aiDelay)
+	assert.Nil(t, a.demo.analyst, "demonstration games shouldn't offer hints")
+	assert.Contains(t, a.caption, a.demo.game.GetPlayer(core.White).Name)
+}
+
+func TestAttractMode_ReturnsToTitleAfterDemo(t *testing.T) {
+	variant, err := core.GetVariant("skirmish")
+	require.NoError(t, err)
+	whitePlayer, err := ai.NewAIPlayer("grabber", core.White)
+	require.NoError(t, err)
+	blackPlayer, err := ai.NewAIPlayer("grabber", core.Black)
+	require.NoError(t, err)
+	game, err := core.NewGameWithConfigAndPlayers(variant.GameConfig(mustConfig(t)), whitePlayer, blackPlayer)
+	require.NoError(t, err)
+	demo := headlessPlayfield(game)
+	a := &AttractMode{width: 1920, height: 1080, demo: demo, phase: demoPlaying}
+
+	// The demonstration game plays itself out
+	now := time.Now()
+	deadline := now.Add(10 * time.Second)
+	for a.phase == demoPlaying {
+		require.True(t, time.Now().Before(deadline), "the demonstration game should finish in time")
+		a.step(now)
+	}
+	require.Equal(t, demoOver, a.phase)
+	assert.True(t, game.Over())
+
+	// The finished game stays on screen for a while, then the title card comes back
+	a.step(now.Add(demoOverTime - time.Millisecond))
+	assert.Equal(t, demoOver, a.phase)
+	a.step(now.Add(demoOverTime))
+	assert.Equal(t, titleCard, a.phase)
+	assert.Same(t, demo, a.demo, "the last game should be kept until the next replaces it")
+	assert.Error(t, demo.aiContext.Err(), "the finished game's players should be stopped")
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"cragspider-go/internal/input"
+	"image/color"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// buttonAction is what pressing a button does.
+type buttonAction int
+
+const (
+	rematchAction buttonAction = iota
+	menuAction
+	saveReplayAction
+	quitAction
+	startAction
+	resumeAction
+	muteAction
+)
+
+// button is a clickable label on a scene, which the controls can move to and press as well.
+type button struct {
+	label  string
+	action buttonAction
+	rect   rl.Rectangle
+}
+
+const (
+	buttonWidth    = 240
+	buttonHeight   = 56
+	buttonGap      = 24
+	buttonFontSize = 24
+)
+
+// contains returns true if the point is over the button.
+func (b button) contains(point rl.Vector2) bool {
+	return rectContains(b.rect, point)
+}
+
+// rectContains returns true if the point is inside the rectangle.
+func rectContains(rect rl.Rectangle, point rl.Vector2) bool {
+	return point.X >= rect.X && point.X < rect.X+rect.Width && point.Y >= rect.Y && point.Y < rect.Y+rect.Height
+}
+
+// layoutButtons lines the buttons up in a row centered across a window of the given width, with their tops at y.
+func layoutButtons(buttons []button, width int, y float32) {
+	rowWidth := float32(len(buttons)*buttonWidth + (len(buttons)-1)*buttonGap)
+	x := (float32(width) - rowWidth) / 2
+	for i := range buttons {
+		buttons[i].rect = rl.Rectangle{X: x, Y: y, Width: buttonWidth, Height: buttonHeight}
+		x += buttonWidth + buttonGap
+	}
+}
+
+// pressedButton returns the button pressed with the controls this frame, if any, after the left and right cursor
+// controls have moved the focus, the index of the focused button, along the row. Select presses the focused button,
+// or with the mouse, the button under it, which takes the focus. Cancel presses the button with the cancel action.
+func pressedButton(controls *input.Controls, buttons []button, focus *int, cancel buttonAction) (button, bool) {
+	if controls.Pressed(input.CursorLeft) {
+		*focus = (*focus + len(buttons) - 1) % len(buttons)
+	}
+	if controls.Pressed(input.CursorRight) {
+		*focus = (*focus + 1) % len(buttons)
+	}
+	if source, ok := controls.PressedWith(input.Select); ok {
+		if source != input.Mouse {
+			return buttons[*focus], true
+		}
+		for i, b := range buttons {
+			if b.contains(controls.Mouse()) {
+				*focus = i
+				return b, true
+			}
+		}
+	}
+	if controls.Pressed(input.Cancel) {
+		for _, b := range buttons {
+			if b.action == cancel {
+				return b, true
+			}
+		}
+	}
+	return button{}, false
+}
+
+// renderButton draws a button, lit up if the mouse is over it, outlined in gold if the controls have it focused, and
+// dimmed if it has nothing left to do.
+func renderButton(b button, hovered, focused, dimmed bool) {
+	var fill color.RGBA
+	switch {
+	case dimmed:
+		fill = rl.Fade(rl.DarkGray, 0.5)
+	case hovered:
+		fill = rl.Gray
+	default:
+		fill = rl.DarkGray
+	}
+	rl.DrawRectangleRec(b.rect, fill)
+	outline := rl.LightGray
+	if focused {
+		outline = rl.Gold
+	}
+	rl.DrawRectangleLinesEx(b.rect, 2, outline)
+	drawCentered(b.label, int32(b.rect.X+b.rect.Width/2), int32(b.rect.Y+(b.rect.Height-buttonFontSize)/2),
+		buttonFontSize, rl.RayWhite)
+}
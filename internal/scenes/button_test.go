@@ -0,0 +1,94 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"testing"
+
+	"cragspider-go/internal/input"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayoutButtons(t *testing.T) {
+	buttons := make([]button, 3)
+	layoutButtons(buttons, 1000, 500)
+
+	rowWidth := float32(3*buttonWidth + 2*buttonGap)
+	assert.Equal(t, rl.Rectangle{X: (1000 - rowWidth) / 2, Y: 500, Width: buttonWidth, Height: buttonHeight},
+		buttons[0].rect)
+	for i := 1; i < len(buttons); i++ {
+		assert.Equal(t, buttons[i-1].rect.X+buttonWidth+buttonGap, buttons[i].rect.X)
+		assert.Equal(t, buttons[0].rect.Y, buttons[i].rect.Y)
+	}
+	assert.InDelta(t, 1000-buttons[2].rect.X-buttonWidth, buttons[0].rect.X, 0.001, "the row should be centered")
+}
+
+func TestRectContains(t *testing.T) {
+	rect := rl.Rectangle{X: 10, Y: 20, Width: 30, Height: 40}
+	tests := []struct {
+		name  string
+		point rl.Vector2
+		want  bool
+	}{
+		{"top left corner", rl.Vector2{X: 10, Y: 20}, true},
+		{"inside", rl.Vector2{X: 25, Y: 45}, true},
+		{"right edge", rl.Vector2{X: 40, Y: 45}, false},
+		{"bottom edge", rl.Vector2{X: 25, Y: 60}, false},
+		{"left", rl.Vector2{X: 9, Y: 45}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rectContains(rect, tt.point))
+		})
+	}
+}
+
+func TestPressedButton(t *testing.T) {
+	buttons := []button{{action: rematchAction}, {action: menuAction}, {action: quitAction}}
+	layoutButtons(buttons, 1000, 500)
+	leftClick := map[input.MouseButton]bool{input.MouseButton(rl.MouseButtonLeft): true}
+
+	tests := []struct {
+		name      string
+		device    fakeDevice
+		mouse     rl.Vector2
+		want      buttonAction
+		pressed   bool
+		wantFocus int
+	}{
+		{"nothing pressed", fakeDevice{}, rl.Vector2{}, 0, false, 1},
+		{"select presses the focused button", fakeDevice{keys: map[input.Key]bool{rl.KeyEnter: true}}, rl.Vector2{},
+			menuAction, true, 1},
+		{"gamepad select", fakeDevice{gamepad: map[input.GamepadButton]bool{rl.GamepadButtonRightFaceDown: true}},
+			rl.Vector2{}, menuAction, true, 1},
+		{"right moves the focus", fakeDevice{keys: map[input.Key]bool{rl.KeyRight: true}}, rl.Vector2{}, 0, false, 2},
+		{"left moves the focus", fakeDevice{keys: map[input.Key]bool{rl.KeyA: true}}, rl.Vector2{}, 0, false, 0},
+		{"move then select", fakeDevice{keys: map[input.Key]bool{rl.KeyRight: true, rl.KeySpace: true}},
+			rl.Vector2{}, quitAction, true, 2},
+		{"click a button", fakeDevice{mouse: leftClick}, buttonCenter(buttons[2]), quitAction, true, 2},
+		{"click outside the buttons", fakeDevice{mouse: leftClick}, rl.Vector2{X: 5, Y: 5}, 0, false, 1},
+		{"cancel", fakeDevice{keys: map[input.Key]bool{rl.KeyBackspace: true}}, rl.Vector2{}, rematchAction, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			focus := 1
+			tt.device.position = tt.mouse
+			b, ok := pressedButton(defaultControls(t, &tt.device), buttons, &focus, rematchAction)
+			assert.Equal(t, tt.pressed, ok)
+			if tt.pressed {
+				assert.Equal(t, tt.want, b.action)
+			}
+			assert.Equal(t, tt.wantFocus, focus)
+		})
+	}
+
+	focus := 2
+	_, ok := pressedButton(nil, buttons, &focus, rematchAction)
+	assert.False(t, ok, "without controls nothing is pressed")
+}
+
+func buttonCenter(b button) rl.Vector2 {
+	return rl.Vector2{X: b.rect.X + b.rect.Width/2, Y: b.rect.Y + b.rect.Height/2}
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/input"
+	"cragspider-go/internal/rating"
+	"cragspider-go/internal/replay"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// GameSummary is how a finished game went, handed from the playfield to the game over scene.
+type GameSummary struct {
+	Setup        GameSetup
+	Game         *core.Game
+	TimeUsed     map[core.Color]time.Duration // Time each side spent on its turns
+	RatingChange *rating.Change               // How the game moved each side's rating, or nil if it wasn't rated
+	Ended        time.Time
+}
+
+// Headline returns who won the game, or that it was drawn.
+func (s *GameSummary) Headline() string {
+	winner, ok := s.Game.Outcome().Result.Winner()
+	if !ok {
+		return "Draw"
+	}
+	return fmt.Sprintf("%s wins as %s", s.Game.GetPlayer(winner), colorName(winner))
+}
+
+// Details returns the lines of the results summary beneath the headline: why the game ended, how long it was,
+// what each side captured, how much time each side took, and how the ratings moved.
+func (s *GameSummary) Details() []string {
+	white, black := s.Game.GetPlayer(core.White), s.Game.GetPlayer(core.Black)
+	lines := []string{
+		capitalize(s.Game.Outcome().Reason),
+		fmt.Sprintf("%d moves", len(s.Game.Moves)),
+		fmt.Sprintf("White captured %s", describeCaptures(s.Game.Board.GetCapturedPieces(core.White))),
+		fmt.Sprintf("Black captured %s", describeCaptures(s.Game.Board.GetCapturedPieces(core.Black))),
+		fmt.Sprintf("Time: White %s, Black %s",
+			formatClock(s.TimeUsed[core.White]), formatClock(s.TimeUsed[core.Black])),
+	}
+	if s.RatingChange != nil {
+		lines = append(lines, fmt.Sprintf("Rating: %s %+.0f, %s %+.0f",
+			white, s.RatingChange.White, black, s.RatingChange.Black))
+	}
+	return lines
+}
+
+// colorName returns the color's name as it's shown on screen.
+func colorName(color core.Color) string {
+	if color == core.White {
+		return "White"
+	}
+	return "Black"
+}
+
+// capitalize returns the text with its first letter in upper case, for showing a sentence on its own.
+func capitalize(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+// describeCaptures returns how many of each kind of piece were captured, in the order they were first captured.
+func describeCaptures(pieces []*core.Piece) string {
+	if len(pieces) == 0 {
+		return "nothing"
+	}
+	var kinds []string
+	counts := make(map[string]int)
+	for _, piece := range pieces {
+		if counts[piece.Name] == 0 {
+			kinds = append(kinds, piece.Name)
+		}
+		counts[piece.Name]++
+	}
+	parts := make([]string, len(kinds))
+	for i, kind := range kinds {
+		parts[i] = fmt.Sprintf("%d %s", counts[kind], kind)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatClock returns the time as minutes and seconds, the way clocks show it.
+func formatClock(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// GameOver is the scene after a game ends. It summarizes how the game went and offers a rematch with the colors
+// swapped, a return to the menu, saving a replay of the game, or quitting.
+type GameOver struct {
+	width, height int
+	summary       *GameSummary
+	controls      *input.Controls
+	buttons       []button
+	focus         int    // The button the controls have focused
+	rematch       bool   // Whether a rematch was chosen
+	replayDir     string // Where replays are saved; empty means replay.DefaultDir
+	saved         bool   // Whether the replay has been saved
+	message       string // What came of saving the replay
+}
+
+var (
+	_ Scene      = (*GameOver)(nil)
+	_ controlled = (*GameOver)(nil)
+)
+
+// NewGameOver returns a game over scene summarizing a game.
+func NewGameOver(summary *GameSummary) *GameOver {
+	return &GameOver{summary: summary}
+}
+
+// useControls lets the controls choose between the buttons.
+func (o *GameOver) useControls(controls *input.Controls) {
+	o.controls = controls
+}
+
+// Init initializes the game over scene with the given width and height. A scene with no game to summarize only
+// offers the menu and quitting.
+func (o *GameOver) Init(width, height int) {
+	summary := o.summary
+
+	buttons := []button{
+		{label: "Rematch", action: rematchAction},
+		{label: "Menu", action: menuAction},
+		{label: "Save Replay", action: saveReplayAction},
+		{label: "Quit", action: quitAction},
+	}
+	if summary == nil {
+		// Without a game there's nothing to play again or save
+		buttons = slices.DeleteFunc(buttons, func(b button) bool {
+			return b.action == rematchAction || b.action == saveReplayAction
+		})
+	}
+	o.buttons = buttons
+	o.focus = 0
+	o.Resize(width, height)
+}
+
+// Resize lays the buttons out for a window of the given size.
+func (o *GameOver) Resize(width, height int) {
+	o.width, o.height = width, height
+	layoutButtons(o.buttons, width, float32(height)*3/4)
+}
+
+// Update waits for one of the buttons to be pressed. Cancel goes back to the menu.
+func (o *GameOver) Update(time.Time) Change {
+	b, ok := pressedButton(o.controls, o.buttons, &o.focus, menuAction)
+	if !ok {
+		return Stay()
+	}
+	return o.choose(b.action)
+}
+
+// choose returns the change the action asks for. Saving a replay stays on the scene.
+func (o *GameOver) choose(action buttonAction) Change {
+	switch action {
+	case rematchAction:
+		o.rematch = true
+		return Switch(NewPlayfield(o.NextSetup()), Fade)
+	case menuAction:
+		return Switch(NewMenu(o.NextSetup()), Slide)
+	case saveReplayAction:
+		if o.saved {
+			return Stay()
+		}
+		path, err := o.saveReplay()
+		if err != nil {
+			o.message = fmt.Sprintf("Replay not saved: %v", err)
+			return Stay()
+		}
+		o.saved = true
+		o.message = "Replay saved to " + path
+		return Stay()
+	default:
+		return QuitGame()
+	}
+}
+
+// saveReplay saves a replay of the game to the replay directory and returns where it went.
+func (o *GameOver) saveReplay() (string, error) {
+	dir := o.replayDir
+	if dir == "" {
+		var err error
+		if dir, err = replay.DefaultDir(); err != nil {
+			return "", err
+		}
+	}
+	r := replay.New(o.summary.Game, o.summary.Setup.Variant, o.summary.Ended)
+	path := filepath.Join(dir, r.FileName())
+	if err := r.Save(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// NextSetup returns the setup for the next game: the players swap colors for a rematch, and otherwise keep them.
+func (o *GameOver) NextSetup() GameSetup {
+	if o.summary == nil {
+		return DefaultSetup()
+	}
+	if o.rematch {
+		return o.summary.Setup.Swapped()
+	}
+	return o.summary.Setup
+}
+
+// Draw draws the summary and the buttons.
+func (o *GameOver) Draw() {
+	const (
+		headlineSize = 72
+		detailSize   = 28
+		messageSize  = 20
+	)
+	rl.DrawRectangle(0, 0, int32(o.width), int32(o.height), rl.Black)
+	centerX := int32(o.width / 2)
+	y := int32(o.height / 6)
+	if o.summary != nil {
+		drawCentered(o.summary.Headline(), centerX, y, headlineSize, rl.Gold)
+		y += headlineSize + detailSize
+		for _, line := range o.summary.Details() {
+			drawCentered(line, centerX, y, detailSize, rl.RayWhite)
+			y += detailSize * 3 / 2
+		}
+	}
+
+	mouse := o.controls.Mouse()
+	for i, b := range o.buttons {
+		renderButton(b, b.contains(mouse), i == o.focus, b.action == saveReplayAction && o.saved)
+	}
+	if o.message != "" {
+		drawCentered(o.message, centerX, int32(o.buttons[0].rect.Y+o.buttons[0].rect.Height)+messageSize,
+			messageSize, rl.LightGray)
+	}
+}
+
+// Close cleans up resources. The game over scene holds none of its own.
+func (o *GameOver) Close() {}
@@ -0,0 +1,174 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/input"
+	"cragspider-go/internal/rating"
+	"cragspider-go/internal/replay"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// finishedGame returns a skirmish between humans played out with each side's first valid action until it ends.
+func finishedGame(t *testing.T) *core.Game {
+	cfg, err := core.GetVariantConfig("skirmish")
+	require.NoError(t, err)
+	game, err := core.NewGameWithConfig(cfg)
+	require.NoError(t, err)
+	for !game.Over() {
+		actions := game.Board.ValidActions(game.ActiveColor)
+		require.NoError(t, game.Play(&actions[0]))
+	}
+	return game
+}
+
+func testSummary(t *testing.T) *GameSummary {
+	return &GameSummary{
+		Setup:        GameSetup{Variant: "skirmish", Black: "doofus"},
+		Game:         finishedGame(t),
+		TimeUsed:     map[core.Color]time.Duration{core.White: 83 * time.Second, core.Black: 4 * time.Second},
+		RatingChange: &rating.Change{White: 12.4, Black: -12.4},
+		Ended:        time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC),
+	}
+}
+
+func TestGameSummary(t *testing.T) {
+	summary := testSummary(t)
+	game := summary.Game
+	outcome := game.Outcome()
+	if winner, ok := outcome.Result.Winner(); ok {
+		assert.Equal(t, "Human wins as "+colorName(winner), summary.Headline())
+	} else {
+		assert.Equal(t, "Draw", summary.Headline())
+	}
+
+	details := summary.Details()
+	require.Len(t, details, 6)
+	assert.Equal(t, capitalize(outcome.Reason), details[0])
+	assert.Contains(t, details[1], " moves")
+	assert.Contains(t, details[2], "White captured ")
+	assert.Contains(t, details[3], "Black captured ")
+	assert.Equal(t, "Time: White 1:23, Black 0:04", details[4])
+	assert.Equal(t, "Rating: Human +12, Human -12", details[5])
+
+	summary.RatingChange = nil
+	assert.Len(t, summary.Details(), 5, "unrated games have no rating line")
+}
+
+func TestDescribeCaptures(t *testing.T) {
+	warrior := &core.Piece{Name: "warrior"}
+	padwar := &core.Piece{Name: "padwar"}
+	tests := []struct {
+		name   string
+		pieces []*core.Piece
+		want   string
+	}{
+		{"none", nil, "nothing"},
+		{"one", []*core.Piece{padwar}, "1 padwar"},
+		{"counted in order of first capture", []*core.Piece{warrior, padwar, warrior}, "2 warrior, 1 padwar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, describeCaptures(tt.pieces))
+		})
+	}
+}
+
+func TestFormatTimeUsed(t *testing.T) {
+	assert.Equal(t, "0:00", formatClock(0))
+	assert.Equal(t, "0:59", formatClock(59*time.Second+200*time.Millisecond))
+	assert.Equal(t, "12:05", formatClock(12*time.Minute+5*time.Second))
+}
+
+func TestGameOver_Buttons(t *testing.T) {
+	over := NewGameOver(testSummary(t))
+	over.Init(1920, 1080)
+	require.Len(t, over.buttons, 4)
+	require.Equal(t, 0, over.focus, "the rematch button is focused first")
+
+	over.useControls(defaultControls(t, &fakeDevice{position: buttonCenter(over.buttons[3])}))
+	assert.Equal(t, Stay(), over.Update(time.Now()), "hovering doesn't press a button")
+
+	// Moving along to the replay button and selecting it saves the replay, and stays on the scene
+	over.replayDir = t.TempDir()
+	over.useControls(pressing(t, rl.KeyRight))
+	over.Update(time.Now())
+	over.Update(time.Now())
+	over.useControls(pressing(t, rl.KeyEnter))
+	assert.Equal(t, Stay(), over.Update(time.Now()))
+	assert.Equal(t, 2, over.focus)
+	assert.True(t, over.saved)
+
+	over.useControls(pressing(t, rl.KeyBackspace))
+	assert.Equal(t, switchOp, over.Update(time.Now()).op, "cancel goes back to the menu")
+
+	over.useControls(defaultControls(t, &fakeDevice{
+		mouse:    map[input.MouseButton]bool{input.MouseButton(rl.MouseButtonLeft): true},
+		position: buttonCenter(over.buttons[3]),
+	}))
+	assert.Equal(t, quitOp, over.Update(time.Now()).op)
+
+	noGame := NewGameOver(nil)
+	noGame.Init(1920, 1080)
+	assert.Len(t, noGame.buttons, 2, "without a game there's only the menu and quitting")
+	assert.Equal(t, DefaultSetup(), noGame.NextSetup())
+}
+
+func TestGameOver_Choose(t *testing.T) {
+	tests := []struct {
+		name      string
+		action    buttonAction
+		op        changeOp
+		nextSetup GameSetup
+	}{
+		{"rematch swaps colors", rematchAction, switchOp, GameSetup{Variant: "skirmish", White: "doofus"}},
+		{"menu keeps the setup", menuAction, switchOp, GameSetup{Variant: "skirmish", Black: "doofus"}},
+		{"quit", quitAction, quitOp, GameSetup{Variant: "skirmish", Black: "doofus"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			over := NewGameOver(testSummary(t))
+			over.Init(1920, 1080)
+			change := over.choose(tt.action)
+			assert.Equal(t, tt.op, change.op)
+			assert.Equal(t, tt.nextSetup, over.NextSetup())
+			switch next := change.scene.(type) {
+			case *Playfield:
+				assert.Equal(t, tt.nextSetup, next.setup)
+				assert.Equal(t, Fade, change.transition)
+			case *Menu:
+				assert.Equal(t, tt.nextSetup, next.initial)
+				assert.Equal(t, Slide, change.transition)
+			}
+		})
+	}
+}
+
+func TestGameOver_SaveReplay(t *testing.T) {
+	summary := testSummary(t)
+	over := NewGameOver(summary)
+	over.replayDir = t.TempDir()
+	over.Init(1920, 1080)
+
+	assert.Equal(t, Stay(), over.choose(saveReplayAction), "saving a replay should stay on the scene")
+	require.True(t, over.saved, over.message)
+	path := filepath.Join(over.replayDir, "20250314-150926.json")
+	assert.Equal(t, "Replay saved to "+path, over.message)
+
+	saved, err := replay.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "skirmish", saved.Variant)
+	assert.Equal(t, summary.Game.Moves, saved.Moves)
+	assert.Equal(t, summary.Game.Outcome().Result, saved.Result)
+	replayed, err := saved.Game()
+	require.NoError(t, err)
+	assert.Equal(t, summary.Game.Board.Hash(summary.Game.ActiveColor), replayed.Board.Hash(replayed.ActiveColor))
+}
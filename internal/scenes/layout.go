@@ -0,0 +1,86 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"cragspider-go/internal/core"
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// boardLayout is where the board is drawn in the window, and how big its squares are. It's worked out from the size
+// of the window and the board, and again whenever the window changes size.
+type boardLayout struct {
+	origin        rl.Vector2 // Where the upper left corner of the board is on the screen
+	square        float32    // The width and height of a square, in pixels
+	rows, columns int
+}
+
+const (
+	// layoutMarginX is the space kept clear at the left and right edges of the window.
+	layoutMarginX = 40
+	// layoutMarginY is the space kept clear above and below the board, for the status and clocks.
+	layoutMarginY = 72
+	// minSquareSize is the smallest a square gets, however small the window.
+	minSquareSize = 8
+)
+
+// newBoardLayout returns the layout that fits a board of the given size, with its captured pieces on either side,
+// centered in a window of the given size. Squares are as big as fit, in whole multiples of the sprites' size once
+// they're at least that big, so that the pixel art stays crisp.
+func newBoardLayout(width, height, rows, columns int) boardLayout {
+	across := float32(width-2*layoutMarginX-2*capturedPadding) / float32(columns+2*capturedPerRow)
+	down := float32(height-2*layoutMarginY) / float32(rows)
+	square := float32(math.Floor(float64(min(across, down))))
+	if square >= core.SpriteSize {
+		square -= float32(math.Mod(float64(square), core.SpriteSize))
+	}
+	square = max(square, minSquareSize)
+	return boardLayout{
+		origin: rl.Vector2{
+			X: (float32(width) - square*float32(columns)) / 2,
+			Y: (float32(height) - square*float32(rows)) / 2,
+		},
+		square:  square,
+		rows:    rows,
+		columns: columns,
+	}
+}
+
+// scale returns how much the sprites are scaled up to fill a square.
+func (l boardLayout) scale() float32 {
+	return l.square / core.SpriteSize
+}
+
+// width returns how wide the board is on the screen.
+func (l boardLayout) width() float32 {
+	return l.square * float32(l.columns)
+}
+
+// height returns how tall the board is on the screen.
+func (l boardLayout) height() float32 {
+	return l.square * float32(l.rows)
+}
+
+// squareLocation returns where the upper left corner of the square at the position is on the screen.
+func (l boardLayout) squareLocation(pos core.Position) rl.Vector2 {
+	return rl.Vector2{X: l.origin.X + float32(pos[1])*l.square, Y: l.origin.Y + float32(pos[0])*l.square}
+}
+
+// contains returns true if the point on the screen is over the board.
+func (l boardLayout) contains(point rl.Vector2) bool {
+	x, y := point.X-l.origin.X, point.Y-l.origin.Y
+	return x >= 0 && x < l.width() && y >= 0 && y < l.height()
+}
+
+// positionAt returns the position of the square under the point on the screen, and false if the point isn't over
+// the board.
+func (l boardLayout) positionAt(point rl.Vector2) (core.Position, bool) {
+	if !l.contains(point) || l.square <= 0 {
+		return core.Position{}, false
+	}
+	row := int((point.Y - l.origin.Y) / l.square)
+	col := int((point.X - l.origin.X) / l.square)
+	return core.Position{min(row, l.rows-1), min(col, l.columns-1)}, true
+}
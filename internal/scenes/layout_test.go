@@ -0,0 +1,106 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"testing"
+
+	"cragspider-go/internal/core"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSquareSize is the size of the squares in tests, the size they are in a 1920x1080 window.
+const testSquareSize = 72
+
+// testLayout returns a layout for the game's board with its upper left corner at x, y and squares testSquareSize
+// across.
+func testLayout(game *core.Game, x, y float32) boardLayout {
+	return boardLayout{
+		origin:  rl.Vector2{X: x, Y: y},
+		square:  testSquareSize,
+		rows:    game.Board.Rows,
+		columns: game.Board.Columns,
+	}
+}
+
+func TestNewBoardLayout(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		rows, columns int
+		wantSquare    float32
+	}{
+		{"full HD", 1920, 1080, 10, 10, 72},
+		{"4K", 3840, 2160, 10, 10, 192},
+		{"laptop", 1366, 768, 10, 10, 48},
+		{"narrow window is limited by its width", 900, 1080, 10, 10, 48},
+		{"small window isn't snapped to the sprites", 800, 300, 10, 10, 15},
+		{"tiny window keeps the smallest squares", 200, 200, 10, 10, minSquareSize},
+		{"small board", 1920, 1080, 8, 8, 96},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newBoardLayout(tt.width, tt.height, tt.rows, tt.columns)
+			assert.Equal(t, tt.wantSquare, l.square)
+			assert.Equal(t, tt.wantSquare/core.SpriteSize, l.scale())
+			assert.Equal(t, float32(tt.width)/2, l.origin.X+l.width()/2, "the board is centered across")
+			assert.Equal(t, float32(tt.height)/2, l.origin.Y+l.height()/2, "the board is centered down")
+		})
+	}
+}
+
+func TestBoardLayout_PositionAt(t *testing.T) {
+	l := boardLayout{origin: rl.Vector2{X: 100, Y: 50}, square: 37.5, rows: 8, columns: 10}
+	tests := []struct {
+		name   string
+		point  rl.Vector2
+		want   core.Position
+		wantOK bool
+	}{
+		{"upper left corner", rl.Vector2{X: 100, Y: 50}, core.Position{0, 0}, true},
+		{"middle of a square", rl.Vector2{X: 100 + 2.5*37.5, Y: 50 + 1.5*37.5}, core.Position{1, 2}, true},
+		{"just inside the last square", rl.Vector2{X: 100 + 375 - 0.01, Y: 50 + 300 - 0.01}, core.Position{7, 9}, true},
+		{"right edge is outside", rl.Vector2{X: 100 + 375, Y: 60}, core.Position{}, false},
+		{"bottom edge is outside", rl.Vector2{X: 110, Y: 50 + 300}, core.Position{}, false},
+		{"above the board", rl.Vector2{X: 110, Y: 49}, core.Position{}, false},
+		{"left of the board", rl.Vector2{X: 99, Y: 60}, core.Position{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos, ok := l.positionAt(tt.point)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, pos)
+			if ok {
+				corner := l.squareLocation(pos)
+				assert.LessOrEqual(t, corner.X, tt.point.X, "the point is in the square it's said to be in")
+				assert.LessOrEqual(t, corner.Y, tt.point.Y)
+			}
+		})
+	}
+}
+
+func TestPlayfield_Resize(t *testing.T) {
+	game, err := core.NewGame()
+	assert.NoError(t, err)
+	pf := headlessPlayfield(game)
+	pf.setup.Animation = NormalAnimation
+	pf.Resize(1920, 1080)
+	assert.Equal(t, float32(72), pf.layout.square)
+
+	action := game.Board.ValidActions(core.White)[0]
+	from, err := game.Board.PieceLocation(action.Piece)
+	assert.NoError(t, err)
+	assert.NoError(t, pf.play(&action))
+	assert.NotNil(t, pf.animation)
+
+	pf.Resize(3840, 2160)
+	assert.Equal(t, float32(192), pf.layout.square)
+	assert.Nil(t, pf.animation, "the animation's path was for the old size")
+
+	// Clicking the middle of a square finds it at the new size
+	dest := from.Add(action.Move)
+	middle := rl.Vector2Add(pf.squareLocation(dest), rl.Vector2{X: 96, Y: 96})
+	assert.Same(t, action.Piece, pf.PieceUnderMouse(middle))
+}
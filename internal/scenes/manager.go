@@ -0,0 +1,228 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"cragspider-go/internal/audio"
+	"cragspider-go/internal/input"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Manager owns the game's frame loop and its stack of scenes. Each frame it updates the scene on top of the stack,
+// carries out the change it asks for, and draws the stack: the top scene, and any scenes beneath that show through
+// the overlays above them.
+type Manager struct {
+	width, height    int
+	sounds           *audio.Manager
+	controls         *input.Controls
+	toggleFullscreen func() // Switches the window in or out of fullscreen
+	stack            []Scene
+	transition       *transition // The switch between scenes underway, if there is one
+	done             bool
+}
+
+// transition is a switch from one stack of scenes to another, drawn over a little time. Neither stack is updated
+// until it's over.
+type transition struct {
+	kind  Transition
+	from  []Scene // The scenes being left, closed when the transition is over
+	start time.Time
+}
+
+// progress returns how far through the transition is at the given time, from 0 to 1.
+func (t *transition) progress(now time.Time) float32 {
+	return min(max(float32(now.Sub(t.start))/float32(transitionTime), 0), 1)
+}
+
+// NewManager returns a manager for a window of the given size, starting with the scene. Scenes that play sounds
+// play them through sounds, which may be nil to keep the game silent, and scenes played with the controls read them
+// from controls.
+func NewManager(width, height int, sounds *audio.Manager, controls *input.Controls, first Scene) *Manager {
+	m := &Manager{
+		width: width, height: height, sounds: sounds, controls: controls,
+		toggleFullscreen: rl.ToggleBorderlessWindowed,
+	}
+	m.enter(first)
+	m.stack = []Scene{first}
+	return m
+}
+
+// enter hands the scene the game's sounds and controls, if it uses them, and initializes it for the window.
+func (m *Manager) enter(scene Scene) {
+	if a, ok := scene.(audible); ok {
+		a.useSounds(m.sounds)
+	}
+	if c, ok := scene.(controlled); ok {
+		c.useControls(m.controls)
+	}
+	scene.Init(m.width, m.height)
+}
+
+// Run runs frames until the game is quit or the window is closed. The scenes are laid out again whenever the
+// window's size changes.
+func (m *Manager) Run() {
+	for !m.done && !rl.WindowShouldClose() {
+		now := time.Now()
+		m.resize(rl.GetScreenWidth(), rl.GetScreenHeight())
+		m.step(now)
+		m.draw(now)
+	}
+}
+
+// resize lays every scene out again for a window of the given size, if it has changed.
+func (m *Manager) resize(width, height int) {
+	if width == m.width && height == m.height {
+		return
+	}
+	m.width, m.height = width, height
+	for _, scene := range m.stack {
+		scene.Resize(width, height)
+	}
+	if m.transition != nil {
+		for _, scene := range m.transition.from {
+			scene.Resize(width, height)
+		}
+	}
+}
+
+// Done returns true once the game has been quit.
+func (m *Manager) Done() bool {
+	return m.done
+}
+
+// step keeps the music playing, switches in or out of fullscreen when the fullscreen control is pressed, updates the
+// top scene and carries out the change it asks for. No scene is updated while a transition is underway.
+func (m *Manager) step(now time.Time) {
+	m.sounds.Update()
+	if m.controls.Pressed(input.Fullscreen) {
+		m.toggleFullscreen()
+	}
+	if m.transition != nil {
+		if m.transition.progress(now) < 1 {
+			return
+		}
+		closeAll(m.transition.from)
+		m.transition = nil
+	}
+	if len(m.stack) == 0 {
+		m.done = true
+		return
+	}
+	m.apply(m.stack[len(m.stack)-1].Update(now), now)
+}
+
+// apply carries out a change to the scene stack.
+func (m *Manager) apply(change Change, now time.Time) {
+	switch change.op {
+	case switchOp:
+		m.enter(change.scene)
+		from := m.stack
+		m.stack = []Scene{change.scene}
+		if change.transition == Cut {
+			closeAll(from)
+			return
+		}
+		m.transition = &transition{kind: change.transition, from: from, start: now}
+	case pushOp:
+		m.enter(change.scene)
+		m.stack = append(m.stack, change.scene)
+	case popOp:
+		top := len(m.stack) - 1
+		m.stack[top].Close()
+		m.stack = m.stack[:top]
+		if top == 0 {
+			m.done = true
+			return
+		}
+		if resumer, ok := m.stack[top-1].(Resumer); ok {
+			resumer.Resume(change.result)
+		}
+	case quitOp:
+		m.done = true
+	}
+}
+
+// draw draws the scenes showing, in the middle of a transition if there is one.
+func (m *Manager) draw(now time.Time) {
+	rl.BeginDrawing()
+	rl.ClearBackground(rl.Black)
+	t := m.transition
+	switch {
+	case t == nil:
+		drawStack(m.stack)
+	case t.kind == Slide:
+		from, to := slideOffsets(t.progress(now), m.width)
+		drawShifted(t.from, from)
+		drawShifted(m.stack, to)
+	default:
+		// Fade the old scenes out to black, then the new ones in
+		alpha, old := fadeAlpha(t.progress(now))
+		if old {
+			drawStack(t.from)
+		} else {
+			drawStack(m.stack)
+		}
+		rl.DrawRectangle(0, 0, int32(m.width), int32(m.height), rl.Fade(rl.Black, alpha))
+	}
+	rl.EndDrawing()
+}
+
+// fadeAlpha returns how dark the screen is at the given point in a fade, and whether the old scenes are still the
+// ones showing.
+func fadeAlpha(progress float32) (float32, bool) {
+	if progress < 0.5 {
+		return progress * 2, true
+	}
+	return (1 - progress) * 2, false
+}
+
+// slideOffsets returns how far across the old and new scenes are at the given point in a slide.
+func slideOffsets(progress float32, width int) (float32, float32) {
+	return -progress * float32(width), (1 - progress) * float32(width)
+}
+
+// drawShifted draws the scenes moved across by x.
+func drawShifted(stack []Scene, x float32) {
+	rl.BeginMode2D(rl.Camera2D{Offset: rl.Vector2{X: x}, Zoom: 1})
+	drawStack(stack)
+	rl.EndMode2D()
+}
+
+// drawStack draws the top scene of the stack, over the scenes beneath it that show through overlays.
+func drawStack(stack []Scene) {
+	for _, scene := range stack[visibleFrom(stack):] {
+		scene.Draw()
+	}
+}
+
+// visibleFrom returns the index of the lowest scene on the stack that can be seen: the one beneath the overlays on
+// top.
+func visibleFrom(stack []Scene) int {
+	i := len(stack) - 1
+	for i > 0 {
+		if _, ok := stack[i].(Overlay); !ok {
+			break
+		}
+		i--
+	}
+	return max(i, 0)
+}
+
+// closeAll closes the scenes, top first.
+func closeAll(stack []Scene) {
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].Close()
+	}
+}
+
+// Close closes every scene still open.
+func (m *Manager) Close() {
+	if m.transition != nil {
+		closeAll(m.transition.from)
+		m.transition = nil
+	}
+	closeAll(m.stack)
+	m.stack = nil
+}
@@ -0,0 +1,303 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"testing"
+	"time"
+
+	"cragspider-go/internal/audio"
+	"cragspider-go/internal/input"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeScene records what the manager does with it, and asks for whatever change it's told to next.
+type fakeScene struct {
+	inits   int
+	size    [2]int
+	updates int
+	closed  int
+	resumed []any
+	next    Change
+}
+
+func (s *fakeScene) Init(width, height int) {
+	s.inits++
+	s.size = [2]int{width, height}
+}
+
+func (s *fakeScene) Resize(width, height int) { s.size = [2]int{width, height} }
+
+func (s *fakeScene) Update(time.Time) Change {
+	s.updates++
+	change := s.next
+	s.next = Stay()
+	return change
+}
+
+func (s *fakeScene) Draw() {}
+
+func (s *fakeScene) Close() { s.closed++ }
+
+func (s *fakeScene) Resume(result any) { s.resumed = append(s.resumed, result) }
+
+// fakeOverlay is a fake scene drawn over the ones beneath it.
+type fakeOverlay struct {
+	fakeScene
+}
+
+func (o *fakeOverlay) overlay() {}
+
+// fakeGameScene is a fake scene that plays sounds and is played with the controls.
+type fakeGameScene struct {
+	fakeScene
+	sounds   *audio.Manager
+	controls *input.Controls
+}
+
+func (s *fakeGameScene) useSounds(sounds *audio.Manager) { s.sounds = sounds }
+
+func (s *fakeGameScene) useControls(controls *input.Controls) { s.controls = controls }
+
+// soundRecorder is an audio backend that records the sounds played instead of playing them.
+type soundRecorder struct {
+	played []string
+	music  []string
+}
+
+func (r *soundRecorder) LoadSound(string) error           { return nil }
+func (r *soundRecorder) PlaySound(path string, _ float32) { r.played = append(r.played, path) }
+func (r *soundRecorder) LoadMusic(string) error           { return nil }
+func (r *soundRecorder) PlayMusic(path string, _ float32) { r.music = append(r.music, path) }
+func (r *soundRecorder) SetMusicVolume(string, float32)   {}
+func (r *soundRecorder) UpdateMusic(string)               {}
+func (r *soundRecorder) StopMusic(string)                 {}
+func (r *soundRecorder) Close()                           {}
+
+// newSoundRecorder returns an audio manager that plays the game's sounds into a recorder.
+func newSoundRecorder(t *testing.T) (*audio.Manager, *soundRecorder) {
+	config, err := audio.GetAudioConfig()
+	require.NoError(t, err)
+	recorder := &soundRecorder{}
+	return audio.NewManager(recorder, config, "", nil), recorder
+}
+
+// soundFile returns the file the game plays for the event in the variant.
+func soundFile(t *testing.T, variant string, event audio.Event) string {
+	config, err := audio.GetAudioConfig()
+	require.NoError(t, err)
+	return config.ForVariant(variant).Sounds[event].File
+}
+
+func TestManager_Switch(t *testing.T) {
+	tests := []struct {
+		name       string
+		transition Transition
+		waits      bool
+	}{
+		{"cut", Cut, false},
+		{"fade", Fade, true},
+		{"slide", Slide, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			first, second := &fakeScene{}, &fakeScene{}
+			m := NewManager(1920, 1080, nil, nil, first)
+			require.Equal(t, 1, first.inits)
+
+			first.next = Switch(second, tt.transition)
+			m.step(start)
+			assert.Equal(t, 1, second.inits, "the new scene is initialized as soon as it's switched to")
+			assert.Equal(t, []Scene{second}, m.stack)
+
+			m.step(start.Add(transitionTime / 2))
+			if tt.waits {
+				assert.Equal(t, 0, first.closed, "the old scene is drawn until the transition is over")
+				assert.Equal(t, 0, second.updates, "nothing is updated during a transition")
+			} else {
+				assert.Equal(t, 1, first.closed)
+				assert.Equal(t, 1, second.updates)
+			}
+
+			m.step(start.Add(transitionTime))
+			assert.Equal(t, 1, first.closed)
+			assert.Nil(t, m.transition)
+			assert.Positive(t, second.updates)
+			assert.Equal(t, 1, first.updates)
+			assert.False(t, m.Done())
+		})
+	}
+}
+
+func TestManager_PushAndPop(t *testing.T) {
+	now := time.Now()
+	game, pause := &fakeScene{}, &fakeOverlay{}
+	m := NewManager(1920, 1080, nil, nil, game)
+
+	game.next = Push(pause)
+	m.step(now)
+	assert.Equal(t, 1, pause.inits)
+	assert.Equal(t, []Scene{game, pause}, m.stack)
+
+	m.step(now)
+	assert.Equal(t, 1, game.updates, "only the top scene is updated")
+	assert.Equal(t, 1, pause.updates)
+
+	pause.next = Pop("resumed")
+	m.step(now)
+	assert.Equal(t, 1, pause.closed)
+	assert.Equal(t, []Scene{game}, m.stack)
+	assert.Equal(t, []any{"resumed"}, game.resumed)
+	assert.Equal(t, 0, game.closed)
+	assert.False(t, m.Done())
+
+	game.next = Pop(nil)
+	m.step(now)
+	assert.Equal(t, 1, game.closed)
+	assert.True(t, m.Done(), "popping the last scene ends the game")
+}
+
+func TestManager_Quit(t *testing.T) {
+	game, pause := &fakeScene{}, &fakeOverlay{}
+	m := NewManager(1920, 1080, nil, nil, game)
+	game.next = Push(pause)
+	m.step(time.Now())
+	pause.next = QuitGame()
+	m.step(time.Now())
+	assert.True(t, m.Done())
+
+	m.Close()
+	assert.Equal(t, 1, game.closed)
+	assert.Equal(t, 1, pause.closed)
+	assert.Empty(t, m.stack)
+}
+
+func TestManager_CloseDuringTransition(t *testing.T) {
+	first, second := &fakeScene{}, &fakeScene{}
+	m := NewManager(1920, 1080, nil, nil, first)
+	first.next = Switch(second, Fade)
+	m.step(time.Now())
+	require.NotNil(t, m.transition)
+
+	m.Close()
+	assert.Equal(t, 1, first.closed, "the scenes being left are closed too")
+	assert.Equal(t, 1, second.closed)
+}
+
+func TestManager_Resize(t *testing.T) {
+	start := time.Now()
+	first, second, pause := &fakeScene{}, &fakeScene{}, &fakeOverlay{}
+	m := NewManager(1920, 1080, nil, nil, first)
+	first.next = Push(pause)
+	m.step(start)
+	pause.next = Switch(second, Fade)
+	m.step(start)
+	require.NotNil(t, m.transition)
+
+	m.resize(1280, 720)
+	assert.Equal(t, [2]int{1280, 720}, second.size)
+	assert.Equal(t, [2]int{1280, 720}, first.size, "scenes being left are still drawn, so they're resized too")
+	assert.Equal(t, [2]int{1280, 720}, pause.size)
+
+	third := &fakeScene{}
+	m.step(start.Add(transitionTime))
+	second.next = Push(third)
+	m.step(start.Add(transitionTime))
+	assert.Equal(t, [2]int{1280, 720}, third.size, "new scenes start at the window's size")
+}
+
+func TestManager_Fullscreen(t *testing.T) {
+	start := time.Now()
+	first, second := &fakeScene{}, &fakeScene{}
+	m := NewManager(1920, 1080, nil, pressing(t, rl.KeyF11), first)
+	toggles := 0
+	m.toggleFullscreen = func() { toggles++ }
+	m.step(start)
+	assert.Equal(t, 1, toggles)
+	assert.Equal(t, 1, first.updates, "the scene is updated as well")
+
+	first.next = Switch(second, Fade)
+	m.step(start)
+	m.step(start)
+	assert.Equal(t, 3, toggles, "fullscreen switches during a transition too")
+
+	m.controls = defaultControls(t, &fakeDevice{
+		gamepad: map[input.GamepadButton]bool{rl.GamepadButtonMiddleLeft: true},
+	})
+	m.step(start)
+	assert.Equal(t, 4, toggles, "the gamepad's back button switches as well")
+
+	m.controls = pressing(t, rl.KeyEnter)
+	m.step(start)
+	assert.Equal(t, 4, toggles)
+}
+
+func TestManager_HandsSoundsAndControlsToScenes(t *testing.T) {
+	sounds, _ := newSoundRecorder(t)
+	controls := input.NewControls(nil, input.Bindings{})
+	first, second := &fakeGameScene{}, &fakeGameScene{}
+	m := NewManager(1920, 1080, sounds, controls, first)
+	assert.Same(t, sounds, first.sounds)
+	assert.Same(t, controls, first.controls)
+
+	first.next = Push(second)
+	m.step(time.Now())
+	assert.Same(t, sounds, second.sounds, "scenes get the sounds before they're initialized")
+	assert.Same(t, controls, second.controls)
+}
+
+func TestVisibleFrom(t *testing.T) {
+	game, other := &fakeScene{}, &fakeScene{}
+	pause, dialog := &fakeOverlay{}, &fakeOverlay{}
+	tests := []struct {
+		name  string
+		stack []Scene
+		want  int
+	}{
+		{"one scene", []Scene{game}, 0},
+		{"scene over scene", []Scene{game, other}, 1},
+		{"overlay", []Scene{game, pause}, 0},
+		{"overlays on overlays", []Scene{other, game, pause, dialog}, 1},
+		{"only overlays", []Scene{pause, dialog}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, visibleFrom(tt.stack))
+		})
+	}
+}
+
+func TestFadeAlpha(t *testing.T) {
+	tests := []struct {
+		progress float32
+		alpha    float32
+		old      bool
+	}{
+		{0, 0, true},
+		{0.25, 0.5, true},
+		{0.5, 1, false},
+		{0.75, 0.5, false},
+		{1, 0, false},
+	}
+	for _, tt := range tests {
+		alpha, old := fadeAlpha(tt.progress)
+		assert.InDelta(t, tt.alpha, alpha, 1e-6, "progress %v", tt.progress)
+		assert.Equal(t, tt.old, old, "progress %v", tt.progress)
+	}
+}
+
+func TestSlideOffsets(t *testing.T) {
+	from, to := slideOffsets(0, 1920)
+	assert.Zero(t, from)
+	assert.Equal(t, float32(1920), to)
+	from, to = slideOffsets(0.5, 1920)
+	assert.Equal(t, float32(-960), from)
+	assert.Equal(t, float32(960), to)
+	from, to = slideOffsets(1, 1920)
+	assert.Equal(t, float32(-1920), from)
+	assert.Zero(t, to)
+}
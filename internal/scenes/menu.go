@@ -0,0 +1,403 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"cragspider-go/internal/ai"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/input"
+	"cragspider-go/internal/rating"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Menu is the main menu, where a new game is set up: the variant, who plays each side, the profile the human players
+// are rated under, the time control, how fast pieces move, and the seed for the AI players' random choices.
+type Menu struct {
+	width, height int
+	initial       GameSetup // The setup chosen to start with
+	controls      *input.Controls
+	variants      []core.Variant
+	players       []string // Who can play a side: a human (empty), then every AI personality
+	rows          []menuRow
+	profiles      []string // Profiles to pick from: the default, then every profile that has been rated
+	profile       string   // The profile picked or typed in
+	focus         int      // The row the controls change, or buttonsRow for the buttons
+	seed          int64    // Zero seeds the AI players from the clock
+	buttons       []button
+	button        int // The button the controls have focused, while they're on the buttons
+}
+
+// menuRow is one of the settings on the menu, and its choices.
+type menuRow struct {
+	label    string
+	choices  []string // What each choice is called on screen; the profile and seed rows have none
+	selected int
+	rect     rl.Rectangle // Where the row's value is drawn, which can be clicked to change it
+}
+
+// The menu's rows, in order.
+const (
+	variantRow = iota
+	whiteRow
+	blackRow
+	profileRow
+	timeControlRow
+	animationRow
+	seedRow
+	// buttonsRow is the row of buttons beneath the settings, which the focus moves to like any other row.
+	buttonsRow
+)
+
+const (
+	// maxSeed is the largest seed that can be typed into the menu.
+	maxSeed = 1_000_000_000
+	// maxProfileLength is the longest profile name that can be typed into the menu.
+	maxProfileLength = 16
+	// menuRowHeight is how far apart the menu's rows are.
+	menuRowHeight = 64
+	// menuFontSize is the size of the menu's settings.
+	menuFontSize = 32
+)
+
+var (
+	_ Scene      = (*Menu)(nil)
+	_ controlled = (*Menu)(nil)
+)
+
+// NewMenu returns a menu scene with the setup chosen to start with.
+func NewMenu(setup GameSetup) *Menu {
+	return &Menu{initial: setup}
+}
+
+// useControls lets the controls move between the settings and buttons, change the settings and press the buttons.
+func (m *Menu) useControls(controls *input.Controls) {
+	m.controls = controls
+}
+
+// Init initializes the menu with the given width and height, with its initial setup chosen and the start button
+// focused, so that the game can be started at once.
+func (m *Menu) Init(width, height int) {
+	setup := m.initial
+
+	variants, err := core.GetVariants()
+	if err != nil {
+		rl.TraceLog(rl.LogFatal, "error loading variants: %v", err)
+	}
+	m.variants = variants
+	variantNames := make([]string, len(variants))
+	for i, v := range variants {
+		variantNames[i] = v.String()
+	}
+
+	aiConfig, err := ai.GetAIConfig()
+	if err != nil {
+		rl.TraceLog(rl.LogFatal, "error loading AI configuration: %v", err)
+	}
+	m.players = []string{""}
+	playerNames := []string{"Human"}
+	for _, player := range aiConfig.Players {
+		// Engines need a program of their own, which may not be installed, so they can't be chosen here
+		if player.Strategy == ai.EngineStrategy {
+			continue
+		}
+		m.players = append(m.players, player.Name)
+		playerNames = append(playerNames, player.String())
+	}
+
+	timeControlNames := make([]string, len(TimeControls))
+	for i, tc := range TimeControls {
+		timeControlNames[i] = tc.String()
+	}
+
+	animationNames := make([]string, len(AnimationSpeeds))
+	for i, speed := range AnimationSpeeds {
+		animationNames[i] = speed.String()
+	}
+
+	m.rows = []menuRow{
+		variantRow:     {label: "Variant", choices: variantNames},
+		whiteRow:       {label: "White", choices: playerNames},
+		blackRow:       {label: "Black", choices: playerNames},
+		profileRow:     {label: "Profile"},
+		timeControlRow: {label: "Time control", choices: timeControlNames},
+		animationRow:   {label: "Animation", choices: animationNames},
+		seedRow:        {label: "Seed"},
+	}
+	m.rows[variantRow].selected = indexOf(len(variants), func(i int) bool {
+		return variants[i].Name == setup.Variant || (setup.Variant == "" && variants[i].Name == core.StandardVariant)
+	})
+	m.rows[whiteRow].selected = indexOf(len(m.players), func(i int) bool { return m.players[i] == setup.White })
+	m.rows[blackRow].selected = indexOf(len(m.players), func(i int) bool { return m.players[i] == setup.Black })
+	m.rows[timeControlRow].selected = indexOf(len(TimeControls), func(i int) bool {
+		return TimeControls[i] == setup.TimeControl
+	})
+	m.rows[animationRow].selected = indexOf(len(AnimationSpeeds), func(i int) bool {
+		return AnimationSpeeds[i] == setup.Animation
+	})
+	m.profiles = knownProfiles()
+	m.profile = core.NewProfilePlayer(setup.Profile).Name
+	if !slices.Contains(m.profiles, m.profile) {
+		m.profiles = append(m.profiles, m.profile)
+	}
+	m.seed = min(max(setup.Seed, 0), maxSeed)
+	m.focus, m.button = buttonsRow, 0
+	m.buttons = []button{
+		{label: "Start", action: startAction},
+		{label: "Quit", action: quitAction},
+	}
+	m.Resize(width, height)
+}
+
+// Resize lays the menu's rows and buttons out for a window of the given size.
+func (m *Menu) Resize(width, height int) {
+	m.width, m.height = width, height
+	top := float32(height) / 4
+	for i := range m.rows {
+		m.rows[i].rect = rl.Rectangle{
+			X:      float32(width) / 2,
+			Y:      top + float32(i*menuRowHeight),
+			Width:  float32(width) / 3,
+			Height: menuFontSize,
+		}
+	}
+	layoutButtons(m.buttons, width, top+float32(len(m.rows)*menuRowHeight+menuRowHeight))
+}
+
+// knownProfiles returns the profiles that can be picked on the menu: the default, then every profile that has been
+// rated. If the ratings can't be read, only the default can be picked, though others can still be typed in.
+func knownProfiles() []string {
+	profiles := []string{core.DefaultProfile}
+	path, err := rating.DefaultPath()
+	if err != nil {
+		return profiles
+	}
+	table, err := rating.Load(path)
+	if err != nil {
+		return profiles
+	}
+	for _, profile := range table.Profiles() {
+		if profile != core.DefaultProfile {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+// indexOf returns the first of n indexes that matches, or zero if none do.
+func indexOf(n int, matches func(i int) bool) int {
+	for i := range n {
+		if matches(i) {
+			return i
+		}
+	}
+	return 0
+}
+
+// Setup returns the game set up on the menu.
+func (m *Menu) Setup() GameSetup {
+	return GameSetup{
+		Variant:     m.variants[m.rows[variantRow].selected].Name,
+		White:       m.players[m.rows[whiteRow].selected],
+		Black:       m.players[m.rows[blackRow].selected],
+		Profile:     core.NewProfilePlayer(strings.TrimSpace(m.profile)).Name,
+		TimeControl: TimeControls[m.rows[timeControlRow].selected],
+		Seed:        m.seed,
+		Animation:   AnimationSpeeds[m.rows[animationRow].selected],
+	}
+}
+
+// Update handles a frame of input: a game is started or the player quits with the buttons, and the settings are
+// changed with the controls and mouse. While the profile or seed is focused, a character typed into it is taken
+// instead of any control its key is bound to.
+func (m *Menu) Update(time.Time) Change {
+	if m.typeChar(m.controls.Typed()) {
+		return Stay()
+	}
+	for _, action := range input.Actions {
+		if source, ok := m.controls.PressedWith(action); ok {
+			if change := m.perform(action, source, m.controls.Mouse()); change.op != stayOp {
+				return change
+			}
+		}
+	}
+	return Stay()
+}
+
+// perform carries out an action performed with the source. Up and down move between the rows, and left and right
+// change the focused setting or move between the buttons. Select presses the focused button or moves the focused
+// setting on to its next choice; with the mouse, it does the same to what was clicked on. Cancel takes back what
+// was typed on the profile and seed rows, and elsewhere moves the focus to the quit button.
+func (m *Menu) perform(action input.Action, source input.Source, mouse rl.Vector2) Change {
+	switch action {
+	case input.CursorUp:
+		m.focus = (m.focus + buttonsRow) % (buttonsRow + 1)
+	case input.CursorDown:
+		m.focus = (m.focus + 1) % (buttonsRow + 1)
+	case input.CursorLeft:
+		m.step(-1)
+	case input.CursorRight:
+		m.step(1)
+	case input.Select:
+		if source == input.Mouse {
+			return m.handleClick(mouse)
+		}
+		if m.focus == buttonsRow {
+			return m.choose(m.buttons[m.button].action)
+		}
+		m.change(m.focus, 1)
+	case input.Cancel:
+		if !m.erase() {
+			m.focus, m.button = buttonsRow, len(m.buttons)-1
+		}
+	}
+	return Stay()
+}
+
+// step moves the focused row's choice, or the focus along the buttons, by delta.
+func (m *Menu) step(delta int) {
+	if m.focus == buttonsRow {
+		m.button = (m.button + delta + len(m.buttons)) % len(m.buttons)
+		return
+	}
+	m.change(m.focus, delta)
+}
+
+// choose returns the change the action asks for.
+func (m *Menu) choose(action buttonAction) Change {
+	if action == startAction {
+		return Switch(NewPlayfield(m.Setup()), Fade)
+	}
+	return QuitGame()
+}
+
+// typeChar types the character into the profile or seed, whichever is focused, returning true if it went in. Only
+// digits go into the seed, and digits that would take it past the limit are ignored.
+func (m *Menu) typeChar(char rune) bool {
+	switch {
+	case m.focus == profileRow:
+		return m.typeProfile(char)
+	case m.focus == seedRow && char >= '0' && char <= '9':
+		if seed := m.seed*10 + int64(char-'0'); seed <= maxSeed {
+			m.seed = seed
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// erase takes back the last character typed into the profile or seed, whichever is focused, returning false if
+// neither is.
+func (m *Menu) erase() bool {
+	switch m.focus {
+	case profileRow:
+		if m.profile != "" {
+			m.profile = m.profile[:len(m.profile)-1]
+		}
+		return true
+	case seedRow:
+		m.seed /= 10
+		return true
+	default:
+		return false
+	}
+}
+
+// typeProfile adds the character typed to the end of the profile's name, returning true if it could be added.
+// Names are kept to printable ASCII, which is what the font can draw.
+func (m *Menu) typeProfile(char rune) bool {
+	if char < ' ' || char > '~' || len(m.profile) >= maxProfileLength {
+		return false
+	}
+	m.profile += string(char)
+	return true
+}
+
+// handleClick presses the button clicked on, or focuses the row whose value was clicked on and moves it on to its
+// next choice.
+func (m *Menu) handleClick(mouse rl.Vector2) Change {
+	for i, b := range m.buttons {
+		if b.contains(mouse) {
+			m.focus, m.button = buttonsRow, i
+			return m.choose(b.action)
+		}
+	}
+	for i, row := range m.rows {
+		if rectContains(row.rect, mouse) {
+			m.focus = i
+			m.change(i, 1)
+			break
+		}
+	}
+	return Stay()
+}
+
+// change moves the row's choice by delta, wrapping around at either end. The seed is changed by delta instead,
+// down to zero, and the profile moves through the profiles that can be picked.
+func (m *Menu) change(row, delta int) {
+	switch row {
+	case seedRow:
+		m.seed = min(max(m.seed+int64(delta), 0), maxSeed)
+		return
+	case profileRow:
+		i := slices.Index(m.profiles, m.profile)
+		if i < 0 && delta < 0 {
+			// A name being typed in comes just before the first profile
+			i = 0
+		}
+		m.profile = m.profiles[(i+delta+len(m.profiles))%len(m.profiles)]
+		return
+	}
+	r := &m.rows[row]
+	r.selected = (r.selected + delta + len(r.choices)) % len(r.choices)
+}
+
+// value returns what the row is set to, as shown on screen.
+func (m *Menu) value(row int) string {
+	if row == profileRow {
+		return m.profile
+	}
+	if row == seedRow {
+		if m.seed == 0 {
+			return "Random"
+		}
+		return strconv.FormatInt(m.seed, 10)
+	}
+	r := m.rows[row]
+	return r.choices[r.selected]
+}
+
+// Draw draws the menu: a title, each setting with the focused one lit up, and the buttons.
+func (m *Menu) Draw() {
+	const (
+		titleSize = 72
+		hintSize  = 20
+	)
+	rl.DrawRectangle(0, 0, int32(m.width), int32(m.height), rl.Black)
+	centerX := int32(m.width / 2)
+	drawCentered("New Game", centerX, int32(m.height/8), titleSize, rl.RayWhite)
+	for i, row := range m.rows {
+		tint := rl.LightGray
+		if i == m.focus {
+			tint = rl.Gold
+		}
+		labelX := int32(row.rect.X) - rl.MeasureText(row.label, menuFontSize) - 48
+		rl.DrawText(row.label, labelX, int32(row.rect.Y), menuFontSize, tint)
+		rl.DrawText("< "+m.value(i)+" >", int32(row.rect.X), int32(row.rect.Y), menuFontSize, tint)
+	}
+	hint := "Up and down choose a setting, left and right change it; type a profile name, or a seed (zero for random)"
+	drawCentered(hint, centerX, int32(m.buttons[0].rect.Y)-2*hintSize, hintSize, rl.Gray)
+
+	mouse := m.controls.Mouse()
+	for i, b := range m.buttons {
+		renderButton(b, b.contains(mouse), m.focus == buttonsRow && i == m.button, false)
+	}
+}
+
+// Close cleans up resources. The menu holds none of its own.
+func (m *Menu) Close() {}
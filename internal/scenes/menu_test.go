@@ -0,0 +1,227 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	"cragspider-go/internal/ai"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/input"
+	"cragspider-go/internal/rating"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMenu_Init(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup GameSetup
+		want  GameSetup
+	}{
+		{"default", DefaultSetup(), DefaultSetup()},
+		{
+			"everything chosen",
+			GameSetup{
+				Variant: "vanguard", White: "gambler", Profile: "Dejah", TimeControl: TimeControls[2], Seed: 42,
+				Animation: FastAnimation,
+			},
+			GameSetup{
+				Variant: "vanguard", White: "gambler", Profile: "Dejah", TimeControl: TimeControls[2], Seed: 42,
+				Animation: FastAnimation,
+			},
+		},
+		{
+			"unknown choices fall back to the first",
+			GameSetup{Variant: "", White: "nobody", Black: "grabber", TimeControl: TimeControl{Base: time.Hour},
+				Animation: 7},
+			GameSetup{Variant: core.StandardVariant, White: "", Black: "grabber", Profile: core.DefaultProfile},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			menu := NewMenu(tt.setup)
+			menu.Init(1920, 1080)
+			assert.Equal(t, tt.want, menu.Setup())
+		})
+	}
+}
+
+func TestMenu_LeavesOutEngines(t *testing.T) {
+	menu := NewMenu(DefaultSetup())
+	menu.Init(1920, 1080)
+	aiConfig, err := ai.GetAIConfig()
+	require.NoError(t, err)
+
+	var want []string
+	for _, player := range aiConfig.Players {
+		if player.Strategy != ai.EngineStrategy {
+			want = append(want, player.Name)
+		}
+	}
+	assert.Equal(t, append([]string{""}, want...), menu.players)
+	assert.Len(t, menu.rows[whiteRow].choices, len(menu.players))
+}
+
+// press has the menu handle a frame in which only the keys were pressed.
+func press(t *testing.T, menu *Menu, keys ...input.Key) Change {
+	menu.useControls(pressing(t, keys...))
+	return menu.Update(time.Now())
+}
+
+// typeText has the menu handle the characters typed, a frame each, with the key each is typed with pressed as well.
+func typeText(t *testing.T, menu *Menu, text string) {
+	for _, char := range text {
+		// Letters, digits and space have the same key codes as their upper case characters
+		device := &fakeDevice{keys: map[input.Key]bool{input.Key(unicode.ToUpper(char)): true}, typed: []rune{char}}
+		menu.useControls(defaultControls(t, device))
+		menu.Update(time.Now())
+	}
+}
+
+func TestMenu_Controls(t *testing.T) {
+	menu := NewMenu(DefaultSetup())
+	menu.Init(1920, 1080)
+	require.Equal(t, buttonsRow, menu.focus, "the start button is focused first")
+
+	// Down from the buttons wraps round to the variant, which cycles both ways around its choices
+	press(t, menu, rl.KeyDown)
+	require.Equal(t, variantRow, menu.focus)
+	press(t, menu, rl.KeyLeft)
+	assert.Equal(t, menu.variants[len(menu.variants)-1].Name, menu.Setup().Variant)
+	press(t, menu, rl.KeyD)
+	press(t, menu, rl.KeyRight)
+	assert.Equal(t, menu.variants[1].Name, menu.Setup().Variant)
+	press(t, menu, rl.KeyEnter)
+	assert.Equal(t, menu.variants[2].Name, menu.Setup().Variant, "select moves a setting on to its next choice")
+
+	// Up from the top wraps round to the buttons, then the seed, where digits are typed
+	press(t, menu, rl.KeyUp)
+	press(t, menu, rl.KeyW)
+	require.Equal(t, seedRow, menu.focus)
+	assert.Equal(t, "Random", menu.value(seedRow))
+	typeText(t, menu, "123")
+	assert.Equal(t, int64(123), menu.Setup().Seed)
+	press(t, menu, rl.KeyBackspace)
+	press(t, menu, rl.KeyRight)
+	assert.Equal(t, "13", menu.value(seedRow))
+
+	// Digits only go into the seed
+	press(t, menu, rl.KeyDown)
+	press(t, menu, rl.KeyDown)
+	require.Equal(t, variantRow, menu.focus)
+	press(t, menu, rl.KeyDown)
+	typeText(t, menu, "5")
+	assert.Equal(t, int64(13), menu.Setup().Seed)
+	press(t, menu, rl.KeyRight)
+	assert.Equal(t, menu.players[1], menu.Setup().White, "the first AI personality follows the human")
+
+	// Cancel goes to the quit button, and select presses it
+	assert.Equal(t, Stay(), press(t, menu, rl.KeyBackspace))
+	assert.Equal(t, buttonsRow, menu.focus)
+	assert.Equal(t, quitOp, press(t, menu, rl.KeyEnter).op)
+}
+
+func TestMenu_GamepadStarts(t *testing.T) {
+	menu := NewMenu(DefaultSetup())
+	menu.Init(1920, 1080)
+	menu.useControls(defaultControls(t, &fakeDevice{
+		gamepad: map[input.GamepadButton]bool{rl.GamepadButtonRightFaceDown: true},
+	}))
+	change := menu.Update(time.Now())
+	assert.Equal(t, switchOp, change.op)
+	assert.IsType(t, &Playfield{}, change.scene)
+}
+
+func TestMenu_SeedLimits(t *testing.T) {
+	menu := NewMenu(GameSetup{Seed: maxSeed})
+	menu.Init(1920, 1080)
+	menu.focus = seedRow
+	typeText(t, menu, "9")
+	assert.Equal(t, int64(maxSeed), menu.seed, "digits that would pass the limit are ignored")
+	press(t, menu, rl.KeyRight)
+	assert.Equal(t, int64(maxSeed), menu.seed)
+
+	menu.seed = 0
+	press(t, menu, rl.KeyLeft)
+	assert.Equal(t, int64(0), menu.seed, "the seed doesn't go below zero")
+}
+
+func TestMenu_Profile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	path, err := rating.DefaultPath()
+	require.NoError(t, err)
+	table := rating.NewTable()
+	_, err = table.Record(rating.HumanKey("Tars"), rating.HumanKey("Dejah"), core.Draw)
+	require.NoError(t, err)
+	require.NoError(t, table.Save(path))
+
+	menu := NewMenu(DefaultSetup())
+	menu.Init(1920, 1080)
+	require.Equal(t, []string{core.DefaultProfile, "Dejah", "Tars"}, menu.profiles, "rated profiles can be picked")
+	menu.focus = profileRow
+
+	// Left and right pick the rated profiles
+	press(t, menu, rl.KeyRight)
+	assert.Equal(t, "Dejah", menu.Setup().Profile)
+	press(t, menu, rl.KeyLeft)
+	press(t, menu, rl.KeyLeft)
+	assert.Equal(t, "Tars", menu.Setup().Profile)
+
+	// Cancel and typing enter a new one, even with letters bound to the cursor
+	for range 5 {
+		press(t, menu, rl.KeyBackspace)
+	}
+	assert.Equal(t, profileRow, menu.focus, "cancel takes back what was typed rather than leaving the profile")
+	assert.Equal(t, core.DefaultProfile, menu.Setup().Profile, "an empty name plays under the default profile")
+	typeText(t, menu, "Sola ")
+	assert.Equal(t, profileRow, menu.focus)
+	assert.False(t, menu.typeProfile('\n'), "only printable characters go into names")
+	assert.Equal(t, "Sola", menu.Setup().Profile)
+	press(t, menu, rl.KeyRight)
+	assert.Equal(t, core.DefaultProfile, menu.Setup().Profile, "a typed name comes before the first profile")
+
+	menu.profile = strings.Repeat("x", maxProfileLength)
+	assert.False(t, menu.typeProfile('y'), "names are kept short")
+}
+
+func TestMenu_HandleClick(t *testing.T) {
+	menu := NewMenu(DefaultSetup())
+	menu.Init(1920, 1080)
+	row := menu.rows[timeControlRow].rect
+
+	assert.Equal(t, Stay(), menu.handleClick(rl.Vector2{X: row.X + 1, Y: row.Y + 1}))
+	assert.Equal(t, timeControlRow, menu.focus)
+	assert.Equal(t, TimeControls[1], menu.Setup().TimeControl)
+
+	assert.Equal(t, Stay(), menu.handleClick(rl.Vector2{X: 1, Y: 1}))
+	assert.Equal(t, timeControlRow, menu.focus, "clicking elsewhere changes nothing")
+	assert.Equal(t, TimeControls[1], menu.Setup().TimeControl)
+
+	menu.useControls(defaultControls(t, &fakeDevice{
+		mouse:    map[input.MouseButton]bool{input.MouseButton(rl.MouseButtonLeft): true},
+		position: buttonCenter(menu.buttons[1]),
+	}))
+	assert.Equal(t, quitOp, menu.Update(time.Now()).op, "clicking a button presses it")
+}
+
+func TestMenu_Choose(t *testing.T) {
+	setup := GameSetup{Variant: "phalanx", White: "gambler", Profile: "Dejah", Seed: 7}
+	menu := NewMenu(setup)
+	menu.Init(1920, 1080)
+
+	change := menu.choose(startAction)
+	assert.Equal(t, switchOp, change.op)
+	assert.Equal(t, Fade, change.transition)
+	game, ok := change.scene.(*Playfield)
+	require.True(t, ok, "starting should switch to the playfield")
+	assert.Equal(t, setup, game.setup)
+
+	assert.Equal(t, quitOp, menu.choose(quitAction).op)
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"cragspider-go/internal/audio"
+	"cragspider-go/internal/input"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Pause is an overlay that stops the game beneath it until it's resumed, or left for the menu or quit.
+type Pause struct {
+	width, height int
+	setup         GameSetup // The paused game's setup, for the menu to start from
+	sounds        *audio.Manager
+	controls      *input.Controls
+	buttons       []button
+	focus         int // The button the controls have focused
+}
+
+var (
+	_ Overlay    = (*Pause)(nil)
+	_ audible    = (*Pause)(nil)
+	_ controlled = (*Pause)(nil)
+)
+
+// NewPause returns a pause overlay for a game with the given setup.
+func NewPause(setup GameSetup) *Pause {
+	return &Pause{setup: setup}
+}
+
+// useSounds lets the pause overlay mute and unmute the game's sounds.
+func (o *Pause) useSounds(sounds *audio.Manager) {
+	o.sounds = sounds
+}
+
+// useControls lets the controls choose between the buttons, and the menu control resume the game, as it paused it.
+func (o *Pause) useControls(controls *input.Controls) {
+	o.controls = controls
+}
+
+// Init initializes the pause overlay with the given width and height.
+func (o *Pause) Init(width, height int) {
+	o.buttons = []button{
+		{label: "Resume", action: resumeAction},
+		{label: o.muteLabel(), action: muteAction},
+		{label: "Menu", action: menuAction},
+		{label: "Quit", action: quitAction},
+	}
+	o.focus = 0
+	o.Resize(width, height)
+}
+
+// Resize lays the buttons out for a window of the given size.
+func (o *Pause) Resize(width, height int) {
+	o.width, o.height = width, height
+	layoutButtons(o.buttons, width, float32(height)/2)
+}
+
+// Update waits for one of the buttons to be pressed. The menu control and Cancel both resume the game.
+func (o *Pause) Update(time.Time) Change {
+	if o.controls.Pressed(input.Menu) {
+		return Pop(nil)
+	}
+	b, ok := pressedButton(o.controls, o.buttons, &o.focus, resumeAction)
+	if !ok {
+		return Stay()
+	}
+	return o.choose(b.action)
+}
+
+// choose returns the change the action asks for. Muting stays on the pause overlay, and leaving the game stops its
+// music.
+func (o *Pause) choose(action buttonAction) Change {
+	switch action {
+	case resumeAction:
+		return Pop(nil)
+	case muteAction:
+		o.sounds.SetMuted(!o.sounds.Muted())
+		for i := range o.buttons {
+			if o.buttons[i].action == muteAction {
+				o.buttons[i].label = o.muteLabel()
+			}
+		}
+		return Stay()
+	case menuAction:
+		o.sounds.StopMusic()
+		return Switch(NewMenu(o.setup), Slide)
+	default:
+		o.sounds.StopMusic()
+		return QuitGame()
+	}
+}
+
+// muteLabel returns the label of the button that mutes or unmutes the sound.
+func (o *Pause) muteLabel() string {
+	if o.sounds.Muted() {
+		return "Unmute"
+	}
+	return "Mute"
+}
+
+// Draw dims the game beneath and draws the buttons over it.
+func (o *Pause) Draw() {
+	const titleSize = 72
+	rl.DrawRectangle(0, 0, int32(o.width), int32(o.height), rl.Fade(rl.Black, 0.6))
+	drawCentered("Paused", int32(o.width/2), int32(o.height/3), titleSize, rl.RayWhite)
+	mouse := o.controls.Mouse()
+	for i, b := range o.buttons {
+		renderButton(b, b.contains(mouse), i == o.focus, false)
+	}
+}
+
+// Close cleans up resources. The pause overlay holds none of its own.
+func (o *Pause) Close() {}
+
+func (o *Pause) overlay() {}
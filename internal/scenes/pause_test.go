@@ -0,0 +1,83 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"testing"
+	"time"
+
+	"cragspider-go/internal/input"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPause_Choose(t *testing.T) {
+	setup := GameSetup{Variant: "skirmish", Black: "doofus"}
+	pause := NewPause(setup)
+	pause.Init(1920, 1080)
+	require.Len(t, pause.buttons, 4)
+
+	assert.Equal(t, Pop(nil), pause.choose(resumeAction))
+	assert.Equal(t, quitOp, pause.choose(quitAction).op)
+
+	change := pause.choose(menuAction)
+	assert.Equal(t, switchOp, change.op)
+	assert.Equal(t, Slide, change.transition)
+	menu, ok := change.scene.(*Menu)
+	require.True(t, ok, "the menu button should switch to the menu")
+	assert.Equal(t, setup, menu.initial, "the menu should start from the paused game's setup")
+}
+
+func TestPause_Mute(t *testing.T) {
+	sounds, _ := newSoundRecorder(t)
+	pause := NewPause(DefaultSetup())
+	pause.useSounds(sounds)
+	pause.Init(1920, 1080)
+	require.Equal(t, muteAction, pause.buttons[1].action)
+	assert.Equal(t, "Mute", pause.buttons[1].label)
+
+	assert.Equal(t, Stay(), pause.choose(muteAction))
+	assert.True(t, sounds.Muted())
+	assert.Equal(t, "Unmute", pause.buttons[1].label)
+
+	pause.choose(muteAction)
+	assert.False(t, sounds.Muted())
+	assert.Equal(t, "Mute", pause.buttons[1].label)
+}
+
+func TestPause_MenuControlResumes(t *testing.T) {
+	pause := NewPause(DefaultSetup())
+	pause.useControls(defaultControls(t, &fakeDevice{keys: map[input.Key]bool{rl.KeyP: true}}))
+	pause.Init(1920, 1080)
+	assert.Equal(t, Pop(nil), pause.Update(time.Now()))
+
+	pause.useControls(defaultControls(t, &fakeDevice{
+		gamepad: map[input.GamepadButton]bool{rl.GamepadButtonMiddleRight: true},
+	}))
+	assert.Equal(t, Pop(nil), pause.Update(time.Now()), "the gamepad's start button resumes as well")
+}
+
+func TestPause_Controls(t *testing.T) {
+	sounds, _ := newSoundRecorder(t)
+	pause := NewPause(DefaultSetup())
+	pause.useSounds(sounds)
+	pause.Init(1920, 1080)
+
+	pause.useControls(pressing(t, rl.KeyD))
+	assert.Equal(t, Stay(), pause.Update(time.Now()))
+	require.Equal(t, 1, pause.focus)
+	pause.useControls(defaultControls(t, &fakeDevice{
+		gamepad: map[input.GamepadButton]bool{rl.GamepadButtonRightFaceDown: true},
+	}))
+	assert.Equal(t, Stay(), pause.Update(time.Now()))
+	assert.True(t, sounds.Muted(), "selecting the focused mute button mutes")
+
+	pause.focus = 0
+	pause.useControls(pressing(t, rl.KeyLeft, rl.KeyEnter))
+	assert.Equal(t, quitOp, pause.Update(time.Now()).op, "left from the first button wraps round to quitting")
+
+	pause.useControls(pressing(t, rl.KeyBackspace))
+	assert.Equal(t, Pop(nil), pause.Update(time.Now()), "cancel resumes")
+}
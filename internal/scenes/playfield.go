@@ -3,10 +3,16 @@
 package scenes
 
 import (
+	"context"
 	"cragspider-go/internal/ai"
+	"cragspider-go/internal/audio"
 	"cragspider-go/internal/core"
+	"cragspider-go/internal/input"
+	"cragspider-go/internal/rating"
 	"cragspider-go/pkg/graphics"
 	"fmt"
+	"io"
+	"maps"
 	"time"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
@@ -19,192 +25,535 @@ type SelectedPieceAndPosition struct {
 }
 
 type Playfield struct {
+	width, height     int
+	setup             GameSetup
 	game              *core.Game
-	boardLoc          rl.Vector2
+	layout            boardLayout // Where the board is drawn, fitted to the window
 	selectedPiece     *SelectedPieceAndPosition
 	backgroundSprites *graphics.SpriteSheet
 	whiteSprites      *graphics.SpriteSheet
 	blackSprites      *graphics.SpriteSheet
-	moveExecutionChan chan struct {
-		piece    *core.Piece
-		position core.Position
-		move     core.Move
-	}
-	planningMove bool
+	aiContext         context.Context
+	cancelAI          context.CancelFunc
+	aiTurn            *aiTurn        // The AI player's move being planned or shown, if it's an AI's turn
+	abandoned         *aiTurn        // A cancelled turn whose worker hasn't finished with the strategy yet
+	aiDelay           time.Duration  // How long an AI's piece is shown selected before it moves
+	rated             bool           // Whether the finished game has been recorded in the ratings
+	ratingChange      *rating.Change // How the finished game moved each side's rating, if it was recorded
+	analyst           ai.Analyzer    // Searches the position for hints and the evaluation bar
+	analysisChan      chan analyzed
+	analyzing         bool
+	analysis          *analyzed                        // The latest hint, shown until a move is made
+	timeUsed          map[core.Color]time.Duration     // Time each side has spent on its turns
+	turnClocks        []map[core.Color]time.Duration   // The time used as each move was played, for undoing
+	lastTick          time.Time                        // When the time used was last charged
+	endedAt           time.Time                        // When the game was first seen to be over
+	animation         *moveAnimation                   // The last move, while it's still being animated
+	now               time.Time                        // The time of the frame being run
+	pausedAt          time.Time                        // When the game was paused, until it's picked up again
+	pieceAnimations   map[*core.Piece]*graphics.Player // Each piece's sprite animation, once it has been drawn
+	sounds            *audio.Manager                   // Plays the game's sounds; nil keeps it silent
+	controls          *input.Controls                  // What the player plays with; nil takes no input
+	cursor            core.Position                    // The square the board cursor is on
+	cursorShown       bool                             // Whether the cursor is drawn, when it's used, not the mouse
+}
+
+// analyzed is an analysis of a board from the game.
+type analyzed struct {
+	*ai.Analysis
+	board *core.Board
+}
+
+// aiTurn is an AI player's move, planned by a worker goroutine from a snapshot of the board. Boards are immutable,
+// so the worker can search its snapshot while the game goes on drawing the real one; all it hands back is its plan,
+// over the result channel. Everything else about the turn belongs to the gameplay loop.
+type aiTurn struct {
+	board  *core.Board // The board the move is planned for
+	cancel context.CancelFunc
+	result chan aiPlan   // Receives the worker's plan, once
+	done   chan struct{} // Closed when the worker has finished with the player's strategy
+	plan   *aiPlan       // The plan once it has arrived
+	playAt time.Time     // When the planned move is played, after its piece has been shown selected
 }
 
-var _ Scene = (*Playfield)(nil)
+// aiPlan is the move an AI player's worker planned, or why it couldn't.
+type aiPlan struct {
+	action *core.Action
+	err    error
+}
+
+const (
+	// defaultOpponent is the AI personality that plays Black.
+	defaultOpponent = "doofus"
+	// aiMoveDelay is how long an AI player's piece is shown selected before it moves, so its move can be followed.
+	aiMoveDelay = time.Second
+	// hintPersonality is the AI personality that analyzes the position when a hint is asked for.
+	hintPersonality = "strategist"
+	// hintBudget is how long a hint takes to think about.
+	hintBudget = 1500 * time.Millisecond
+	// hintLines is how many candidate moves a hint looks at, all of which are logged.
+	hintLines = 3
+	// finalPositionTime is how long the final position is shown before the game over scene.
+	finalPositionTime = 2 * time.Second
+	// clockMovesToGo is how many more moves an AI player under a time control expects to make, for sharing out its
+	// remaining time.
+	clockMovesToGo = 30
+)
+
+var (
+	_ Scene      = (*Playfield)(nil)
+	_ Resumer    = (*Playfield)(nil)
+	_ audible    = (*Playfield)(nil)
+	_ controlled = (*Playfield)(nil)
+)
 
-// Init initializes the playfield scene with the given width and height.
-// It uses the default game configuration loaded from the embedded YAML file.
+// NewPlayfield returns a playfield scene for the game the setup describes.
+func NewPlayfield(setup GameSetup) *Playfield {
+	return &Playfield{setup: setup}
+}
+
+// useSounds has the game play its sounds through the manager.
+func (p *Playfield) useSounds(sounds *audio.Manager) {
+	p.sounds = sounds
+}
+
+// Init initializes the playfield scene with the given width and height, for the game its setup describes, and starts
+// the variant's music.
 func (p *Playfield) Init(width, height int) {
-	config, err := core.GetConfig()
+	setup := p.setup
+	cfg, err := setup.config()
 	if err != nil {
-		rl.TraceLog(rl.LogFatal, "error loading default configuration: %v", err)
+		rl.TraceLog(rl.LogFatal, "error loading game configuration: %v", err)
+	}
+	whitePlayer, err := setup.player(core.White)
+	if err != nil {
+		rl.TraceLog(rl.LogFatal, "error creating White player: %v", err)
+	}
+	blackPlayer, err := setup.player(core.Black)
+	if err != nil {
+		rl.TraceLog(rl.LogFatal, "error creating Black player: %v", err)
 	}
-	p.InitWithConfig(width, height, config)
-}
-
-// InitWithConfig initializes the playfield scene with the given width, height, and configuration.
-// If config is nil, the default configuration is loaded from the embedded YAML file.
-func (p *Playfield) InitWithConfig(width, height int, cfg *core.GameConfig) {
-	whitePlayer := core.NewHumanPlayer()
-	blackPlayer := core.NewAIPlayer("Random AI", ai.NewRandomBot(core.Black))
 
 	g, err := core.NewGameWithConfigAndPlayers(cfg, whitePlayer, blackPlayer)
 	if err != nil {
 		rl.TraceLog(rl.LogFatal, "error creating game: %v", err)
 	}
-	p.game = g
+	p.initGame(width, height, g)
+
+	// Hints come from a personality of their own, so they don't disturb the opponent's search
+	analyst, err := ai.NewStrategy(hintPersonality, core.White)
+	if err != nil {
+		rl.TraceLog(rl.LogWarning, "hints unavailable: %v", err)
+	} else {
+		p.analyst = analyst
+	}
+	p.analysisChan = make(chan analyzed, 1)
 
-	// Calculate board dimensions
-	boardWidth := p.game.Board.Columns * core.SquareSize
-	boardHeight := p.game.Board.Rows * core.SquareSize
+	p.sounds.UseVariant(setup.Variant)
+	p.sounds.PlayMusic()
+}
 
-	// Center the board in the window by calculating the upper-left corner position
-	boardX := float32(width-boardWidth) / 2
-	boardY := float32(height-boardHeight) / 2
-	p.boardLoc = rl.Vector2{X: boardX, Y: boardY}
+// initGame sets the playfield up to show the game, with the board centered in a window of the given size.
+func (p *Playfield) initGame(width, height int, g *core.Game) {
+	p.game = g
+	p.cursor = core.Position{g.Board.Rows / 2, g.Board.Columns / 2}
+	p.Resize(width, height)
 
 	// Initialize sprite sheets for rendering
 	p.backgroundSprites = graphics.Load("dungeon_tiles.png", 4, 9)
 	p.whiteSprites = graphics.Load("adventurer_pieces.png", 6, 18)
 	p.blackSprites = graphics.Load("monster_pieces.png", 11, 18)
 
-	// Initialize the channel for AI move execution
-	p.moveExecutionChan = make(chan struct {
-		piece    *core.Piece
-		position core.Position
-		move     core.Move
-	}, 1)
-}
-
-// Loop is the basic gameplay loop. Returns a scene code to indicate the next scene.
-func (p *Playfield) Loop() SceneCode {
-	for !rl.WindowShouldClose() && !p.game.Over() {
-		p.handleInput()
-		p.update()
-		p.render()
-	}
-	return Quit
-}
-
-// handleInput processes keyboard and mouse input.
-func (p *Playfield) handleInput() {
-
-	// User click is used to select a piece, unselect a piece, or move a piece depending
-	// on the current state of the board.
-	if rl.IsMouseButtonPressed(rl.MouseButtonLeft) {
-		pieceUnderClick := p.PieceUnderMouse(rl.GetMousePosition())
-		if p.selectedPiece == nil {
-			p.SelectPiece(pieceUnderClick)
-		} else {
-			// User is trying to move selected piece to a new location
-			dest, err := p.PositionUnderMouse(rl.GetMousePosition())
-			if err != nil {
-				// User clicked outside the board, so deselect.
-				p.SelectPiece(pieceUnderClick)
-			} else {
-				// User is trying to move into a new square.
-				move := core.Move{
-					dest[0] - p.selectedPiece.Position[0],
-					dest[1] - p.selectedPiece.Position[1],
-				}
-				err = p.movePiece(p.selectedPiece, move)
-				if err != nil {
-					rl.TraceLog(rl.LogWarning, "failed to move piece %s: %s", p.selectedPiece.Piece, err)
-				}
-				p.SelectPiece(nil)
-			}
+	p.aiDelay = aiMoveDelay
+
+	// AI planning and hints are abandoned when the scene closes
+	p.aiContext, p.cancelAI = context.WithCancel(context.Background())
+}
+
+// Resize fits the board to a window of the given size. A move being animated is shown finished, since its path
+// was worked out for the old size.
+func (p *Playfield) Resize(width, height int) {
+	p.width, p.height = width, height
+	p.layout = newBoardLayout(width, height, p.game.Board.Rows, p.game.Board.Columns)
+	p.animation = nil
+}
+
+// Update runs a frame of the game. Once the game has ended and its final position has been shown for a moment, it
+// fades to the game over scene. The menu control pauses the game.
+func (p *Playfield) Update(now time.Time) Change {
+	p.holdPause(now)
+	p.advance(now)
+	if p.game.Over() && !p.rated {
+		p.rated = true
+		p.recordRating()
+		p.sounds.StopMusic()
+		p.sounds.Play(audio.GameOver)
+	}
+	if p.finished(now) {
+		return Switch(NewGameOver(p.Summary()), Fade)
+	}
+	if p.controls.Pressed(input.Menu) && !p.game.Over() {
+		p.pausedAt = now
+		return Push(NewPause(p.setup))
+	}
+	p.handleInput()
+	p.tick(now)
+	p.update()
+	return Stay()
+}
+
+// Resume picks the game up again after a pause. The time it was paused for isn't charged to either side.
+func (p *Playfield) Resume(any) {
+	p.lastTick = time.Time{}
+}
+
+// holdPause puts off the AI's move being shown, on the first frame after a pause, by as long as the game was paused,
+// so that it's still shown for as long as it would have been.
+func (p *Playfield) holdPause(now time.Time) {
+	if p.pausedAt.IsZero() {
+		return
+	}
+	if p.aiTurn != nil && p.aiTurn.plan != nil {
+		p.aiTurn.playAt = p.aiTurn.playAt.Add(now.Sub(p.pausedAt))
+	}
+	p.pausedAt = time.Time{}
+}
+
+// finished returns true once the game has been over for long enough that the game over scene should take over. The
+// final position is only shown once the last move has finished moving.
+func (p *Playfield) finished(now time.Time) bool {
+	if !p.game.Over() || p.animating() {
+		return false
+	}
+	if p.endedAt.IsZero() {
+		p.endedAt = now
+	}
+	return now.Sub(p.endedAt) >= finalPositionTime
+}
+
+// tick charges the time since the last frame to the side on the move, while the game is going. Under a time
+// control, a side that runs out of time loses.
+func (p *Playfield) tick(now time.Time) {
+	if !p.lastTick.IsZero() && !p.game.Over() {
+		if p.timeUsed == nil {
+			p.timeUsed = make(map[core.Color]time.Duration)
 		}
+		color := p.game.ActiveColor
+		p.timeUsed[color] += now.Sub(p.lastTick)
+		if !p.setup.TimeControl.Untimed() && p.remaining(color) <= 0 {
+			p.cancelAITurn()
+			p.game.Forfeit(color, fmt.Sprintf("%s ran out of time", color))
+		}
+	}
+	p.lastTick = now
+}
+
+// remaining returns how much time the side has left under the time control.
+func (p *Playfield) remaining(color core.Color) time.Duration {
+	moves := 0
+	for _, move := range p.game.Moves {
+		if move.Color == color {
+			moves++
+		}
+	}
+	return p.setup.TimeControl.Remaining(p.timeUsed[color], moves)
+}
+
+// aiBudget returns how long the AI player on the move may think. Untimed, they think as long as their personality
+// likes; under a time control they share their remaining time out over the moves to come, never thinking longer than
+// their personality would.
+func (p *Playfield) aiBudget(player *core.Player) time.Duration {
+	tc := p.setup.TimeControl
+	if tc.Untimed() {
+		return 0
+	}
+	budget := p.remaining(p.game.ActiveColor)/clockMovesToGo + tc.Increment/2
+	if personality, ok := player.Strategy.(*ai.Personality); ok {
+		if limit := personality.Config.ThinkTime.Max; limit > 0 && limit < budget {
+			return 0
+		}
+	}
+	return max(budget, time.Millisecond)
+}
+
+// Summary returns how the game went, for the game over scene.
+func (p *Playfield) Summary() *GameSummary {
+	return &GameSummary{
+		Setup: p.setup,
+		Game:  p.game,
+		TimeUsed: map[core.Color]time.Duration{
+			core.White: p.timeUsed[core.White],
+			core.Black: p.timeUsed[core.Black],
+		},
+		RatingChange: p.ratingChange,
+		Ended:        p.endedAt,
+	}
+}
+
+// recordRating records the finished game in the ratings file, keeping how each side's rating moved for the status
+// display. The game is still over if the ratings can't be saved; it just isn't rated.
+func (p *Playfield) recordRating() {
+	path, err := rating.DefaultPath()
+	if err != nil {
+		rl.TraceLog(rl.LogWarning, "game not rated: %v", err)
+		return
+	}
+	change, err := rateGame(path, p.game)
+	if err != nil {
+		rl.TraceLog(rl.LogWarning, "game not rated: %v", err)
+		return
+	}
+	p.ratingChange = &change
+}
+
+// rateGame records the finished game's result in the ratings file at path and returns how it moved each side's
+// rating.
+func rateGame(path string, game *core.Game) (rating.Change, error) {
+	table, err := rating.Load(path)
+	if err != nil {
+		return rating.Change{}, err
+	}
+	change, err := table.Record(ratingKey(game.GetPlayer(core.White)), ratingKey(game.GetPlayer(core.Black)),
+		game.Outcome().Result)
+	if err != nil {
+		return rating.Change{}, err
 	}
+	return change, table.Save(path)
+}
+
+// ratingKey returns the key a player is rated under: AI personalities by their configured name and humans by
+// their profile name.
+func ratingKey(player *core.Player) string {
+	if personality, ok := player.Strategy.(*ai.Personality); ok {
+		return rating.AIKey(personality.Config.Name)
+	}
+	return rating.HumanKey(player.String())
 }
 
 // movePiece takes the selected piece and tries to make the specified move. This fails if the location isn't
 // a valid one. If the move succeeds, the turn is advanced to the next player.
 func (p *Playfield) movePiece(spp *SelectedPieceAndPosition, move core.Move) error {
-	newBoard, err := p.game.Board.MovePiece(spp.Piece, spp.Position, move)
-	if err == nil {
-		p.game.Board = newBoard
-		p.game.AdvanceTurn()
+	return p.play(&core.Action{Piece: spp.Piece, Move: move})
+}
+
+// requestHint starts analyzing the position for the human player on the move, unless an analysis is already
+// underway. The result arrives on the analysis channel.
+func (p *Playfield) requestHint() {
+	if p.analyst == nil || p.analyzing || p.game.GetPlayer(p.game.ActiveColor).IsAI() {
+		return
+	}
+	p.analyzing = true
+	board, toMove := p.game.Board, p.game.ActiveColor
+	go func() {
+		analysis, err := p.analyst.Analyze(p.aiContext, board, toMove, hintBudget, hintLines)
+		if err != nil {
+			if p.aiContext.Err() == nil {
+				rl.TraceLog(rl.LogWarning, "hint failed: %v", err)
+			}
+			analysis = nil
+		}
+		p.analysisChan <- analyzed{Analysis: analysis, board: board}
+	}()
+}
+
+// receiveAnalysis takes a finished analysis off the channel, if there is one, and keeps it to show if the game
+// hasn't moved on since it was asked for. It returns the analysis if it was kept.
+func (p *Playfield) receiveAnalysis() *ai.Analysis {
+	select {
+	case result := <-p.analysisChan:
+		p.analyzing = false
+		if result.Analysis == nil || result.board != p.game.Board {
+			return nil
+		}
+		p.analysis = &result
+		return result.Analysis
+	default:
+		return nil
+	}
+}
+
+// currentAnalysis returns the latest analysis if it's of the position on the board, or nil.
+func (p *Playfield) currentAnalysis() *ai.Analysis {
+	if p.analysis == nil || p.analysis.board != p.game.Board {
+		return nil
 	}
-	return err
+	return p.analysis.Analysis
 }
 
 // update updates the game state since the last time through the gameplay loop.
-// If the current player is AI controlled, executes their move automatically.
+// If the current player is AI controlled, plans and then plays their move.
 func (p *Playfield) update() {
+	if analysis := p.receiveAnalysis(); analysis != nil {
+		rl.TraceLog(rl.LogInfo, "hint: %s", analysis)
+	}
+	if p.game.Over() {
+		return
+	}
 	currentPlayer := p.game.GetPlayer(p.game.ActiveColor)
 	if !currentPlayer.IsAI() {
 		return
 	}
 
-	// First, check if there's a pending move that should be executed
-	select {
-	case execution := <-p.moveExecutionChan:
-		// Execute the pending move
-		err := p.movePiece(&SelectedPieceAndPosition{Piece: execution.piece, Position: execution.position}, execution.move)
-		if err != nil {
-			rl.TraceLog(rl.LogError, "AI move could not execute: %s", err)
+	// A cancelled worker may still be using the strategy, so the next plan waits for it to finish
+	if p.abandoned != nil {
+		select {
+		case <-p.abandoned.done:
+			p.abandoned = nil
+		default:
 			return
 		}
-		p.SelectPiece(nil)
-		p.planningMove = false
+	}
+	if p.aiTurn == nil {
+		p.startAITurn(currentPlayer)
+		return
+	}
+	if p.animating() {
+		// The AI thinks while the last move is animated, but shows its own move once it has landed
 		return
-	default:
-		// No pending move; continue with planning the next AI move
 	}
+	p.continueAITurn()
+}
 
-	// If we're not already planning a move, we should kick that off now
-	if !p.planningMove {
-		p.planningMove = true
-		go p.planAIMove(currentPlayer)
+// startAITurn sets a worker planning the player's move from the board as it stands.
+func (p *Playfield) startAITurn(player *core.Player) {
+	ctx, cancel := context.WithCancel(p.aiContext)
+	turn := &aiTurn{
+		board:  p.game.Board,
+		cancel: cancel,
+		result: make(chan aiPlan, 1),
+		done:   make(chan struct{}),
 	}
+	p.aiTurn = turn
+	go planAIMove(ctx, core.AsTimed(player.Strategy), turn.board, p.aiBudget(player), turn.result, turn.done)
 }
 
-// planAIMove plans and executes an AI move with visualization.
-// It runs in a goroutine to avoid blocking the main loop during AI planning.
-func (p *Playfield) planAIMove(player *core.Player) {
-	// Get the AI's next move
-	action, err := player.Strategy.NextMove(p.game.Board)
-	if err != nil {
-		rl.TraceLog(rl.LogWarning, "AI player failed to generate move: %v", err)
-		// Skip turn if AI has no valid moves
-		p.game.AdvanceTurn()
+// planAIMove asks the strategy for its move on the board, thinking within the budget, and sends the plan back,
+// unless the turn is cancelled first. It runs in a goroutine so that the gameplay loop keeps going while the AI
+// thinks, and touches nothing but its arguments.
+func planAIMove(ctx context.Context, strategy core.TimedAgentStrategy, board *core.Board, budget time.Duration,
+	result chan<- aiPlan, done chan<- struct{}) {
+	defer close(done)
+	action, err := strategy.NextMoveContext(ctx, board, budget)
+	if ctx.Err() != nil {
+		// The turn was cancelled, so nobody wants this move anymore
 		return
 	}
+	result <- aiPlan{action: action, err: err}
+}
 
-	// Find the current position of the piece
-	currentPos, err := p.game.Board.PieceLocation(action.Piece)
-	if err != nil {
-		rl.TraceLog(rl.LogWarning, "failed to find AI piece location: %v", err)
-		p.game.AdvanceTurn()
+// continueAITurn picks up the AI's plan once it arrives, shows its piece selected for a moment, then plays it. A plan
+// for a board that's no longer on the table is dropped, and the AI plans again. An AI that can't come up with a move
+// it can play forfeits the game, rather than leaving it waiting forever.
+func (p *Playfield) continueAITurn() {
+	turn := p.aiTurn
+	if turn.plan == nil {
+		select {
+		case plan := <-turn.result:
+			turn.plan = &plan
+		default:
+			return
+		}
+		if turn.board != p.game.Board {
+			p.aiTurn = nil
+			return
+		}
+		if turn.plan.err == nil && (turn.plan.action == nil || turn.plan.action.Piece == nil) {
+			turn.plan.err = fmt.Errorf("no move planned")
+		}
+		if turn.plan.err != nil {
+			p.aiTurn = nil
+			p.forfeitAITurn(turn.plan.err)
+			return
+		}
+		// Select the piece to show where it can move
+		p.SelectPiece(turn.plan.action.Piece)
+		turn.playAt = p.now.Add(p.aiDelay)
+	}
+	if p.now.Before(turn.playAt) {
 		return
 	}
 
-	// Select the piece to visualize the valid moves
-	p.SelectPiece(action.Piece)
+	p.aiTurn = nil
+	p.SelectPiece(nil)
+	if turn.board != p.game.Board {
+		return
+	}
+	if err := p.play(turn.plan.action); err != nil {
+		p.forfeitAITurn(err)
+	}
+}
 
-	// Wait 1 second before executing the move
-	time.Sleep(1 * time.Second)
+// forfeitAITurn ends the game as a loss for the AI on the move, which failed to move for the given reason.
+func (p *Playfield) forfeitAITurn(err error) {
+	color := p.game.ActiveColor
+	p.game.Forfeit(color, fmt.Sprintf("%s failed to move: %v", p.game.GetPlayer(color), err))
+}
 
-	// Signal the main loop to execute this move
-	p.moveExecutionChan <- struct {
-		piece    *core.Piece
-		position core.Position
-		move     core.Move
-	}{
-		piece:    action.Piece,
-		position: currentPos,
-		move:     action.Move,
+// cancelAITurn abandons the AI's move being planned or shown, if there is one.
+func (p *Playfield) cancelAITurn() {
+	if p.aiTurn == nil {
+		return
 	}
+	p.aiTurn.cancel()
+	p.abandoned = p.aiTurn
+	p.aiTurn = nil
+	p.SelectPiece(nil)
 }
 
-// render draws the current game state to the screen.
-func (p *Playfield) render() {
-	rl.BeginDrawing()
-	rl.ClearBackground(rl.RayWhite)
+// undo takes back moves until the human player's last one, so that it's their turn again, abandoning whatever the
+// AI was planning. With no human playing, only the last move is taken back. The time spent on the moves taken back
+// is given back to the sides that spent it.
+func (p *Playfield) undo() {
+	p.cancelAITurn()
+	humanPlaying := p.game.GetPlayer(core.White).IsHuman() || p.game.GetPlayer(core.Black).IsHuman()
+	for len(p.game.Moves) > 0 {
+		color := p.game.Moves[len(p.game.Moves)-1].Color
+		if err := p.game.Undo(); err != nil {
+			break
+		}
+		if !humanPlaying || p.game.GetPlayer(color).IsHuman() {
+			break
+		}
+	}
+	p.rewindClocks()
+	p.animation = nil
+	p.SelectPiece(nil)
+}
 
+// recordClocks records the time each side has used as of the move just played, so that undoing back to it can give
+// back the time spent since.
+func (p *Playfield) recordClocks() {
+	moves := len(p.game.Moves)
+	p.turnClocks = p.turnClocks[:min(len(p.turnClocks), moves-1)]
+	for len(p.turnClocks) < moves-1 {
+		// Moves made before the playfield took the game over used no time that it knows of
+		p.turnClocks = append(p.turnClocks, nil)
+	}
+	p.turnClocks = append(p.turnClocks, maps.Clone(p.timeUsed))
+}
+
+// rewindClocks sets the time each side has used back to what it was when the position on the board was reached,
+// forgetting the times of the moves taken back.
+func (p *Playfield) rewindClocks() {
+	moves := len(p.game.Moves)
+	if moves >= len(p.turnClocks) {
+		return
+	}
+	p.timeUsed = nil
+	if moves > 0 {
+		p.timeUsed = maps.Clone(p.turnClocks[moves-1])
+	}
+	p.turnClocks = p.turnClocks[:moves]
+}
+
+// Draw draws the current game state to the screen.
+func (p *Playfield) Draw() {
+	rl.DrawRectangle(0, 0, int32(p.width), int32(p.height), rl.RayWhite)
+	p.draw()
+}
+
+// draw draws the board, the captured pieces, the move being animated, the cursor and the status.
+func (p *Playfield) draw() {
 	if err := p.renderBoard(); err != nil {
 		rl.TraceLog(rl.LogError, "error rendering game: %v", err)
 	}
@@ -213,13 +562,25 @@ func (p *Playfield) render() {
 		rl.TraceLog(rl.LogError, "error rendering captured pieces: %v", err)
 	}
 
-	p.renderStatus()
+	if err := p.renderAnimation(); err != nil {
+		rl.TraceLog(rl.LogError, "error rendering move: %v", err)
+	}
+	p.renderCursor()
 
-	rl.EndDrawing()
+	p.renderStatus()
+	if !p.setup.TimeControl.Untimed() {
+		p.renderClocks()
+	}
 }
 
 // Close closes the game and cleans up resources.
 func (p *Playfield) Close() {
+	p.closeGame()
+	p.unloadSprites()
+}
+
+// unloadSprites unloads the sprite sheets the playfield draws with.
+func (p *Playfield) unloadSprites() {
 	if p.backgroundSprites != nil {
 		p.backgroundSprites.Unload()
 	}
@@ -231,6 +592,26 @@ func (p *Playfield) Close() {
 	}
 }
 
+// closeGame stops the AI players thinking and closes their strategies, leaving the sprites loaded.
+func (p *Playfield) closeGame() {
+	if p.cancelAI != nil {
+		p.cancelAI()
+	}
+	// Let the workers stop before their strategies are closed
+	for _, turn := range []*aiTurn{p.aiTurn, p.abandoned} {
+		if turn != nil {
+			<-turn.done
+		}
+	}
+	if p.game != nil {
+		for _, color := range []core.Color{core.White, core.Black} {
+			if closer, ok := p.game.GetPlayer(color).Strategy.(io.Closer); ok {
+				_ = closer.Close()
+			}
+		}
+	}
+}
+
 // SelectPiece selects the specified piece, unselecting any previously selected piece.
 // Only allows selecting pieces that belong to the current player.
 func (p *Playfield) SelectPiece(piece *core.Piece) {
@@ -262,20 +643,17 @@ func (p *Playfield) SelectPiece(piece *core.Piece) {
 
 // MouseIsOverBoard returns true if and only if the mouse location is somewhere on the playable board.
 func (p *Playfield) MouseIsOverBoard(mouseLoc rl.Vector2) bool {
-	adjClickLoc := rl.Vector2{X: mouseLoc.X - p.boardLoc.X, Y: mouseLoc.Y - p.boardLoc.Y}
-	return adjClickLoc.X >= 0 && adjClickLoc.X < float32(core.SquareSize*p.game.Board.Columns) &&
-		adjClickLoc.Y >= 0 && adjClickLoc.Y < float32(core.SquareSize*p.game.Board.Rows)
+	return p.layout.contains(mouseLoc)
 }
 
-// PositionUnderMouse returns the board position under a mouse position. If it's outside the board, then an error
-// is returned.
+// PositionUnderMouse returns the board position under a mouse position, at the board's current size. If it's outside
+// the board, then an error is returned.
 func (p *Playfield) PositionUnderMouse(mouseLoc rl.Vector2) (core.Position, error) {
-	// Shift the position relative to the board upper corner so the click loc is in board space
-	adjClickLoc := rl.Vector2{X: mouseLoc.X - p.boardLoc.X, Y: mouseLoc.Y - p.boardLoc.Y}
-	if !p.MouseIsOverBoard(mouseLoc) {
+	pos, ok := p.layout.positionAt(mouseLoc)
+	if !ok {
 		return core.Position{}, fmt.Errorf("click is outside the board bounds")
 	}
-	return core.Position{int(adjClickLoc.Y / float32(core.SquareSize)), int(adjClickLoc.X / float32(core.SquareSize))}, nil
+	return pos, nil
 }
 
 // PieceUnderMouse returns the piece under a mouse position. If there's no piece there, then nil is returned.
@@ -0,0 +1,167 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"cragspider-go/internal/audio"
+	"cragspider-go/internal/core"
+	"cragspider-go/pkg/graphics"
+	"math"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/samber/lo"
+)
+
+// moveAnimation shows the last move being made: its piece stepping square by square along the path it took, then
+// the piece it captured, if any, shrinking away on its way to the captured pieces beside the board.
+type moveAnimation struct {
+	piece    *core.Piece
+	path     graphics.Path // Where the piece is drawn as it moves, from its square to where it stopped
+	captured *core.Piece   // The piece the move captured, or nil
+	capture  graphics.Tween
+	start    time.Time
+}
+
+const (
+	// stepTime is how long a piece takes over each step of its path at normal speed.
+	stepTime = 150 * time.Millisecond
+	// captureTime is how long a captured piece takes to reach the captured pieces at normal speed.
+	captureTime = 400 * time.Millisecond
+	// captureShrink is how small a captured piece gets, halfway to the captured pieces.
+	captureShrink = 0.5
+	// idleStagger is how far apart in time each piece starts idling, so that they don't all move together.
+	idleStagger = 170 * time.Millisecond
+)
+
+// duration returns how long the whole animation takes.
+func (a *moveAnimation) duration() time.Duration {
+	if a.captured == nil {
+		return a.path.Duration()
+	}
+	return a.path.Duration() + a.capture.Duration
+}
+
+// done returns true once the animation is over at the given time.
+func (a *moveAnimation) done(now time.Time) bool {
+	return now.Sub(a.start) >= a.duration()
+}
+
+// arrived returns true once the moving piece has reached the end of its path at the given time.
+func (a *moveAnimation) arrived(now time.Time) bool {
+	return a.path.Done(now.Sub(a.start))
+}
+
+// pieceAt returns where the moving piece is drawn at the given time.
+func (a *moveAnimation) pieceAt(now time.Time) rl.Vector2 {
+	return a.path.At(now.Sub(a.start))
+}
+
+// capturedAt returns where the captured piece is drawn at the given time, and how big. It waits on its square
+// until the moving piece arrives.
+func (a *moveAnimation) capturedAt(now time.Time) (rl.Vector2, float32) {
+	elapsed := now.Sub(a.start) - a.path.Duration()
+	if elapsed <= 0 || a.capture.Duration <= 0 {
+		return a.capture.At(elapsed), 1
+	}
+	progress := rl.Clamp(float32(elapsed)/float32(a.capture.Duration), 0, 1)
+	return a.capture.At(elapsed), 1 - (1-captureShrink)*float32(math.Sin(math.Pi*float64(progress)))
+}
+
+// play makes the move for the player on the move, with the sound of a move or a capture, and, unless animation is
+// turned off, starts it moving across the board.
+func (p *Playfield) play(action *core.Action) error {
+	before := p.game.Board
+	if err := p.game.Play(action); err != nil {
+		return err
+	}
+	move := p.game.Moves[len(p.game.Moves)-1]
+	p.recordClocks()
+	p.sounds.Play(lo.Ternary(before.GetPieceAt(move.To) != nil, audio.Capture, audio.Move))
+	p.animate(before, move)
+	return nil
+}
+
+// animate starts the animation of a move made on the board before it, at the setup's speed.
+func (p *Playfield) animate(before *core.Board, move core.MoveRecord) {
+	speed := p.setup.Animation
+	if speed <= 0 {
+		return
+	}
+	piece := before.GetPieceAt(move.From)
+	if piece == nil {
+		return
+	}
+	points := []rl.Vector2{p.squareLocation(move.From)}
+	squares := piece.PathTo(move.From, move.To, before)
+	if squares == nil {
+		squares = []core.Position{move.To}
+	}
+	for _, square := range squares {
+		points = append(points, p.squareLocation(square))
+	}
+	animation := &moveAnimation{
+		piece: piece,
+		path:  graphics.Path{Points: points, LegTime: speed.scale(stepTime), Ease: graphics.EaseInOutQuad},
+		start: p.now,
+	}
+	if captured := before.GetPieceAt(move.To); captured != nil {
+		slot := len(p.game.Board.GetCapturedPieces(move.Color)) - 1
+		animation.captured = captured
+		animation.capture = graphics.Tween{
+			From:     p.squareLocation(move.To),
+			To:       p.capturedLocation(move.Color, slot),
+			Duration: speed.scale(captureTime),
+			Ease:     graphics.EaseOutCubic,
+		}
+	}
+	p.animation = animation
+}
+
+// advance moves the playfield's clock on to the given time, dropping the move animation once it's over.
+func (p *Playfield) advance(now time.Time) {
+	p.now = now
+	if p.animation != nil && p.animation.done(now) {
+		p.animation = nil
+	}
+}
+
+// animating returns true while a move is being animated, when the board doesn't take input.
+func (p *Playfield) animating() bool {
+	return p.animation != nil && !p.animation.done(p.now)
+}
+
+// renderAnimation draws the pieces of the move being animated over everything else: the captured piece, then the
+// piece that took it. The piece walks along its path and attacks when it arrives, and the captured piece dies.
+func (p *Playfield) renderAnimation() error {
+	a := p.animation
+	if a == nil {
+		return nil
+	}
+	arrived := a.arrived(p.now)
+	if a.captured != nil {
+		location, scale := a.capturedAt(p.now)
+		frame := p.pieceFrame(a.captured, lo.Ternary(arrived, graphics.Die, graphics.Idle))
+		if err := p.renderPieceScaled(a.captured, location, frame, scale); err != nil {
+			return err
+		}
+	}
+	frame := p.pieceFrame(a.piece, lo.Ternary(arrived && a.captured != nil, graphics.Attack, graphics.Walk))
+	return p.renderPieceAtLocationWithFrame(a.piece, a.pieceAt(p.now), frame)
+}
+
+// pieceFrame plays the named clip of the piece's animation, carrying on if it's already playing, and returns the
+// frame of its sprite sheet to draw now.
+func (p *Playfield) pieceFrame(piece *core.Piece, clip string) graphics.FrameCoords {
+	if p.pieceAnimations == nil {
+		p.pieceAnimations = make(map[*core.Piece]*graphics.Player)
+	}
+	player, ok := p.pieceAnimations[piece]
+	if !ok {
+		start := p.now.Add(-time.Duration(len(p.pieceAnimations)) * idleStagger)
+		player = graphics.NewPlayer(piece.Config.Animation(piece.Color), start)
+		p.pieceAnimations[piece] = player
+	}
+	player.Play(clip, p.now)
+	return player.Frame(p.now)
+}
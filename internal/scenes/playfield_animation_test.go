@@ -0,0 +1,213 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"testing"
+	"time"
+
+	"cragspider-go/internal/audio"
+	"cragspider-go/internal/core"
+	"cragspider-go/pkg/graphics"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// animatedPlayfield returns a headless playfield for a new game, animating moves at the speed.
+func animatedPlayfield(t *testing.T, speed AnimationSpeed) *Playfield {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	pf.setup.Animation = speed
+	return pf
+}
+
+// longestAction returns the active color's valid action whose piece travels furthest along its path.
+func longestAction(t *testing.T, game *core.Game) (core.Action, []core.Position) {
+	var (
+		best     core.Action
+		bestPath []core.Position
+	)
+	for _, action := range game.Board.ValidActions(game.ActiveColor) {
+		from, err := game.Board.PieceLocation(action.Piece)
+		require.NoError(t, err)
+		path := action.Piece.PathTo(from, from.Add(action.Move), game.Board)
+		if len(path) > len(bestPath) {
+			best, bestPath = action, path
+		}
+	}
+	require.NotEmpty(t, bestPath)
+	return best, bestPath
+}
+
+func TestPlayfield_AnimatesMoveAlongPath(t *testing.T) {
+	pf := animatedPlayfield(t, NormalAnimation)
+	start := time.Now()
+	pf.advance(start)
+	action, path := longestAction(t, pf.game)
+	from, err := pf.game.Board.PieceLocation(action.Piece)
+	require.NoError(t, err)
+
+	require.NoError(t, pf.play(&action))
+	require.NotNil(t, pf.animation)
+	assert.Same(t, action.Piece, pf.animation.piece)
+	assert.Nil(t, pf.animation.captured)
+	require.Len(t, pf.animation.path.Points, len(path)+1, "the piece should stop on every square of its path")
+	assert.Equal(t, pf.squareLocation(from), pf.animation.path.Points[0])
+	for i, square := range path {
+		assert.Equal(t, pf.squareLocation(square), pf.animation.path.Points[i+1])
+	}
+
+	duration := time.Duration(len(path)) * stepTime
+	assert.Equal(t, duration, pf.animation.duration())
+	assert.Equal(t, pf.squareLocation(from), pf.animation.pieceAt(start))
+	assert.True(t, pf.animating())
+	assert.False(t, pf.finished(start), "nothing is finished while a move is animated")
+
+	pf.advance(start.Add(duration - time.Millisecond))
+	assert.True(t, pf.animating(), "input stays locked until the piece arrives")
+	pf.advance(start.Add(duration))
+	assert.False(t, pf.animating())
+	assert.Nil(t, pf.animation, "a finished animation should be dropped")
+}
+
+func TestPlayfield_AnimationSpeed(t *testing.T) {
+	tests := []struct {
+		name  string
+		speed AnimationSpeed
+		scale float64
+	}{
+		{"slow", SlowAnimation, 2},
+		{"normal", NormalAnimation, 1},
+		{"fast", FastAnimation, 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pf := animatedPlayfield(t, tt.speed)
+			action, path := longestAction(t, pf.game)
+			require.NoError(t, pf.play(&action))
+			require.NotNil(t, pf.animation)
+			assert.Equal(t, time.Duration(float64(len(path))*float64(stepTime)*tt.scale), pf.animation.duration())
+		})
+	}
+
+	pf := animatedPlayfield(t, NoAnimation)
+	action, _ := longestAction(t, pf.game)
+	require.NoError(t, pf.play(&action))
+	assert.Nil(t, pf.animation, "pieces should jump straight there with animation off")
+	assert.False(t, pf.animating())
+}
+
+func TestPlayfield_AnimatesCapture(t *testing.T) {
+	pf := animatedPlayfield(t, NormalAnimation)
+	start := time.Now()
+	pf.advance(start)
+
+	// Play the last move each turn until there's a capture to be made
+	var capture *core.Action
+	for !pf.game.Over() {
+		actions := pf.game.Board.ValidActions(pf.game.ActiveColor)
+		require.NotEmpty(t, actions)
+		for i, action := range actions {
+			from, err := pf.game.Board.PieceLocation(action.Piece)
+			require.NoError(t, err)
+			if pf.game.Board.GetPieceAt(from.Add(action.Move)) != nil {
+				capture = &actions[i]
+				break
+			}
+		}
+		if capture != nil {
+			break
+		}
+		require.NoError(t, pf.game.Play(&actions[len(actions)-1]))
+	}
+	require.NotNil(t, capture, "no capture came up")
+	from, err := pf.game.Board.PieceLocation(capture.Piece)
+	require.NoError(t, err)
+	dest := from.Add(capture.Move)
+	captured := pf.game.Board.GetPieceAt(dest)
+	color := pf.game.ActiveColor
+
+	sounds, recorder := newSoundRecorder(t)
+	pf.useSounds(sounds)
+	require.NoError(t, pf.play(capture))
+	assert.Equal(t, []string{soundFile(t, "", audio.Capture)}, recorder.played)
+	a := pf.animation
+	require.NotNil(t, a)
+	assert.Same(t, captured, a.captured)
+	slot := len(pf.game.Board.GetCapturedPieces(color)) - 1
+	assert.Equal(t, pf.squareLocation(dest), a.capture.From)
+	assert.Equal(t, pf.capturedLocation(color, slot), a.capture.To)
+	assert.Equal(t, a.path.Duration()+captureTime, a.duration())
+
+	// The captured piece waits for the piece taking it, then shrinks on its way and is full size when it arrives
+	location, scale := a.capturedAt(start)
+	assert.Equal(t, pf.squareLocation(dest), location)
+	assert.Equal(t, float32(1), scale)
+	_, scale = a.capturedAt(start.Add(a.path.Duration() + captureTime/2))
+	assert.InDelta(t, captureShrink, scale, 1e-6)
+	location, scale = a.capturedAt(start.Add(a.duration()))
+	assert.Equal(t, pf.capturedLocation(color, slot), location)
+	assert.InDelta(t, 1, scale, 1e-6)
+}
+
+func TestPlayfield_UndoStopsAnimation(t *testing.T) {
+	pf := animatedPlayfield(t, NormalAnimation)
+	pf.advance(time.Now())
+	action, _ := longestAction(t, pf.game)
+	require.NoError(t, pf.play(&action))
+	require.True(t, pf.animating())
+
+	pf.undo()
+	assert.Nil(t, pf.animation)
+	assert.Empty(t, pf.game.Moves)
+}
+
+func TestPlayfield_CapturedLocation(t *testing.T) {
+	pf := animatedPlayfield(t, NoAnimation)
+	square := float32(testSquareSize)
+	width := float32(pf.game.Board.Columns) * square
+	tests := []struct {
+		name  string
+		color core.Color
+		idx   int
+		want  rl.Vector2
+	}{
+		{"white's first on the left", core.White, 0,
+			rl.Vector2{X: 100 - capturedPerRow*square - capturedPadding, Y: 100}},
+		{"black's first on the right", core.Black, 0, rl.Vector2{X: 100 + width + capturedPadding, Y: 100}},
+		{"next along the row", core.Black, 1,
+			rl.Vector2{X: 100 + width + capturedPadding + square, Y: 100}},
+		{"next row down", core.Black, capturedPerRow,
+			rl.Vector2{X: 100 + width + capturedPadding, Y: 100 + square + capturedRowPadding}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pf.capturedLocation(tt.color, tt.idx))
+		})
+	}
+}
+
+func TestPlayfield_PieceFrame(t *testing.T) {
+	pf := animatedPlayfield(t, NormalAnimation)
+	start := time.Now()
+	pf.advance(start)
+	warrior := pf.game.Board.GetPieceAt(core.Position{9, 0})
+	require.NotNil(t, warrior)
+	padwar := pf.game.Board.GetPieceAt(core.Position{9, 1})
+	require.NotNil(t, padwar)
+	animation := warrior.Config.Animation(warrior.Color)
+
+	assert.Equal(t, animation.Clip(graphics.Idle).FrameAt(0), pf.pieceFrame(warrior, graphics.Idle))
+	assert.Equal(t, padwar.Config.Animation(padwar.Color).Clip(graphics.Idle).FrameAt(idleStagger),
+		pf.pieceFrame(padwar, graphics.Idle), "each piece should start idling a little after the last")
+
+	// Idling carries on from where it was, and a new clip starts from its first frame
+	later := start.Add(time.Second + 100*time.Millisecond)
+	pf.advance(later)
+	assert.Equal(t, animation.Clip(graphics.Idle).FrameAt(later.Sub(start)), pf.pieceFrame(warrior, graphics.Idle))
+	assert.Equal(t, animation.Clip(graphics.Walk).FrameAt(0), pf.pieceFrame(warrior, graphics.Walk))
+	assert.Equal(t, graphics.Walk, pf.pieceAnimations[warrior].Playing())
+}
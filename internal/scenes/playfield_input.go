@@ -0,0 +1,151 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"cragspider-go/internal/audio"
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/input"
+	"fmt"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// cursorSteps is how far each of the cursor's controls moves it, in rows and columns.
+var cursorSteps = map[input.Action]core.Move{
+	input.CursorUp:    {-1, 0},
+	input.CursorDown:  {1, 0},
+	input.CursorLeft:  {0, -1},
+	input.CursorRight: {0, 1},
+}
+
+// useControls has the player play the game with the controls.
+func (p *Playfield) useControls(controls *input.Controls) {
+	p.controls = controls
+}
+
+// handleInput carries out the actions the player performed this frame, and moves the cursor to follow the mouse.
+// The board takes no input while a move is being animated.
+func (p *Playfield) handleInput() {
+	if p.game.Over() || p.animating() {
+		return
+	}
+	mouse := p.controls.Mouse()
+	if p.controls.MouseMoved() {
+		p.followMouse(mouse)
+	}
+	for _, action := range input.Actions {
+		if source, ok := p.controls.PressedWith(action); ok {
+			p.perform(action, source, mouse)
+		}
+	}
+}
+
+// followMouse puts the cursor on the square under the mouse, and hides it, since the mouse shows where it is.
+func (p *Playfield) followMouse(mouse rl.Vector2) {
+	if pos, err := p.PositionUnderMouse(mouse); err == nil {
+		p.cursor = pos
+	}
+	p.cursorShown = false
+}
+
+// perform carries out an action performed with the source. Selecting with the mouse chooses the square under it,
+// and with anything else, the square under the cursor. Pieces can only be selected on a human player's turn.
+func (p *Playfield) perform(action input.Action, source input.Source, mouse rl.Vector2) {
+	if step, ok := cursorSteps[action]; ok {
+		p.moveCursor(step)
+		return
+	}
+	if (action == input.Select || action == input.Cancel) && !p.humanToMove() {
+		// The board is the AI's while it's on the move, including the piece it's showing selected
+		return
+	}
+	switch action {
+	case input.Select:
+		if source != input.Mouse {
+			p.cursorShown = true
+			p.chooseSquare(p.cursor)
+			return
+		}
+		pos, err := p.PositionUnderMouse(mouse)
+		if err != nil {
+			// Clicking outside the board puts the piece down
+			p.SelectPiece(nil)
+			return
+		}
+		p.cursor = pos
+		p.chooseSquare(pos)
+	case input.Cancel:
+		p.SelectPiece(nil)
+	case input.Undo:
+		p.undo()
+	case input.Hint:
+		p.requestHint()
+	}
+}
+
+// humanToMove returns true if the player on the move is human, so the board takes their input.
+func (p *Playfield) humanToMove() bool {
+	return !p.game.GetPlayer(p.game.ActiveColor).IsAI()
+}
+
+// moveCursor moves the cursor by the step, keeping it on the board, and shows it.
+func (p *Playfield) moveCursor(step core.Move) {
+	pos := p.cursor.Add(step)
+	p.cursor = core.Position{
+		min(max(pos[0], 0), p.game.Board.Rows-1),
+		min(max(pos[1], 0), p.game.Board.Columns-1),
+	}
+	p.cursorShown = true
+}
+
+// chooseSquare picks up the piece on the square if none is picked up yet, or tries to move the piece picked up
+// there, putting it down either way.
+func (p *Playfield) chooseSquare(pos core.Position) {
+	if p.selectedPiece == nil {
+		p.pickUp(p.game.Board.GetPieceAt(pos))
+		return
+	}
+	move := core.Move{pos[0] - p.selectedPiece.Position[0], pos[1] - p.selectedPiece.Position[1]}
+	if err := p.movePiece(p.selectedPiece, move); err != nil {
+		rl.TraceLog(rl.LogWarning, "failed to move piece %s: %s", p.selectedPiece.Piece, err)
+		p.sounds.Play(audio.InvalidMove)
+	}
+	p.SelectPiece(nil)
+}
+
+// pickUp selects the piece the player chose, with a sound if it could be selected.
+func (p *Playfield) pickUp(piece *core.Piece) {
+	p.SelectPiece(piece)
+	if p.selectedPiece != nil {
+		p.sounds.Play(audio.Select)
+	}
+}
+
+// renderCursor outlines the square the cursor is on, once the player has used it.
+func (p *Playfield) renderCursor() {
+	if !p.cursorShown || p.game.Over() {
+		return
+	}
+	corner := p.squareLocation(p.cursor)
+	square := rl.Rectangle{X: corner.X, Y: corner.Y, Width: p.layout.square, Height: p.layout.square}
+	rl.DrawRectangleLinesEx(square, max(p.layout.square/24, 2), rl.Gold)
+}
+
+// controlsHelp returns what the keys for hints, undoing and pausing are, for the status.
+func (p *Playfield) controlsHelp() string {
+	parts := make([]string, 0, 3)
+	for _, control := range []struct {
+		action input.Action
+		does   string
+	}{{input.Hint, "for a hint"}, {input.Undo, "to undo"}, {input.Menu, "to pause"}} {
+		if key := p.controls.KeyName(control.action); key != "" {
+			parts = append(parts, fmt.Sprintf("%s %s", key, control.does))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "Press " + strings.Join(parts, ", ")
+}
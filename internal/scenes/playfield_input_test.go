@@ -0,0 +1,219 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"testing"
+
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/input"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDevice reports the keys and buttons it's told were pressed, where the mouse is and how far it moved, and the
+// characters typed.
+type fakeDevice struct {
+	keys     map[input.Key]bool
+	mouse    map[input.MouseButton]bool
+	gamepad  map[input.GamepadButton]bool
+	position rl.Vector2
+	delta    rl.Vector2
+	typed    []rune
+}
+
+func (d *fakeDevice) KeyPressed(key input.Key, _ bool) bool { return d.keys[key] }
+
+func (d *fakeDevice) MousePressed(button input.MouseButton) bool { return d.mouse[button] }
+
+func (d *fakeDevice) GamepadPressed(button input.GamepadButton) bool { return d.gamepad[button] }
+
+func (d *fakeDevice) MousePosition() rl.Vector2 { return d.position }
+
+func (d *fakeDevice) MouseDelta() rl.Vector2 { return d.delta }
+
+func (d *fakeDevice) CharPressed() rune {
+	if len(d.typed) == 0 {
+		return 0
+	}
+	char := d.typed[0]
+	d.typed = d.typed[1:]
+	return char
+}
+
+// pressing returns the standard controls, reading a device on which only the keys are pressed.
+func pressing(t *testing.T, keys ...input.Key) *input.Controls {
+	device := &fakeDevice{keys: make(map[input.Key]bool)}
+	for _, key := range keys {
+		device.keys[key] = true
+	}
+	return defaultControls(t, device)
+}
+
+// defaultControls returns the standard controls, reading the device.
+func defaultControls(t *testing.T, device input.Device) *input.Controls {
+	bindings, err := input.DefaultBindings()
+	require.NoError(t, err)
+	return input.NewControls(device, bindings)
+}
+
+// middleOf returns the point in the middle of the square at the position.
+func middleOf(pf *Playfield, pos core.Position) rl.Vector2 {
+	half := pf.layout.square / 2
+	return rl.Vector2Add(pf.squareLocation(pos), rl.Vector2{X: half, Y: half})
+}
+
+func TestPlayfield_MoveCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    core.Position
+		actions []input.Action
+		want    core.Position
+	}{
+		{"up", core.Position{5, 5}, []input.Action{input.CursorUp}, core.Position{4, 5}},
+		{"down", core.Position{5, 5}, []input.Action{input.CursorDown}, core.Position{6, 5}},
+		{"left", core.Position{5, 5}, []input.Action{input.CursorLeft}, core.Position{5, 4}},
+		{"right", core.Position{5, 5}, []input.Action{input.CursorRight}, core.Position{5, 6}},
+		{"stops at the top", core.Position{0, 3}, []input.Action{input.CursorUp}, core.Position{0, 3}},
+		{"stops at the right", core.Position{3, 9}, []input.Action{input.CursorRight}, core.Position{3, 9}},
+		{"around the corner", core.Position{9, 0},
+			[]input.Action{input.CursorDown, input.CursorLeft, input.CursorUp, input.CursorRight},
+			core.Position{8, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := core.NewGame()
+			require.NoError(t, err)
+			pf := headlessPlayfield(game)
+			pf.cursor = tt.from
+			for _, action := range tt.actions {
+				pf.perform(action, input.Keyboard, rl.Vector2{})
+			}
+			assert.Equal(t, tt.want, pf.cursor)
+			assert.True(t, pf.cursorShown, "moving the cursor shows it")
+		})
+	}
+}
+
+func TestPlayfield_SelectWithCursor(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	action := game.Board.ValidActions(core.White)[0]
+	from, err := game.Board.PieceLocation(action.Piece)
+	require.NoError(t, err)
+
+	pf.cursor = from
+	pf.perform(input.Select, input.Gamepad, rl.Vector2{})
+	require.NotNil(t, pf.selectedPiece)
+	assert.Same(t, action.Piece, pf.selectedPiece.Piece)
+
+	pf.cursor = from.Add(action.Move)
+	pf.perform(input.Select, input.Keyboard, rl.Vector2{})
+	assert.Nil(t, pf.selectedPiece)
+	require.Len(t, game.Moves, 1)
+	assert.Equal(t, from.Add(action.Move), game.Moves[0].To)
+}
+
+func TestPlayfield_SelectWithMouse(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	action := game.Board.ValidActions(core.White)[0]
+	from, err := game.Board.PieceLocation(action.Piece)
+	require.NoError(t, err)
+
+	pf.perform(input.Select, input.Mouse, middleOf(pf, from))
+	require.NotNil(t, pf.selectedPiece)
+	assert.Equal(t, from, pf.cursor, "the cursor follows the mouse")
+	assert.False(t, pf.cursorShown)
+
+	pf.perform(input.Select, input.Mouse, rl.Vector2{X: 1, Y: 1})
+	assert.Nil(t, pf.selectedPiece, "clicking off the board puts the piece down")
+
+	pf.perform(input.Select, input.Mouse, middleOf(pf, from))
+	pf.perform(input.Select, input.Mouse, middleOf(pf, from.Add(action.Move)))
+	require.Len(t, game.Moves, 1)
+	assert.Equal(t, from.Add(action.Move), game.Moves[0].To)
+}
+
+func TestPlayfield_Cancel(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	pf.SelectPiece(game.Board.ValidActions(core.White)[0].Piece)
+	require.NotNil(t, pf.selectedPiece)
+
+	pf.perform(input.Cancel, input.Keyboard, rl.Vector2{})
+	assert.Nil(t, pf.selectedPiece)
+	assert.Empty(t, game.Moves)
+}
+
+func TestPlayfield_FollowMouse(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	pf.cursor, pf.cursorShown = core.Position{2, 2}, true
+
+	pf.followMouse(middleOf(pf, core.Position{7, 3}))
+	assert.Equal(t, core.Position{7, 3}, pf.cursor)
+	assert.False(t, pf.cursorShown, "the mouse shows where it is instead")
+
+	pf.followMouse(rl.Vector2{X: 1, Y: 1})
+	assert.Equal(t, core.Position{7, 3}, pf.cursor, "the cursor stays on the board")
+
+	// Through the controls, the cursor only follows the mouse when it moves
+	pf.controls = defaultControls(t, &fakeDevice{position: middleOf(pf, core.Position{5, 4})})
+	pf.handleInput()
+	assert.Equal(t, core.Position{7, 3}, pf.cursor, "a mouse that hasn't moved is left alone")
+	pf.controls = defaultControls(t, &fakeDevice{
+		position: middleOf(pf, core.Position{5, 4}),
+		delta:    rl.Vector2{X: 3},
+	})
+	pf.handleInput()
+	assert.Equal(t, core.Position{5, 4}, pf.cursor)
+}
+
+func TestPlayfield_ControlsHelp(t *testing.T) {
+	pf := &Playfield{}
+	assert.Empty(t, pf.controlsHelp(), "without controls there's nothing to press")
+
+	pf.controls = defaultControls(t, &fakeDevice{})
+	assert.Equal(t, "Press H for a hint, U to undo, P to pause", pf.controlsHelp())
+
+	pf.controls = input.NewControls(&fakeDevice{}, input.Bindings{
+		input.Undo: {Keys: []input.Key{rl.KeyZ}},
+		input.Menu: {Keys: []input.Key{rl.KeyF10}},
+	})
+	assert.Equal(t, "Press Z to undo, F10 to pause", pf.controlsHelp())
+}
+
+func TestPlayfield_IgnoresBoardInputOnAITurn(t *testing.T) {
+	strategy := &blockingStrategy{cancelled: make(chan struct{}, 1)}
+	game, err := core.NewGameWithConfigAndPlayers(mustConfig(t), core.NewHumanPlayer(),
+		core.NewAIPlayer("blocker", strategy))
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	defer pf.Close()
+	require.NoError(t, game.Play(&game.Board.ValidActions(core.White)[0]))
+	pf.update()
+	require.NotNil(t, pf.aiTurn, "the AI should be thinking")
+
+	action := game.Board.ValidActions(core.Black)[0]
+	from, err := game.Board.PieceLocation(action.Piece)
+	require.NoError(t, err)
+	pf.cursor = from
+	pf.perform(input.Select, input.Keyboard, rl.Vector2{})
+	pf.perform(input.Select, input.Mouse, middleOf(pf, from))
+	assert.Nil(t, pf.selectedPiece, "the AI's pieces can't be picked up")
+
+	// The piece the AI shows selected stays selected
+	pf.SelectPiece(action.Piece)
+	pf.perform(input.Cancel, input.Keyboard, rl.Vector2{})
+	assert.NotNil(t, pf.selectedPiece)
+
+	pf.perform(input.CursorDown, input.Keyboard, rl.Vector2{})
+	assert.Equal(t, from.Add(core.Move{1, 0}), pf.cursor, "the cursor still moves")
+}
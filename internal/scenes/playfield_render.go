@@ -3,10 +3,12 @@
 package scenes
 
 import (
+	"cragspider-go/internal/ai"
 	"cragspider-go/internal/core"
 	"cragspider-go/pkg/graphics"
 	"fmt"
 	"image/color"
+	"math"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 	"github.com/samber/lo"
@@ -15,10 +17,19 @@ import (
 // positionTintMap is a map of board positions to their tint colors
 type positionTintMap map[core.Position]color.RGBA
 
+const (
+	// capturedPadding is the space between the board and the captured pieces beside it.
+	capturedPadding = 20
+	// capturedRowPadding is the space between rows of captured pieces.
+	capturedRowPadding = 10
+	// capturedPerRow is how many captured pieces are shown side by side.
+	capturedPerRow = 3
+)
+
 // renderBoard draws the board to the screen with the given board location (where the upper left corner is).
 func (p *Playfield) renderBoard() error {
 	// First draw the board itself
-	tints := p.getTintedPositions(rl.GetMousePosition())
+	tints := p.getTintedPositions(p.controls.Mouse())
 	for i := range p.game.Board.Rows {
 		for j := range p.game.Board.Columns {
 			pos := core.Position{i, j}
@@ -28,8 +39,8 @@ func (p *Playfield) renderBoard() error {
 			}
 			err := p.backgroundSprites.DrawFrame(
 				p.game.Board.GetSquareAt(pos).Frame,
-				rl.Vector2{X: p.boardLoc.X + float32(j*core.SquareSize), Y: p.boardLoc.Y + float32(i*core.SquareSize)},
-				core.Scale,
+				p.squareLocation(pos),
+				p.layout.scale(),
 				p.game.Board.GetSquareAt(core.Position{i, j}).Rotation,
 				tint)
 			if err != nil {
@@ -37,11 +48,11 @@ func (p *Playfield) renderBoard() error {
 			}
 		}
 	}
-	// Now draw each of the pieces on the board
+	// Now draw each of the pieces on the board, except one that's being animated
 	for i := range p.game.Board.Rows {
 		for j := range p.game.Board.Columns {
 			piece := p.game.Board.GetPieceAt(core.Position{i, j})
-			if piece != nil {
+			if piece != nil && (p.animation == nil || piece != p.animation.piece) {
 				err2 := p.renderPieceOnBoard(piece, j, i)
 				if err2 != nil {
 					return err2
@@ -54,9 +65,14 @@ func (p *Playfield) renderBoard() error {
 }
 
 // getTintedPositions returns a map of positions on the board that should be tinted to their corresponding colors.
-// It checks for pieces under the mouse first, then falls back to the selected piece.
+// A hint's move is tinted first, then pieces under the mouse, then the selected piece.
 func (p *Playfield) getTintedPositions(mousePos rl.Vector2) positionTintMap {
 	tints := make(positionTintMap)
+	if analysis := p.currentAnalysis(); analysis != nil && analysis.Best() != nil {
+		move := analysis.Best().PV[0]
+		tints[move.From] = rl.SkyBlue
+		tints[move.To] = rl.SkyBlue
+	}
 	// If mouse is hovering over a piece, tint it and where it can move to
 	pieceUnderMouse := p.PieceUnderMouse(mousePos)
 	if pieceUnderMouse != nil {
@@ -89,56 +105,62 @@ func (p *Playfield) getTintedPositions(mousePos rl.Vector2) positionTintMap {
 // renderPieceOnBoard renders a single piece on the board at the specified position.
 func (p *Playfield) renderPieceOnBoard(piece *core.Piece, j int, i int) error {
 	isSelected := p.selectedPiece != nil && p.selectedPiece.Piece == piece
-	frame := lo.Ternary(isSelected, 0, 1)
-	location := rl.Vector2{X: p.boardLoc.X + float32(j*core.SquareSize), Y: p.boardLoc.Y + float32(i*core.SquareSize)}
-	return p.renderPieceAtLocationWithFrame(piece, location, frame)
+	frame := p.pieceFrame(piece, lo.Ternary(isSelected, graphics.Walk, graphics.Idle))
+	return p.renderPieceAtLocationWithFrame(piece, p.squareLocation(core.Position{i, j}), frame)
 }
 
-// renderCapturedPieces renders the captured pieces on the sides of the board.
-// White captured pieces are displayed on the left, black on the right.
-func (p *Playfield) renderCapturedPieces() error {
-	const (
-		padding        = 20
-		piecePadding   = 10
-		capturedPerRow = 3
-	)
-
-	// Render white captured pieces on the left
-	whiteCaptured := p.game.Board.GetCapturedPieces(core.White)
-	for idx, piece := range whiteCaptured {
-		row := idx / capturedPerRow
-		col := idx % capturedPerRow
-		x := p.boardLoc.X - float32((capturedPerRow*core.SquareSize)+padding)
-		y := p.boardLoc.Y + float32(row*(core.SquareSize+piecePadding))
+// squareLocation returns where the upper left corner of the square at the position is on the screen.
+func (p *Playfield) squareLocation(pos core.Position) rl.Vector2 {
+	return p.layout.squareLocation(pos)
+}
 
-		if err := p.renderPieceAtLocationWithFrame(piece, rl.Vector2{X: x + float32(col*core.SquareSize), Y: y}, 1); err != nil {
-			return err
+// renderCapturedPieces renders the captured pieces on the sides of the board, except one still being animated on its
+// way there. White captured pieces are displayed on the left, black on the right.
+func (p *Playfield) renderCapturedPieces() error {
+	for _, color := range []core.Color{core.White, core.Black} {
+		for idx, piece := range p.game.Board.GetCapturedPieces(color) {
+			if p.animation != nil && piece == p.animation.captured {
+				continue
+			}
+			frame := p.pieceFrame(piece, graphics.Idle)
+			if err := p.renderPieceAtLocationWithFrame(piece, p.capturedLocation(color, idx), frame); err != nil {
+				return err
+			}
 		}
 	}
+	return nil
+}
 
-	// Render black captured pieces on the right
-	blackCaptured := p.game.Board.GetCapturedPieces(core.Black)
-	for idx, piece := range blackCaptured {
-		row := idx / capturedPerRow
-		col := idx % capturedPerRow
-		x := p.boardLoc.X + float32(p.game.Board.Columns*core.SquareSize+padding)
-		y := p.boardLoc.Y + float32(row*(core.SquareSize+piecePadding))
-
-		if err := p.renderPieceAtLocationWithFrame(piece, rl.Vector2{X: x + float32(col*core.SquareSize), Y: y}, 1); err != nil {
-			return err
-		}
+// capturedLocation returns where the upper left corner of the idx'th piece captured by the color is on the screen.
+func (p *Playfield) capturedLocation(color core.Color, idx int) rl.Vector2 {
+	row := idx / capturedPerRow
+	col := idx % capturedPerRow
+	square := p.layout.square
+	x := p.layout.origin.X - capturedPerRow*square - capturedPadding
+	if color == core.Black {
+		x = p.layout.origin.X + p.layout.width() + capturedPadding
 	}
+	y := p.layout.origin.Y + float32(row)*(square+capturedRowPadding)
+	return rl.Vector2{X: x + float32(col)*square, Y: y}
+}
 
-	return nil
+// renderPieceAtLocationWithFrame renders a piece at the specified screen location with a specific frame of its
+// sprite sheet.
+func (p *Playfield) renderPieceAtLocationWithFrame(piece *core.Piece, location rl.Vector2,
+	frame graphics.FrameCoords) error {
+	return p.renderPieceScaled(piece, location, frame, 1)
 }
 
-// renderPieceAtLocationWithFrame renders a piece at the specified screen location with a specific frame.
-func (p *Playfield) renderPieceAtLocationWithFrame(piece *core.Piece, location rl.Vector2, frame int) error {
+// renderPieceScaled renders a piece with a specific frame, scaled about the middle of the square whose upper left
+// corner is at the location.
+func (p *Playfield) renderPieceScaled(piece *core.Piece, location rl.Vector2, frame graphics.FrameCoords,
+	scale float32) error {
 	sheet := lo.Ternary(piece.Color == core.White, p.whiteSprites, p.blackSprites)
+	inset := p.layout.square * (1 - scale) / 2
 	err := sheet.DrawFrame(
-		piece.Config.Sprites[piece.Color][frame],
-		location,
-		core.Scale,
+		frame,
+		rl.Vector2{X: location.X + inset, Y: location.Y + inset},
+		p.layout.scale()*scale,
 		rl.Vector2{X: 1.0, Y: 0.0},
 		rl.White)
 	if err != nil {
@@ -147,12 +169,88 @@ func (p *Playfield) renderPieceAtLocationWithFrame(piece *core.Piece, location r
 	return nil
 }
 
-// renderStatus renders all status needs of the playfield, like whose turn it is.
+// renderStatus renders all status needs of the playfield, like whose turn it is or how the game ended.
 func (p *Playfield) renderStatus() {
+	if p.game.Over() {
+		p.renderOutcome()
+		return
+	}
 	turnText := lo.Ternary(p.game.ActiveColor == core.White, "White's Turn", "Black's Turn")
 	turnColor := lo.Ternary(p.game.ActiveColor == core.White, rl.Black, rl.DarkGray)
 	fontSize := int32(24)
 	x := int32(20)
 	y := int32(20)
 	rl.DrawText(turnText, x, y, fontSize, turnColor)
+
+	if p.analyst == nil {
+		// Without hints, there's no analysis to show
+		return
+	}
+	analysis := p.currentAnalysis()
+	hintText := p.controlsHelp()
+	switch {
+	case p.analyzing:
+		hintText = "Thinking..."
+	case analysis != nil:
+		hintText = fmt.Sprintf("Eval %s (depth %d)", ai.FormatScore(analysis.Eval()), analysis.Depth)
+	}
+	rl.DrawText(hintText, x, y+fontSize+8, fontSize*3/4, rl.DarkGray)
+	if analysis != nil {
+		p.renderEvalBar(analysis.Eval())
+	}
+}
+
+// renderClocks draws the time each side has left above the right-hand corner of the board, the side on the move's
+// darker.
+func (p *Playfield) renderClocks() {
+	const (
+		fontSize = 24
+		gap      = 32
+	)
+	right := int32(p.layout.origin.X + p.layout.width())
+	y := int32(p.layout.origin.Y) - fontSize - 12
+	for _, color := range []core.Color{core.Black, core.White} {
+		text := fmt.Sprintf("%s %s", colorName(color), formatClock(max(p.remaining(color), 0)))
+		tint := lo.Ternary(color == p.game.ActiveColor && !p.game.Over(), rl.Black, rl.Gray)
+		right -= rl.MeasureText(text, fontSize)
+		rl.DrawText(text, right, y, fontSize, tint)
+		right -= gap
+	}
+}
+
+// renderEvalBar draws a bar beside the board that is White from the bottom up as far as White is ahead, by the
+// score from White's point of view.
+func (p *Playfield) renderEvalBar(eval float32) {
+	const (
+		width   = 12
+		padding = 8
+	)
+	top, height := p.layout.origin.Y, p.layout.height()
+	x := p.layout.origin.X - width - padding
+	white := height * evalBarFraction(eval)
+	rl.DrawRectangleV(rl.Vector2{X: x, Y: top}, rl.Vector2{X: width, Y: height - white}, rl.DarkGray)
+	rl.DrawRectangleV(rl.Vector2{X: x, Y: top + height - white}, rl.Vector2{X: width, Y: white}, rl.White)
+	rl.DrawRectangleLines(int32(x), int32(top), width, int32(height), rl.Black)
+}
+
+// evalBarFraction returns how much of the evaluation bar is White's for a score from White's point of view. An
+// even position is half and half, and the bar fills up smoothly as one side gets further ahead, so that a won game
+// fills it completely.
+func evalBarFraction(eval float32) float32 {
+	const threeQuarters = 4 // How far ahead White is when three quarters of the bar are White's
+	return float32(0.5 + 0.5*math.Tanh(float64(eval)*math.Atanh(0.5)/threeQuarters))
+}
+
+// renderOutcome renders how the game ended and how it moved each side's rating.
+func (p *Playfield) renderOutcome() {
+	fontSize := int32(24)
+	x := int32(20)
+	y := int32(20)
+	rl.DrawText(p.game.Outcome().String(), x, y, fontSize, rl.Black)
+	if p.ratingChange == nil {
+		return
+	}
+	ratingText := fmt.Sprintf("Rating: %s %+.0f, %s %+.0f",
+		p.game.GetPlayer(core.White), p.ratingChange.White, p.game.GetPlayer(core.Black), p.ratingChange.Black)
+	rl.DrawText(ratingText, x, y+fontSize+8, fontSize*3/4, rl.DarkGray)
 }
@@ -5,10 +5,12 @@ package scenes
 import (
 	"testing"
 
+	"cragspider-go/internal/ai"
 	"cragspider-go/internal/core"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPlayfield_getTintedPositions(t *testing.T) {
@@ -20,8 +22,8 @@ func TestPlayfield_getTintedPositions(t *testing.T) {
 
 	// Create a test playfield with the game
 	pf := &Playfield{
-		game:     game,
-		boardLoc: rl.Vector2{X: 0, Y: 0}, // Simple location for test
+		game:   game,
+		layout: testLayout(game, 0, 0), // Simple location for test
 	}
 
 	tests := []struct {
@@ -126,8 +128,8 @@ func TestPlayfield_getTintedPositions(t *testing.T) {
 		assert.NotNil(t, whitePiece, "Should find at least one white piece on board")
 
 		// Calculate mouse position at center of that square
-		mouseX := float32(whitePiecePos[1]*core.SquareSize + core.SquareSize/2)
-		mouseY := float32(whitePiecePos[0]*core.SquareSize + core.SquareSize/2)
+		mouseX := float32(whitePiecePos[1]*testSquareSize + testSquareSize/2)
+		mouseY := float32(whitePiecePos[0]*testSquareSize + testSquareSize/2)
 
 		tints := pf.getTintedPositions(rl.Vector2{X: mouseX, Y: mouseY})
 
@@ -135,3 +137,31 @@ func TestPlayfield_getTintedPositions(t *testing.T) {
 		assert.NotEmpty(t, tints, "Should have tints when mouse is over piece")
 	})
 }
+
+func TestPlayfield_getTintedPositionsShowsHint(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	pf := &Playfield{game: game}
+	hint := core.MoveRecord{Color: core.White, Piece: "warrior", From: core.Position{9, 0}, To: core.Position{7, 0}}
+	pf.analysis = &analyzed{Analysis: &ai.Analysis{
+		ToMove: core.White,
+		Lines:  []ai.Line{{PV: []core.MoveRecord{hint}}},
+	}, board: game.Board}
+
+	tints := pf.getTintedPositions(rl.Vector2{X: -100, Y: -100})
+	assert.Equal(t, rl.SkyBlue, tints[hint.From])
+	assert.Equal(t, rl.SkyBlue, tints[hint.To])
+
+	// Once a move is made, the hint is out of date
+	require.NoError(t, game.Play(&game.Board.ValidActions(core.White)[0]))
+	assert.Empty(t, pf.getTintedPositions(rl.Vector2{X: -100, Y: -100}))
+}
+
+func TestEvalBarFraction(t *testing.T) {
+	assert.InDelta(t, 0.5, evalBarFraction(0), 1e-6, "an even position is half and half")
+	assert.InDelta(t, 0.75, evalBarFraction(4), 1e-6)
+	assert.InDelta(t, 0.25, evalBarFraction(-4), 1e-6)
+	assert.InDelta(t, 1, evalBarFraction(100000), 1e-6, "a won game fills the bar")
+	assert.InDelta(t, 0, evalBarFraction(-100000), 1e-6, "a lost game empties it")
+	assert.Less(t, evalBarFraction(1), evalBarFraction(2))
+}
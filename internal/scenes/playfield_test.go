@@ -3,9 +3,16 @@
 package scenes
 
 import (
+	"context"
+	"errors"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"cragspider-go/internal/ai"
+	"cragspider-go/internal/audio"
 	"cragspider-go/internal/core"
+	"cragspider-go/internal/rating"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 	"github.com/stretchr/testify/assert"
@@ -14,14 +21,9 @@ import (
 
 func TestPlayfield_PositionUnderClick(t *testing.T) {
 	// Create a test playfield with a 10x10 board
-	pf := &Playfield{
-		boardLoc: rl.Vector2{X: 100, Y: 100},
-	}
-
-	// Create a mock game with a board
 	game, err := core.NewGame()
 	require.NoError(t, err)
-	pf.game = game
+	pf := &Playfield{game: game, layout: testLayout(game, 100, 100)}
 
 	tests := []struct {
 		name        string
@@ -49,17 +51,17 @@ func TestPlayfield_PositionUnderClick(t *testing.T) {
 		},
 		{
 			name:        "middle of first square",
-			clickX:      float32(100 + core.SquareSize/2),
-			clickY:      float32(100 + core.SquareSize/2),
+			clickX:      float32(100 + testSquareSize/2),
+			clickY:      float32(100 + testSquareSize/2),
 			expectedRow: 0,
 			expectedCol: 0,
 			expectErr:   false,
 		},
 		{
 			name:        "on the border between squares",
-			clickX:      float32(100 + core.SquareSize), // Exactly on the border between first and second column
-			clickY:      float32(100 + core.SquareSize), // Exactly on the border between first and second row
-			expectedRow: 1,                              // Should go to the next row/column
+			clickX:      float32(100 + testSquareSize), // Exactly on the border between first and second column
+			clickY:      float32(100 + testSquareSize), // Exactly on the border between first and second row
+			expectedRow: 1,                             // Should go to the next row/column
 			expectedCol: 1,
 			expectErr:   false,
 		},
@@ -71,7 +73,7 @@ func TestPlayfield_PositionUnderClick(t *testing.T) {
 		},
 		{
 			name:      "outside right",
-			clickX:    float32(100 + 10*core.SquareSize),
+			clickX:    float32(100 + 10*testSquareSize),
 			clickY:    150,
 			expectErr: true,
 		},
@@ -84,13 +86,13 @@ func TestPlayfield_PositionUnderClick(t *testing.T) {
 		{
 			name:      "outside bottom",
 			clickX:    150,
-			clickY:    float32(100 + 10*core.SquareSize),
+			clickY:    float32(100 + 10*testSquareSize),
 			expectErr: true,
 		},
 		{
 			name:      "bottom right corner of last square",
-			clickX:    float32(100 + 10*core.SquareSize),
-			clickY:    float32(100 + 10*core.SquareSize),
+			clickX:    float32(100 + 10*testSquareSize),
+			clickY:    float32(100 + 10*testSquareSize),
 			expectErr: true,
 		},
 	}
@@ -113,14 +115,9 @@ func TestPlayfield_PositionUnderClick(t *testing.T) {
 
 func TestPlayfield_MouseIsOverBoard(t *testing.T) {
 	// Create a test playfield with a 10x10 board
-	pf := &Playfield{
-		boardLoc: rl.Vector2{X: 100, Y: 100},
-	}
-
-	// Create a mock game with a board
 	game, err := core.NewGame()
 	require.NoError(t, err)
-	pf.game = game
+	pf := &Playfield{game: game, layout: testLayout(game, 100, 100)}
 
 	tests := []struct {
 		name     string
@@ -136,8 +133,8 @@ func TestPlayfield_MouseIsOverBoard(t *testing.T) {
 		},
 		{
 			name:     "middle of first square",
-			mouseX:   float32(100 + core.SquareSize/2),
-			mouseY:   float32(100 + core.SquareSize/2),
+			mouseX:   float32(100 + testSquareSize/2),
+			mouseY:   float32(100 + testSquareSize/2),
 			expected: true,
 		},
 		{
@@ -148,7 +145,7 @@ func TestPlayfield_MouseIsOverBoard(t *testing.T) {
 		},
 		{
 			name:     "just outside right",
-			mouseX:   float32(100 + 10*core.SquareSize),
+			mouseX:   float32(100 + 10*testSquareSize),
 			mouseY:   150,
 			expected: false,
 		},
@@ -161,19 +158,19 @@ func TestPlayfield_MouseIsOverBoard(t *testing.T) {
 		{
 			name:     "just outside bottom",
 			mouseX:   150,
-			mouseY:   float32(100 + 10*core.SquareSize),
+			mouseY:   float32(100 + 10*testSquareSize),
 			expected: false,
 		},
 		{
 			name:     "bottom right corner of last square",
-			mouseX:   float32(100 + 10*core.SquareSize - 1),
-			mouseY:   float32(100 + 10*core.SquareSize - 1),
+			mouseX:   float32(100 + 10*testSquareSize - 1),
+			mouseY:   float32(100 + 10*testSquareSize - 1),
 			expected: true,
 		},
 		{
 			name:     "exactly at bottom right corner",
-			mouseX:   float32(100 + 10*core.SquareSize),
-			mouseY:   float32(100 + 10*core.SquareSize),
+			mouseX:   float32(100 + 10*testSquareSize),
+			mouseY:   float32(100 + 10*testSquareSize),
 			expected: false,
 		},
 	}
@@ -277,3 +274,426 @@ func TestPlayfield_SelectPiece(t *testing.T) {
 		})
 	}
 }
+
+func TestPlayfield_rateGame(t *testing.T) {
+	cfg, err := core.GetConfig()
+	require.NoError(t, err)
+	lonely := *cfg
+	lonely.Board.White = cfg.Board.White[:1]
+	lonely.Board.Black = []core.BoardPosition{{Name: "padwar", Position: core.Position{7, 0}}}
+	blackPlayer, err := ai.NewAIPlayer("doofus", core.Black)
+	require.NoError(t, err)
+	game, err := core.NewGameWithConfigAndPlayers(&lonely, core.NewProfilePlayer("Dejah"), blackPlayer)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "ratings.json")
+
+	_, err = rateGame(path, game)
+	assert.Error(t, err, "an unfinished game can't be rated")
+
+	require.NoError(t, game.Play(&core.Action{Piece: game.Board.GetPieceAt(core.Position{9, 0}), Move: core.Move{-2, 0}}))
+	change, err := rateGame(path, game)
+	require.NoError(t, err)
+	assert.InDelta(t, 16, change.White, 1e-9)
+	assert.InDelta(t, -16, change.Black, 1e-9)
+
+	table, err := rating.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, table.Get(rating.HumanKey("Dejah")).Wins, "humans are rated under their profile")
+	assert.Zero(t, table.Get(rating.HumanKey(core.DefaultProfile)).Games())
+	assert.Equal(t, 1, table.Get(rating.AIKey("doofus")).Losses)
+}
+
+func TestPlayfield_receiveAnalysis(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	pf := &Playfield{game: game, analysisChan: make(chan analyzed, 1), analyzing: true}
+
+	assert.Nil(t, pf.receiveAnalysis())
+	assert.True(t, pf.analyzing, "nothing has arrived yet")
+	assert.Nil(t, pf.currentAnalysis())
+
+	analysis := &ai.Analysis{ToMove: core.White, Depth: 2}
+	start := game.Board
+	pf.analysisChan <- analyzed{Analysis: analysis, board: start}
+	assert.Same(t, analysis, pf.receiveAnalysis())
+	assert.False(t, pf.analyzing)
+	assert.Same(t, analysis, pf.currentAnalysis())
+
+	// An analysis of a position the game has already left is dropped
+	require.NoError(t, game.Play(&game.Board.ValidActions(core.White)[0]))
+	assert.Nil(t, pf.currentAnalysis())
+	pf.analyzing = true
+	pf.analysisChan <- analyzed{Analysis: analysis, board: start}
+	assert.Nil(t, pf.receiveAnalysis())
+	assert.False(t, pf.analyzing)
+	assert.Nil(t, pf.currentAnalysis())
+}
+
+// headlessPlayfield returns a playfield for the game that can be updated without a window, with AI moves played as
+// soon as they're planned.
+func headlessPlayfield(game *core.Game) *Playfield {
+	pf := &Playfield{game: game, layout: testLayout(game, 100, 100), analysisChan: make(chan analyzed, 1)}
+	pf.aiContext, pf.cancelAI = context.WithCancel(context.Background())
+	return pf
+}
+
+// blockingStrategy never finds a move, thinking until it's cancelled and then saying so on its channel.
+type blockingStrategy struct {
+	cancelled chan struct{}
+}
+
+func (s *blockingStrategy) NextMove(board *core.Board) (*core.Action, error) {
+	return s.NextMoveContext(context.Background(), board, 0)
+}
+
+func (s *blockingStrategy) NextMoveContext(ctx context.Context, _ *core.Board, _ time.Duration) (*core.Action, error) {
+	<-ctx.Done()
+	s.cancelled <- struct{}{}
+	return nil, ctx.Err()
+}
+
+// updateUntil runs the gameplay loop's update, and reads what rendering reads, until done says to stop.
+func updateUntil(t *testing.T, pf *Playfield, done func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !done() {
+		require.True(t, time.Now().Before(deadline), "the playfield should get there in time")
+		pf.update()
+		_ = pf.getTintedPositions(rl.Vector2{X: 150, Y: 150})
+		_ = pf.currentAnalysis()
+	}
+}
+
+func TestPlayfield_AITurns(t *testing.T) {
+	blackPlayer, err := ai.NewAIPlayer("grabber", core.Black)
+	require.NoError(t, err)
+	cfg, err := core.GetConfig()
+	require.NoError(t, err)
+	game, err := core.NewGameWithConfigAndPlayers(cfg, core.NewHumanPlayer(), blackPlayer)
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	defer pf.Close()
+
+	// The human plays scripted moves and the AI answers each in turn
+	for turn := range 6 {
+		if game.Over() {
+			break
+		}
+		actions := game.Board.ValidActions(core.White)
+		require.NoError(t, pf.movePiece(&SelectedPieceAndPosition{Piece: actions[0].Piece}, actions[0].Move))
+		updateUntil(t, pf, func() bool { return game.Over() || game.ActiveColor == core.White })
+		if !game.Over() {
+			assert.Len(t, game.Moves, 2*(turn+1), "the AI should have answered")
+			assert.Equal(t, core.Black, game.Moves[len(game.Moves)-1].Color)
+		}
+		assert.Nil(t, pf.aiTurn, "the AI's turn should be over")
+		assert.Nil(t, pf.selectedPiece, "the AI's piece should be deselected once it moves")
+	}
+}
+
+func TestPlayfield_ShowsAIMoveBeforePlayingIt(t *testing.T) {
+	blackPlayer, err := ai.NewAIPlayer("grabber", core.Black)
+	require.NoError(t, err)
+	cfg, err := core.GetConfig()
+	require.NoError(t, err)
+	game, err := core.NewGameWithConfigAndPlayers(cfg, core.NewHumanPlayer(), blackPlayer)
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	pf.aiDelay = time.Hour
+	defer pf.Close()
+	start := time.Now()
+	pf.advance(start)
+
+	require.NoError(t, game.Play(&game.Board.ValidActions(core.White)[0]))
+	updateUntil(t, pf, func() bool { return pf.aiTurn != nil && pf.aiTurn.plan != nil })
+	require.NotNil(t, pf.selectedPiece, "the AI's piece should be shown selected")
+	assert.Equal(t, pf.aiTurn.plan.action.Piece, pf.selectedPiece.Piece)
+	pf.advance(start.Add(time.Hour - time.Second))
+	pf.update()
+	assert.Len(t, game.Moves, 1, "the move waits until it has been shown")
+
+	// A pause puts it off by as long as it lasted
+	pf.pausedAt = start.Add(time.Hour - time.Second)
+	pf.holdPause(start.Add(3 * time.Hour))
+	pf.advance(start.Add(3 * time.Hour))
+	pf.update()
+	assert.Len(t, game.Moves, 1, "the pause isn't counted as showing the move")
+
+	pf.advance(start.Add(3*time.Hour + time.Second))
+	pf.update()
+	assert.Len(t, game.Moves, 2, "then it's played")
+}
+
+func TestPlayfield_DropsStalePlan(t *testing.T) {
+	strategy := &blockingStrategy{cancelled: make(chan struct{}, 1)}
+	game, err := core.NewGameWithConfigAndPlayers(mustConfig(t), core.NewHumanPlayer(),
+		core.NewAIPlayer("blocker", strategy))
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	defer pf.Close()
+
+	planned := game.Board
+	action := game.Board.ValidActions(core.White)[0]
+	pf.aiTurn = &aiTurn{board: planned, plan: &aiPlan{action: &action}, cancel: func() {}, done: closedDone()}
+	require.NoError(t, game.Play(&game.Board.ValidActions(core.White)[1]))
+
+	pf.continueAITurn()
+	assert.Nil(t, pf.aiTurn, "a plan for an old board is dropped")
+	assert.Len(t, game.Moves, 1, "and not played")
+}
+
+// closedDone returns a done channel for a turn whose worker has already finished.
+func closedDone() chan struct{} {
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+// fixedPlan is a strategy that always plans the same move, or fails with the same error.
+type fixedPlan struct {
+	action *core.Action
+	err    error
+}
+
+func (s fixedPlan) NextMove(*core.Board) (*core.Action, error) {
+	return s.action, s.err
+}
+
+func TestPlayfield_AIFailsToMove(t *testing.T) {
+	tests := []struct {
+		name       string
+		strategy   fixedPlan
+		wantReason string
+	}{
+		{"error", fixedPlan{err: errors.New("engine exited")}, "blocker failed to move: engine exited"},
+		{"no move", fixedPlan{}, "blocker failed to move: no move planned"},
+		{"no piece", fixedPlan{action: &core.Action{}}, "blocker failed to move: no move planned"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := core.NewGameWithConfigAndPlayers(mustConfig(t), core.NewHumanPlayer(),
+				core.NewAIPlayer("blocker", tt.strategy))
+			require.NoError(t, err)
+			pf := headlessPlayfield(game)
+			defer pf.Close()
+			require.NoError(t, game.Play(&game.Board.ValidActions(core.White)[0]))
+
+			updateUntil(t, pf, game.Over)
+			assert.Equal(t, core.Outcome{Result: core.WhiteWins, Reason: tt.wantReason}, game.Outcome(),
+				"an AI that can't move forfeits")
+			assert.Len(t, game.Moves, 1)
+		})
+	}
+}
+
+func TestPlayfield_UndoCancelsAITurn(t *testing.T) {
+	strategy := &blockingStrategy{cancelled: make(chan struct{}, 1)}
+	game, err := core.NewGameWithConfigAndPlayers(mustConfig(t), core.NewHumanPlayer(),
+		core.NewAIPlayer("blocker", strategy))
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	defer pf.Close()
+
+	start := game.Board
+	require.NoError(t, game.Play(&game.Board.ValidActions(core.White)[0]))
+	pf.update()
+	require.NotNil(t, pf.aiTurn, "the AI should be thinking")
+
+	pf.undo()
+	<-strategy.cancelled
+	assert.Nil(t, pf.aiTurn)
+	assert.Same(t, start, game.Board, "the human's move should be taken back")
+	assert.Equal(t, core.White, game.ActiveColor)
+
+	// The AI thinks again once the human moves again
+	require.NoError(t, game.Play(&game.Board.ValidActions(core.White)[1]))
+	updateUntil(t, pf, func() bool { return pf.aiTurn != nil })
+	assert.Same(t, game.Board, pf.aiTurn.board, "the AI should plan for the board as it is now")
+}
+
+func TestPlayfield_UndoWithoutHumans(t *testing.T) {
+	game, err := core.NewGameWithConfigAndPlayers(mustConfig(t),
+		core.NewAIPlayer("white", &blockingStrategy{cancelled: make(chan struct{}, 1)}),
+		core.NewAIPlayer("black", &blockingStrategy{cancelled: make(chan struct{}, 1)}))
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	defer pf.Close()
+	for range 3 {
+		require.NoError(t, game.Play(&game.Board.ValidActions(game.ActiveColor)[0]))
+	}
+
+	pf.undo()
+	assert.Len(t, game.Moves, 2, "only the last move is taken back")
+	assert.Equal(t, core.White, game.ActiveColor)
+}
+
+func TestPlayfield_UndoGivesTimeBack(t *testing.T) {
+	strategy := &blockingStrategy{cancelled: make(chan struct{}, 1)}
+	game, err := core.NewGameWithConfigAndPlayers(mustConfig(t), core.NewHumanPlayer(),
+		core.NewAIPlayer("blocker", strategy))
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	defer pf.Close()
+
+	// Each side spends a while on each of its moves, then White thinks about a third
+	pf.timeUsed = make(map[core.Color]time.Duration)
+	for i := range 4 {
+		pf.timeUsed[game.ActiveColor] += time.Duration(i+1) * time.Second
+		require.NoError(t, pf.play(&game.Board.ValidActions(game.ActiveColor)[0]))
+	}
+	pf.timeUsed[core.White] += time.Minute
+
+	pf.undo()
+	require.Len(t, game.Moves, 2, "White's last move and Black's answer are taken back")
+	assert.Equal(t, map[core.Color]time.Duration{core.White: time.Second, core.Black: 2 * time.Second}, pf.timeUsed)
+
+	pf.undo()
+	assert.Empty(t, game.Moves)
+	assert.Zero(t, pf.timeUsed[core.White]+pf.timeUsed[core.Black], "back at the start, no time has been used")
+}
+
+func TestPlayfield_CloseCancelsAITurn(t *testing.T) {
+	strategy := &blockingStrategy{cancelled: make(chan struct{}, 1)}
+	game, err := core.NewGameWithConfigAndPlayers(mustConfig(t), core.NewHumanPlayer(),
+		core.NewAIPlayer("blocker", strategy))
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+
+	require.NoError(t, game.Play(&game.Board.ValidActions(core.White)[0]))
+	pf.update()
+	require.NotNil(t, pf.aiTurn, "the AI should be thinking")
+	pf.Close()
+	select {
+	case <-strategy.cancelled:
+	default:
+		t.Fatal("closing should stop the AI before returning")
+	}
+}
+
+func mustConfig(t *testing.T) *core.GameConfig {
+	cfg, err := core.GetConfig()
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestPlayfield_TimeUsedAndSummary(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	pf.setup = DefaultSetup()
+	start := time.Now()
+
+	// The first frame only starts the clock, then each frame is charged to the side on the move
+	pf.tick(start)
+	pf.tick(start.Add(3 * time.Second))
+	actions := game.Board.ValidActions(core.White)
+	require.NoError(t, game.Play(&actions[0]))
+	pf.tick(start.Add(5 * time.Second))
+	assert.False(t, pf.finished(start.Add(5*time.Second)))
+
+	summary := pf.Summary()
+	assert.Equal(t, DefaultSetup(), summary.Setup)
+	assert.Same(t, game, summary.Game)
+	assert.Equal(t, map[core.Color]time.Duration{core.White: 3 * time.Second, core.Black: 2 * time.Second},
+		summary.TimeUsed)
+	assert.Nil(t, summary.RatingChange)
+}
+
+func TestPlayfield_ResumeStopsTheClockForThePause(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	start := time.Now()
+
+	pf.tick(start)
+	pf.tick(start.Add(2 * time.Second))
+	pf.Resume(nil)
+	pf.tick(start.Add(time.Minute))
+	pf.tick(start.Add(time.Minute + time.Second))
+	assert.Equal(t, 3*time.Second, pf.timeUsed[core.White], "the time spent paused shouldn't be charged")
+}
+
+func TestPlayfield_FinishedAfterFinalPosition(t *testing.T) {
+	game := finishedGame(t)
+	pf := headlessPlayfield(game)
+	start := time.Now()
+
+	pf.tick(start)
+	pf.tick(start.Add(time.Second))
+	assert.Empty(t, pf.timeUsed, "the clock should stop when the game is over")
+
+	assert.False(t, pf.finished(start), "the final position should be shown first")
+	assert.False(t, pf.finished(start.Add(finalPositionTime-time.Millisecond)))
+	assert.True(t, pf.finished(start.Add(finalPositionTime)))
+	assert.Equal(t, start, pf.Summary().Ended)
+}
+
+func TestPlayfield_RunningOutOfTimeLoses(t *testing.T) {
+	blackPlayer, err := ai.NewAIPlayer("doofus", core.Black)
+	require.NoError(t, err)
+	game, err := core.NewGameWithConfigAndPlayers(mustConfig(t), core.NewHumanPlayer(), blackPlayer)
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	defer pf.Close()
+	pf.setup = GameSetup{Black: "doofus", TimeControl: TimeControl{Base: time.Minute, Increment: time.Second}}
+	start := time.Now()
+
+	pf.tick(start)
+	pf.tick(start.Add(50 * time.Second))
+	assert.Equal(t, 10*time.Second, pf.remaining(core.White))
+	actions := game.Board.ValidActions(core.White)
+	require.NoError(t, game.Play(&actions[0]))
+	assert.Equal(t, 11*time.Second, pf.remaining(core.White), "each move earns the increment")
+
+	// Black's AI thinks with a share of its time, and loses when it runs out
+	updateUntil(t, pf, func() bool { return pf.aiTurn != nil })
+	pf.tick(start.Add(50*time.Second + time.Minute))
+	assert.True(t, game.Over())
+	assert.Equal(t, core.Outcome{Result: core.WhiteWins, Reason: "black ran out of time"}, game.Outcome())
+	assert.Nil(t, pf.aiTurn, "the AI's turn should be abandoned")
+}
+
+func TestPlayfield_AIBudget(t *testing.T) {
+	tests := []struct {
+		name   string
+		player string
+		tc     TimeControl
+		want   time.Duration
+	}{
+		{"untimed leaves it to the personality", "doofus", TimeControl{}, 0},
+		{"a share of the time left", "doofus", TimeControl{Base: 5 * time.Minute, Increment: 4 * time.Second},
+			10*time.Second + 2*time.Second},
+		{"no longer than the personality likes", "strategist", TimeControl{Base: time.Hour}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player, err := ai.NewAIPlayer(tt.player, core.White)
+			require.NoError(t, err)
+			game, err := core.NewGameWithConfigAndPlayers(mustConfig(t), player, core.NewHumanPlayer())
+			require.NoError(t, err)
+			pf := headlessPlayfield(game)
+			pf.setup = GameSetup{White: tt.player, TimeControl: tt.tc}
+			assert.Equal(t, tt.want, pf.aiBudget(player))
+		})
+	}
+}
+
+func TestPlayfield_Sounds(t *testing.T) {
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	pf := headlessPlayfield(game)
+	sounds, recorder := newSoundRecorder(t)
+	pf.useSounds(sounds)
+
+	// Only the player on the move's pieces can be picked up
+	black := game.Board.GetPiecesByColor(core.Black)
+	require.NotEmpty(t, black)
+	pf.pickUp(black[0])
+	assert.Empty(t, recorder.played)
+
+	action := game.Board.ValidActions(core.White)[0]
+	pf.pickUp(action.Piece)
+	assert.Equal(t, []string{soundFile(t, "", audio.Select)}, recorder.played)
+
+	require.NoError(t, pf.play(&action))
+	assert.Equal(t, []string{soundFile(t, "", audio.Select), soundFile(t, "", audio.Move)}, recorder.played)
+}
@@ -1,16 +1,106 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
 package scenes
 
+import (
+	"cragspider-go/internal/audio"
+	"cragspider-go/internal/input"
+	"time"
+)
+
+// Scene is one screen of the game, run by a Manager. The manager initializes a scene when it's entered, then each
+// frame updates the scene on top of its stack and draws what's showing, until a scene asks for a change.
 type Scene interface {
+	// Init sets the scene up for a window of the given size.
 	Init(width, height int)
-	Loop() SceneCode
+	// Resize lays the scene out again for a window that has changed size, or gone in or out of fullscreen.
+	Resize(width, height int)
+	// Update moves the scene along to the given time, handling input, and returns what should happen next.
+	Update(now time.Time) Change
+	// Draw draws the scene. The manager has already begun drawing, and scenes draw their own backgrounds rather than
+	// clearing the screen, so that they can be drawn over one another and moved during transitions.
+	Draw()
+	// Close cleans up the scene's resources once it's left.
 	Close()
 }
 
-type SceneCode int
+// Overlay is a scene drawn over the scenes beneath it rather than in place of them, like a pause menu. The scenes
+// beneath are drawn but not updated while it's showing.
+type Overlay interface {
+	Scene
+	overlay()
+}
+
+// Resumer is a scene that wants to know when the scene pushed over it has been popped, and what it left behind.
+type Resumer interface {
+	Resume(result any)
+}
+
+// audible is a scene that plays sounds. The manager hands it the game's sounds before initializing it.
+type audible interface {
+	useSounds(sounds *audio.Manager)
+}
+
+// controlled is a scene that's played with the game's controls. The manager hands them to it before initializing it.
+type controlled interface {
+	useControls(controls *input.Controls)
+}
+
+// Change is what a scene asks the manager to do after a frame. The zero Change stays on the scene.
+type Change struct {
+	op         changeOp
+	scene      Scene
+	result     any
+	transition Transition
+}
+
+// changeOp is how a Change changes the scene stack.
+type changeOp int
+
+const (
+	stayOp changeOp = iota
+	switchOp
+	pushOp
+	popOp
+	quitOp
+)
+
+// Transition is how the screen changes from one scene to the next.
+type Transition int
 
 const (
-	AttractModeScene SceneCode = iota
-	GameplayScene
-	GameOverScene
-	Quit
+	// Cut changes scenes at once.
+	Cut Transition = iota
+	// Fade fades the old scene out to black and the new one in.
+	Fade
+	// Slide slides the new scene in from the right, pushing the old one out to the left.
+	Slide
 )
+
+// Stay keeps the scene going.
+func Stay() Change {
+	return Change{}
+}
+
+// Switch leaves every scene on the stack for the given one, with a transition.
+func Switch(scene Scene, transition Transition) Change {
+	return Change{op: switchOp, scene: scene, transition: transition}
+}
+
+// Push shows the scene over the current one, which waits beneath it until it's popped.
+func Push(scene Scene) Change {
+	return Change{op: pushOp, scene: scene}
+}
+
+// Pop leaves the scene for the one beneath it, handing it the result if it's a Resumer.
+func Pop(result any) Change {
+	return Change{op: popOp, result: result}
+}
+
+// QuitGame leaves every scene and ends the game.
+func QuitGame() Change {
+	return Change{op: quitOp}
+}
+
+// transitionTime is how long a transition between scenes takes.
+const transitionTime = 400 * time.Millisecond
@@ -0,0 +1,142 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"cragspider-go/internal/ai"
+	"cragspider-go/internal/core"
+	"fmt"
+	"time"
+)
+
+// GameSetup is who plays a game, which variant they play, and how.
+type GameSetup struct {
+	Variant     string // Name of the variant; empty plays the standard game
+	White       string // AI personality that plays White, or empty for a human
+	Black       string // AI personality that plays Black, or empty for a human
+	Profile     string // Local profile the human players play and are rated under; empty means the default
+	TimeControl TimeControl
+	Seed        int64          // Seed for the AI players' random choices; zero seeds them from the clock
+	Animation   AnimationSpeed // How fast pieces move across the board
+}
+
+// DefaultSetup returns the setup for an untimed standard game between a human with the default profile playing
+// White and the default opponent, with pieces moving at normal speed.
+func DefaultSetup() GameSetup {
+	return GameSetup{
+		Variant:   core.StandardVariant,
+		Black:     defaultOpponent,
+		Profile:   core.DefaultProfile,
+		Animation: NormalAnimation,
+	}
+}
+
+// Swapped returns the setup with the players changing colors.
+func (s GameSetup) Swapped() GameSetup {
+	s.White, s.Black = s.Black, s.White
+	return s
+}
+
+// config returns the game configuration for the setup's variant.
+func (s GameSetup) config() (*core.GameConfig, error) {
+	if s.Variant == "" {
+		return core.GetConfig()
+	}
+	return core.GetVariantConfig(s.Variant)
+}
+
+// player returns the player the setup has playing the color.
+func (s GameSetup) player(color core.Color) (*core.Player, error) {
+	personality, seed := s.White, 2*s.Seed
+	if color == core.Black {
+		personality, seed = s.Black, 2*s.Seed+1
+	}
+	if personality == "" {
+		return core.NewProfilePlayer(s.Profile), nil
+	}
+	if s.Seed == 0 {
+		return ai.NewAIPlayer(personality, color)
+	}
+	strategy, err := ai.NewSeededStrategy(personality, color, seed)
+	if err != nil {
+		return nil, err
+	}
+	return core.NewAIPlayer(strategy.Config.String(), strategy), nil
+}
+
+// TimeControl is how much time each side has for a game: an allowance to start with, and a bonus for every move
+// they make. A side that runs out of time loses.
+type TimeControl struct {
+	Base      time.Duration
+	Increment time.Duration
+}
+
+// TimeControls are the time controls a game can be set up with, untimed first.
+var TimeControls = []TimeControl{
+	{},
+	{Base: time.Minute},
+	{Base: 3 * time.Minute, Increment: 2 * time.Second},
+	{Base: 5 * time.Minute},
+	{Base: 10 * time.Minute, Increment: 5 * time.Second},
+}
+
+// Untimed returns true if the time control doesn't limit the players' time.
+func (tc TimeControl) Untimed() bool {
+	return tc.Base <= 0
+}
+
+// String returns the time control the way it's usually written: minutes to start with, plus seconds per move.
+func (tc TimeControl) String() string {
+	if tc.Untimed() {
+		return "Untimed"
+	}
+	return fmt.Sprintf("%g+%g", tc.Base.Minutes(), tc.Increment.Seconds())
+}
+
+// Remaining returns how much time a side has left after using used over the given number of moves.
+func (tc TimeControl) Remaining(used time.Duration, moves int) time.Duration {
+	return tc.Base + time.Duration(moves)*tc.Increment - used
+}
+
+// AnimationSpeed is how fast pieces move across the board, as a multiple of the normal speed. Pieces at zero speed
+// jump straight to where they're going.
+type AnimationSpeed float32
+
+const (
+	// NoAnimation moves pieces at once.
+	NoAnimation AnimationSpeed = 0
+	// SlowAnimation moves pieces at half the normal speed.
+	SlowAnimation AnimationSpeed = 0.5
+	// NormalAnimation moves pieces at the normal speed.
+	NormalAnimation AnimationSpeed = 1
+	// FastAnimation moves pieces at twice the normal speed.
+	FastAnimation AnimationSpeed = 2
+)
+
+// AnimationSpeeds are the speeds pieces can be set to move at, with no animation first.
+var AnimationSpeeds = []AnimationSpeed{NoAnimation, SlowAnimation, NormalAnimation, FastAnimation}
+
+// String returns the speed's name.
+func (s AnimationSpeed) String() string {
+	switch s {
+	case NoAnimation:
+		return "Off"
+	case SlowAnimation:
+		return "Slow"
+	case NormalAnimation:
+		return "Normal"
+	case FastAnimation:
+		return "Fast"
+	default:
+		return fmt.Sprintf("%gx", float32(s))
+	}
+}
+
+// scale returns how long something that takes d at normal speed takes at this speed, or zero if there's no
+// animation.
+func (s AnimationSpeed) scale(d time.Duration) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+	return time.Duration(float64(d) / float64(s))
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package scenes
+
+import (
+	"testing"
+	"time"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameSetup_Swapped(t *testing.T) {
+	setup := GameSetup{Variant: "phalanx", White: "grabber", Black: "doofus"}
+	assert.Equal(t, GameSetup{Variant: "phalanx", White: "doofus", Black: "grabber"}, setup.Swapped())
+	assert.Equal(t, setup, setup.Swapped().Swapped())
+}
+
+func TestGameSetup_Players(t *testing.T) {
+	setup := DefaultSetup()
+	white, err := setup.player(core.White)
+	require.NoError(t, err)
+	assert.True(t, white.IsHuman())
+	assert.Equal(t, core.DefaultProfile, white.Name)
+	black, err := setup.player(core.Black)
+	require.NoError(t, err)
+	assert.True(t, black.IsAI())
+
+	_, err = GameSetup{White: "nobody"}.player(core.White)
+	assert.Error(t, err)
+
+	setup.Profile = "Dejah"
+	white, err = setup.Swapped().player(core.Black)
+	require.NoError(t, err)
+	assert.Equal(t, "Dejah", white.Name, "humans play under the setup's profile, whichever color they play")
+}
+
+func TestGameSetup_Config(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant string
+		rows    int
+		wantErr bool
+	}{
+		{"standard when unset", "", 10, false},
+		{"named variant", "skirmish", 8, false},
+		{"unknown variant", "nonesuch", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := GameSetup{Variant: tt.variant}.config()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.rows, cfg.Board.Rows)
+		})
+	}
+}
+
+func TestGameSetup_SeededPlayers(t *testing.T) {
+	setup := GameSetup{White: "doofus", Black: "doofus", Seed: 7}
+	game, err := core.NewGame()
+	require.NoError(t, err)
+
+	// Seeded players make the same choices every time, and each side has a seed of its own
+	moves := func(color core.Color) []*core.Action {
+		player, err := setup.player(color)
+		require.NoError(t, err)
+		var actions []*core.Action
+		for range 5 {
+			action, err := player.Strategy.NextMove(game.Board)
+			require.NoError(t, err)
+			actions = append(actions, action)
+		}
+		return actions
+	}
+	assert.Equal(t, moves(core.White), moves(core.White))
+}
+
+func TestTimeControl(t *testing.T) {
+	tests := []struct {
+		tc      TimeControl
+		untimed bool
+		text    string
+		used    time.Duration
+		moves   int
+		remains time.Duration
+	}{
+		{TimeControl{}, true, "Untimed", 0, 0, 0},
+		{TimeControl{Base: time.Minute}, false, "1+0", 20 * time.Second, 4, 40 * time.Second},
+		{TimeControl{Base: 3 * time.Minute, Increment: 2 * time.Second}, false, "3+2", time.Minute, 5,
+			2*time.Minute + 10*time.Second},
+		{TimeControl{Base: 30 * time.Second}, false, "0.5+0", time.Minute, 0, -30 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.untimed, tt.tc.Untimed())
+			assert.Equal(t, tt.text, tt.tc.String())
+			if !tt.untimed {
+				assert.Equal(t, tt.remains, tt.tc.Remaining(tt.used, tt.moves))
+			}
+		})
+	}
+	assert.True(t, TimeControls[0].Untimed(), "untimed should come first")
+}
+
+func TestAnimationSpeed(t *testing.T) {
+	tests := []struct {
+		speed AnimationSpeed
+		name  string
+		scale time.Duration
+	}{
+		{NoAnimation, "Off", 0},
+		{SlowAnimation, "Slow", 2 * time.Second},
+		{NormalAnimation, "Normal", time.Second},
+		{FastAnimation, "Fast", 500 * time.Millisecond},
+		{AnimationSpeed(3), "3x", time.Second / 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.name, tt.speed.String())
+			assert.Equal(t, tt.scale, tt.speed.scale(time.Second))
+		})
+	}
+}
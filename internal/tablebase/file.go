@@ -0,0 +1,145 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package tablebase
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+)
+
+// A tablebase file is gzipped. Inside, it starts with a magic string and version, then the length of a JSON header
+// that gives the board size and the pieces of each table, then every table's values in the header's order, as
+// little-endian int16s.
+const (
+	magic         = "CRAGTB"
+	formatVersion = 1
+)
+
+type fileHeader struct {
+	Rows    int           `json:"rows"`
+	Columns int           `json:"columns"`
+	Tables  []tableHeader `json:"tables"`
+}
+
+type tableHeader struct {
+	Pieces []Piece `json:"pieces"`
+}
+
+// Write writes the tablebase in its compact file format.
+func (tb *Tablebase) Write(w io.Writer) error {
+	header := fileHeader{Rows: tb.Rows, Columns: tb.Columns}
+	names := tb.Tables()
+	for _, name := range names {
+		header.Tables = append(header.Tables, tableHeader{Pieces: tb.tables[name].pieces})
+	}
+	data, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tablebase header: %w", err)
+	}
+
+	zw := gzip.NewWriter(w)
+	bw := bufio.NewWriter(zw)
+	if _, err := bw.WriteString(magic); err != nil {
+		return fmt.Errorf("failed to write tablebase: %w", err)
+	}
+	if err := bw.WriteByte(formatVersion); err != nil {
+		return fmt.Errorf("failed to write tablebase: %w", err)
+	}
+	if err := binary.Write(bw, binary.LittleEndian, uint32(len(data))); err != nil {
+		return fmt.Errorf("failed to write tablebase: %w", err)
+	}
+	if _, err := bw.Write(data); err != nil {
+		return fmt.Errorf("failed to write tablebase: %w", err)
+	}
+	for _, name := range names {
+		if err := binary.Write(bw, binary.LittleEndian, tb.tables[name].values); err != nil {
+			return fmt.Errorf("failed to write tablebase: %w", err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write tablebase: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write tablebase: %w", err)
+	}
+	return nil
+}
+
+// Read reads a tablebase written by Write.
+func Read(r io.Reader) (*Tablebase, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a tablebase: %w", err)
+	}
+	defer zr.Close()
+	br := bufio.NewReader(zr)
+
+	prefix := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(br, prefix); err != nil || string(prefix[:len(magic)]) != magic {
+		return nil, fmt.Errorf("not a tablebase")
+	}
+	if prefix[len(magic)] != formatVersion {
+		return nil, fmt.Errorf("unsupported tablebase version %d", prefix[len(magic)])
+	}
+	var length uint32
+	if err := binary.Read(br, binary.LittleEndian, &length); err != nil {
+		return nil, fmt.Errorf("failed to read tablebase header: %w", err)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(br, data); err != nil {
+		return nil, fmt.Errorf("failed to read tablebase header: %w", err)
+	}
+	var header fileHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tablebase header: %w", err)
+	}
+	squares := header.Rows * header.Columns
+	if squares <= 0 {
+		return nil, fmt.Errorf("invalid tablebase board size %dx%d", header.Rows, header.Columns)
+	}
+
+	tb := &Tablebase{Rows: header.Rows, Columns: header.Columns, tables: make(map[string]*table)}
+	for _, th := range header.Tables {
+		if len(th.Pieces) > MaxPieces || !slices.IsSortedFunc(th.Pieces, comparePieces) {
+			return nil, fmt.Errorf("invalid tablebase table %s", signature(th.Pieces))
+		}
+		t := &table{pieces: th.Pieces, values: make([]int16, 2*pow(squares, len(th.Pieces)))}
+		if err := binary.Read(br, binary.LittleEndian, t.values); err != nil {
+			return nil, fmt.Errorf("failed to read tablebase values: %w", err)
+		}
+		tb.tables[signature(t.pieces)] = t
+	}
+	return tb, nil
+}
+
+// Load reads a tablebase from the named file.
+func Load(path string) (*Tablebase, error) {
+	f, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tablebase: %w", err)
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Save writes the tablebase to the named file.
+func (tb *Tablebase) Save(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to create tablebase: %w", err)
+	}
+	if err := tb.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write tablebase: %w", err)
+	}
+	return nil
+}
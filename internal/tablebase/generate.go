@@ -0,0 +1,259 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package tablebase
+
+import (
+	"cragspider-go/internal/core"
+	"fmt"
+	"slices"
+)
+
+// maxEntries is the most values one table may hold, which keeps a table to 128MB. Four pieces fit comfortably on
+// the small boards of most variants, but not on the standard 10x10 board.
+const maxEntries = 1 << 26
+
+// Generate solves the ending with the given pieces on the game configuration's board, along with every ending it
+// can turn into by captures. It works backwards from the positions the victory rules in core say are lost, where the
+// side to move has no valid move: a position is won if some move leads to a lost one, and lost if every move leads
+// to a won one, with the distance to the end of the game counted in plies. Whatever is never won or lost is a draw.
+// The game's move limit is left out, since it depends on how the game got there. Progress, if given, is called as
+// each ending is solved.
+func Generate(cfg *core.GameConfig, material []Piece, progress func(ending string, positions int)) (*Tablebase, error) {
+	if len(material) == 0 || len(material) > MaxPieces {
+		return nil, fmt.Errorf("a tablebase needs 1 to %d pieces, not %d", MaxPieces, len(material))
+	}
+	g, err := newGenerator(cfg)
+	if err != nil {
+		return nil, err
+	}
+	material = slices.Clone(material)
+	for _, p := range material {
+		if _, ok := g.paths[p.Name]; !ok {
+			return nil, fmt.Errorf("unknown piece '%s'", p.Name)
+		}
+		if p.Color != core.White && p.Color != core.Black {
+			return nil, fmt.Errorf("invalid color '%s' for %s", p.Color, p.Name)
+		}
+	}
+	slices.SortFunc(material, comparePieces)
+	if entries := 2 * pow(g.squares, len(material)); entries > maxEntries {
+		return nil, fmt.Errorf("%s has %d positions on a %dx%d board, more than the %d a tablebase can hold",
+			signature(material), entries, g.rows, g.cols, maxEntries)
+	}
+
+	tb := &Tablebase{Rows: g.rows, Columns: g.cols, tables: make(map[string]*table)}
+	if err := g.solveAll(tb, material, progress); err != nil {
+		return nil, err
+	}
+	return tb, nil
+}
+
+// generator generates moves for pieces on squares numbered row by row, without building boards.
+type generator struct {
+	rows, cols, squares int
+	paths               map[string][][][]int // Piece name, then starting square, then the squares along each path
+}
+
+// newGenerator works out the squares along every path of every piece from every square of the board.
+func newGenerator(cfg *core.GameConfig) (*generator, error) {
+	g := &generator{rows: cfg.Board.Rows, cols: cfg.Board.Columns, paths: make(map[string][][][]int)}
+	g.squares = g.rows * g.cols
+	if g.squares <= 0 {
+		return nil, fmt.Errorf("invalid board size %dx%d", g.rows, g.cols)
+	}
+	for _, pc := range cfg.Pieces {
+		bySquare := make([][][]int, g.squares)
+		for square := range g.squares {
+			for _, path := range pc.Moves {
+				pos := core.Position{square / g.cols, square % g.cols}
+				var squares []int
+				for _, delta := range path {
+					pos = pos.Add(delta)
+					if pos[0] < 0 || pos[0] >= g.rows || pos[1] < 0 || pos[1] >= g.cols {
+						break
+					}
+					squares = append(squares, pos[0]*g.cols+pos[1])
+				}
+				if len(squares) > 0 {
+					bySquare[square] = append(bySquare[square], squares)
+				}
+			}
+		}
+		g.paths[pc.Name] = bySquare
+	}
+	return g, nil
+}
+
+// solveAll solves the endings the pieces can turn into by captures, smallest first, and then the pieces' own.
+func (g *generator) solveAll(tb *Tablebase, pieces []Piece, progress func(string, int)) error {
+	name := signature(pieces)
+	if _, ok := tb.tables[name]; ok {
+		return nil
+	}
+	for i := range pieces {
+		if i > 0 && pieces[i] == pieces[i-1] {
+			continue
+		}
+		if err := g.solveAll(tb, slices.Delete(slices.Clone(pieces), i, i+1), progress); err != nil {
+			return err
+		}
+	}
+	tb.tables[name] = g.solve(tb, pieces)
+	if progress != nil {
+		progress(name, len(tb.tables[name].values))
+	}
+	return nil
+}
+
+// capture is the ending left when the piece in one slot of a table is captured.
+type capture struct {
+	table   *table
+	weights []int // Weight of each of the remaining slots' squares in the smaller table's index
+}
+
+// solve solves one ending, whose smaller endings must already be in the tablebase.
+func (g *generator) solve(tb *Tablebase, pieces []Piece) *table {
+	n := len(pieces)
+	t := &table{pieces: pieces, values: make([]int16, 2*pow(g.squares, n))}
+	weights := make([]int, n)
+	for i := range n {
+		weights[i] = pow(g.squares, n-1-i)
+	}
+
+	// Once the ending runs out of new results, the only ones left to find are those that wait on long wins and
+	// losses in the smaller endings.
+	captures := make([]capture, n)
+	longest := 0
+	for j := range n {
+		rest := slices.Delete(slices.Clone(pieces), j, j+1)
+		c := capture{table: tb.tables[signature(rest)], weights: make([]int, n)}
+		for i := range n {
+			switch {
+			case i < j:
+				c.weights[i] = pow(g.squares, n-2-i)
+			case i > j:
+				c.weights[i] = pow(g.squares, n-1-i)
+			}
+		}
+		captures[j] = c
+		for _, v := range c.table.values {
+			longest = max(longest, decode(v).Distance)
+		}
+	}
+
+	squares := make([]int, n)
+	occupant := make([]int, g.squares) // Slot of the piece on each square, plus one; zero is empty
+	for pass := 0; ; pass++ {
+		changed := false
+		for index := range len(t.values) / 2 {
+			if !g.place(index, squares, occupant) {
+				continue
+			}
+			for side, color := range []core.Color{core.White, core.Black} {
+				at := 2*index + side
+				if t.values[at] != 0 {
+					continue
+				}
+				if v := g.evaluate(t, captures, pieces, weights, squares, occupant, index, color, pass); v != 0 {
+					t.values[at] = v
+					changed = true
+				}
+			}
+			for _, square := range squares {
+				occupant[square] = 0
+			}
+		}
+		if !changed && pass > longest {
+			return t
+		}
+	}
+}
+
+// place decodes an index into the squares of the pieces and marks them on the occupant board. It returns false, and
+// marks nothing, if two pieces are on the same square.
+func (g *generator) place(index int, squares, occupant []int) bool {
+	for i := len(squares) - 1; i >= 0; i-- {
+		squares[i] = index % g.squares
+		index /= g.squares
+	}
+	for i, square := range squares {
+		if occupant[square] != 0 {
+			for _, s := range squares[:i] {
+				occupant[s] = 0
+			}
+			return false
+		}
+		occupant[square] = i + 1
+	}
+	return true
+}
+
+// evaluate returns the value for color to move in the position, if it can be decided on this pass, or zero. On pass
+// zero only positions where color has no moves are lost; after that, a position is won or lost in pass plies once
+// the positions its moves lead to are all known well enough to say so.
+func (g *generator) evaluate(t *table, captures []capture, pieces []Piece, weights, squares, occupant []int,
+	index int, color core.Color, pass int) int16 {
+	other := 1 - colorIndex(color)
+	hasMoves, allWins := false, true
+	longestLoss := 0
+	for i, p := range pieces {
+		if p.Color != color {
+			continue
+		}
+		for _, path := range g.paths[p.Name][squares[i]] {
+			for _, to := range path {
+				occupied := occupant[to]
+				if occupied != 0 && pieces[occupied-1].Color == color {
+					break
+				}
+				var v int16
+				if occupied == 0 {
+					v = t.values[2*(index+(to-squares[i])*weights[i])+other]
+				} else {
+					c := captures[occupied-1]
+					child := 0
+					for k, square := range squares {
+						if k == i {
+							square = to
+						}
+						child += square * c.weights[k]
+					}
+					v = c.table.values[2*child+other]
+				}
+				hasMoves = true
+				// The child's value is for the opponent, who moves next
+				result := decode(v)
+				switch result.WDL {
+				case Loss:
+					if result.Distance+1 <= pass {
+						return winValue(result.Distance + 1)
+					}
+					allWins = false
+				case Win:
+					longestLoss = max(longestLoss, result.Distance+1)
+				default:
+					allWins = false
+				}
+				if occupied != 0 {
+					break
+				}
+			}
+		}
+	}
+	switch {
+	case !hasMoves:
+		return lossValue(0)
+	case allWins && longestLoss <= pass:
+		return lossValue(longestLoss)
+	}
+	return 0
+}
+
+// pow returns base to the power of exp.
+func pow(base, exp int) int {
+	result := 1
+	for range exp {
+		result *= base
+	}
+	return result
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Package tablebase solves endings with only a few pieces left. Every position with the given pieces is worked out
+// to a win, loss or draw for the side to move, and how many moves it takes, so that search bots can play those
+// endings perfectly rather than by guesswork.
+package tablebase
+
+import (
+	"cmp"
+	"cragspider-go/internal/core"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxPieces is the most pieces a tablebase can have. Each extra piece multiplies the number of positions by the
+// number of squares on the board.
+const MaxPieces = 4
+
+// Piece is one of the pieces in an ending.
+type Piece struct {
+	Name  string     `json:"name"`
+	Color core.Color `json:"color"`
+}
+
+// String returns a nicely formatted string representation of the piece.
+func (p Piece) String() string {
+	return fmt.Sprintf("%s %s", p.Color, p.Name)
+}
+
+// comparePieces orders pieces White first, then by name.
+func comparePieces(a, b Piece) int {
+	return cmp.Or(cmp.Compare(colorIndex(a.Color), colorIndex(b.Color)), cmp.Compare(a.Name, b.Name))
+}
+
+// signature returns the name of the table for a set of pieces, which must already be in order.
+func signature(pieces []Piece) string {
+	names := make([]string, len(pieces))
+	for i, p := range pieces {
+		names[i] = p.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// colorIndex returns 0 for White and 1 for Black.
+func colorIndex(color core.Color) int {
+	if color == core.Black {
+		return 1
+	}
+	return 0
+}
+
+// WDL is whether the side to move wins, loses or draws with perfect play.
+type WDL int
+
+const (
+	Loss WDL = -1
+	Draw WDL = 0
+	Win  WDL = 1
+)
+
+// String returns a nicely formatted string representation of the result.
+func (w WDL) String() string {
+	switch w {
+	case Win:
+		return "win"
+	case Loss:
+		return "loss"
+	default:
+		return "draw"
+	}
+}
+
+// Entry is what the tablebase knows about a position: whether the side to move wins, loses or draws, and in how
+// many plies the game ends with perfect play on both sides. Draws go on forever, so they have no distance.
+type Entry struct {
+	WDL      WDL
+	Distance int
+}
+
+// String returns a nicely formatted string representation of the entry.
+func (e Entry) String() string {
+	if e.WDL == Draw {
+		return "draw"
+	}
+	return fmt.Sprintf("%s in %d", e.WDL, e.Distance)
+}
+
+// Values are stored as int16: zero is a draw, a positive number d is a win in d plies, and a negative number -d is
+// a loss in d-1 plies, so that a side with no moves, which has lost already, is -1.
+func decode(v int16) Entry {
+	switch {
+	case v > 0:
+		return Entry{WDL: Win, Distance: int(v)}
+	case v < 0:
+		return Entry{WDL: Loss, Distance: int(-v) - 1}
+	default:
+		return Entry{WDL: Draw}
+	}
+}
+
+func winValue(distance int) int16  { return int16(distance) }
+func lossValue(distance int) int16 { return int16(-distance - 1) }
+
+// table holds the solution for one set of pieces. Positions are numbered by the squares of the pieces, in the
+// table's piece order, as digits of a number in base rows × columns; each has a value with White to move followed
+// by one with Black to move.
+type table struct {
+	pieces []Piece
+	values []int16
+}
+
+// Tablebase is the solution to an ending and every ending it can turn into by captures, on a board of a given size.
+type Tablebase struct {
+	Rows, Columns int
+	tables        map[string]*table
+}
+
+// Tables returns the names of the endings the tablebase has solved, in order.
+func (tb *Tablebase) Tables() []string {
+	names := make([]string, 0, len(tb.tables))
+	for name := range tb.tables {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Probe returns what the tablebase knows about the board with the specified color to move, or false if the board
+// isn't the right size or the pieces on it aren't an ending the tablebase has solved.
+func (tb *Tablebase) Probe(board *core.Board, toMove core.Color) (Entry, bool) {
+	if board.Rows != tb.Rows || board.Columns != tb.Columns {
+		return Entry{}, false
+	}
+	type placed struct {
+		piece  Piece
+		square int
+	}
+	var on []placed
+	for row := range board.Rows {
+		for col := range board.Columns {
+			if p := board.GetPieceAt(core.Position{row, col}); p != nil {
+				if len(on) == MaxPieces {
+					return Entry{}, false
+				}
+				on = append(on, placed{Piece{Name: p.Name, Color: p.Color}, row*board.Columns + col})
+			}
+		}
+	}
+	slices.SortStableFunc(on, func(a, b placed) int { return comparePieces(a.piece, b.piece) })
+
+	pieces := make([]Piece, len(on))
+	for i, p := range on {
+		pieces[i] = p.piece
+	}
+	t, ok := tb.tables[signature(pieces)]
+	if !ok {
+		return Entry{}, false
+	}
+	index := 0
+	squares := board.Rows * board.Columns
+	for _, p := range on {
+		index = index*squares + p.square
+	}
+	return decode(t.values[2*index+colorIndex(toMove)]), true
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package tablebase
+
+import (
+	"bytes"
+	"testing"
+
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/core/coretest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// boardWith returns a board of the configuration's size with just the given pieces on it.
+func boardWith(t *testing.T, cfg *core.GameConfig, pieces []Piece, squares []int) *core.Board {
+	t.Helper()
+	c := *cfg
+	c.Board.White, c.Board.Black = nil, nil
+	for i, p := range pieces {
+		square := core.Position{squares[i] / cfg.Board.Columns, squares[i] % cfg.Board.Columns}
+		pos := core.BoardPosition{Name: p.Name, Position: square}
+		if p.Color == core.White {
+			c.Board.White = append(c.Board.White, pos)
+		} else {
+			c.Board.Black = append(c.Board.Black, pos)
+		}
+	}
+	game, err := core.NewGameWithConfig(&c)
+	require.NoError(t, err)
+	return game.Board
+}
+
+var (
+	whiteWarrior = Piece{Name: "warrior", Color: core.White}
+	blackWarrior = Piece{Name: "warrior", Color: core.Black}
+	whitePadwar  = Piece{Name: "padwar", Color: core.White}
+	blackPadwar  = Piece{Name: "padwar", Color: core.Black}
+)
+
+func TestGenerate_KnownPositions(t *testing.T) {
+	cfg := coretest.SmallConfig(t, 4, 4)
+	tb, err := Generate(cfg, []Piece{blackWarrior, whiteWarrior}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "black warrior", "white warrior", "white warrior, black warrior"}, tb.Tables())
+
+	tests := []struct {
+		name     string
+		pieces   []Piece
+		squares  []int
+		toMove   core.Color
+		expected Entry
+	}{
+		{
+			name:     "no pieces left loses at once",
+			pieces:   []Piece{whiteWarrior},
+			squares:  []int{5},
+			toMove:   core.Black,
+			expected: Entry{WDL: Loss, Distance: 0},
+		},
+		{
+			name:     "opponent has no pieces left",
+			pieces:   []Piece{whiteWarrior},
+			squares:  []int{5},
+			toMove:   core.White,
+			expected: Entry{WDL: Win, Distance: 1},
+		},
+		{
+			name:     "capture wins",
+			pieces:   []Piece{whiteWarrior, blackWarrior},
+			squares:  []int{0, 2},
+			toMove:   core.White,
+			expected: Entry{WDL: Win, Distance: 1},
+		},
+		{
+			name:     "capture wins for black too",
+			pieces:   []Piece{whiteWarrior, blackWarrior},
+			squares:  []int{0, 8},
+			toMove:   core.Black,
+			expected: Entry{WDL: Win, Distance: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, ok := tb.Probe(boardWith(t, cfg, tt.pieces, tt.squares), tt.toMove)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, entry)
+		})
+	}
+}
+
+// TestGenerate_AgreesWithCore checks every position of an ending against the rules in core: positions core says
+// are over are lost, a won position has a move to a position lost one ply sooner, a lost one has only moves to
+// positions won at most one ply sooner, and a drawn one has no move to a lost position but some move to a draw.
+func TestGenerate_AgreesWithCore(t *testing.T) {
+	cfg := coretest.SmallConfig(t, 4, 4)
+	pieces := []Piece{whiteWarrior, whitePadwar, blackWarrior}
+	tb, err := Generate(cfg, pieces, nil)
+	require.NoError(t, err)
+	assert.Len(t, tb.Tables(), 8)
+
+	wdl := map[WDL]int{}
+	squares := make([]int, len(pieces))
+	for index := range 16 * 16 * 16 {
+		squares[0], squares[1], squares[2] = index/256, index/16%16, index%16
+		if squares[0] == squares[1] || squares[0] == squares[2] || squares[1] == squares[2] {
+			continue
+		}
+		board := boardWith(t, cfg, pieces, squares)
+		for _, toMove := range []core.Color{core.White, core.Black} {
+			entry, ok := tb.Probe(board, toMove)
+			require.True(t, ok)
+			wdl[entry.WDL]++
+			assert.Equal(t, board.Judge(toMove).Over(), entry == Entry{WDL: Loss, Distance: 0},
+				"squares %v, %s to move: %s", squares, toMove, entry)
+
+			var children []Entry
+			for _, action := range board.ValidActions(toMove) {
+				child, err := board.ApplyAction(&action)
+				require.NoError(t, err)
+				childEntry, ok := tb.Probe(child, toMove.Opponent())
+				require.True(t, ok)
+				children = append(children, childEntry)
+			}
+			switch entry.WDL {
+			case Win:
+				assert.Contains(t, children, Entry{WDL: Loss, Distance: entry.Distance - 1})
+				for _, c := range children {
+					if c.WDL == Loss {
+						assert.GreaterOrEqual(t, c.Distance, entry.Distance-1)
+					}
+				}
+			case Loss:
+				for _, c := range children {
+					assert.Equal(t, Win, c.WDL)
+					assert.LessOrEqual(t, c.Distance, entry.Distance-1)
+				}
+				if entry.Distance > 0 {
+					assert.Contains(t, children, Entry{WDL: Win, Distance: entry.Distance - 1})
+				}
+			case Draw:
+				assert.NotEmpty(t, children)
+				assert.Contains(t, children, Entry{WDL: Draw})
+				for _, c := range children {
+					assert.NotEqual(t, Loss, c.WDL)
+				}
+			}
+		}
+	}
+	assert.Positive(t, wdl[Win])
+	assert.Positive(t, wdl[Loss])
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	cfg := coretest.SmallConfig(t, 4, 4)
+	_, err := Generate(cfg, nil, nil)
+	assert.Error(t, err)
+	_, err = Generate(cfg, []Piece{whiteWarrior, whiteWarrior, blackWarrior, blackWarrior, blackPadwar}, nil)
+	assert.Error(t, err)
+	_, err = Generate(cfg, []Piece{{Name: "dragon", Color: core.White}}, nil)
+	assert.ErrorContains(t, err, "unknown piece")
+	_, err = Generate(coretest.SmallConfig(t, 10, 10), []Piece{whiteWarrior, whitePadwar, blackWarrior, blackPadwar}, nil)
+	assert.ErrorContains(t, err, "more than")
+}
+
+func TestTablebase_Probe(t *testing.T) {
+	cfg := coretest.SmallConfig(t, 4, 4)
+	tb, err := Generate(cfg, []Piece{whiteWarrior, blackWarrior, blackWarrior}, nil)
+	require.NoError(t, err)
+
+	// Pieces of the same kind can be in either order
+	pieces := []Piece{whiteWarrior, blackWarrior, blackWarrior}
+	a, ok := tb.Probe(boardWith(t, cfg, pieces, []int{0, 5, 15}), core.White)
+	require.True(t, ok)
+	b, ok := tb.Probe(boardWith(t, cfg, pieces, []int{0, 15, 5}), core.White)
+	require.True(t, ok)
+	assert.Equal(t, a, b)
+
+	_, ok = tb.Probe(boardWith(t, cfg, []Piece{whitePadwar, blackWarrior}, []int{0, 5}), core.White)
+	assert.False(t, ok, "ending not in the tablebase")
+	_, ok = tb.Probe(boardWith(t, coretest.SmallConfig(t, 5, 5), []Piece{whiteWarrior}, []int{0}), core.White)
+	assert.False(t, ok, "board of another size")
+}
+
+func TestTablebase_WriteRead(t *testing.T) {
+	tb, err := Generate(coretest.SmallConfig(t, 4, 4), []Piece{whiteWarrior, blackPadwar}, nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tb.Write(&buf))
+	read, err := Read(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, tb, read)
+
+	_, err = Read(bytes.NewReader([]byte("not a tablebase")))
+	assert.Error(t, err)
+}
+
+func TestTablebase_SaveLoad(t *testing.T) {
+	tb, err := Generate(coretest.SmallConfig(t, 3, 3), []Piece{whiteWarrior, blackWarrior}, nil)
+	require.NoError(t, err)
+	path := t.TempDir() + "/endings.tb"
+	require.NoError(t, tb.Save(path))
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, tb, loaded)
+
+	_, err = Load(t.TempDir() + "/missing.tb")
+	assert.Error(t, err)
+}
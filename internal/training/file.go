@@ -0,0 +1,280 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package training
+
+import (
+	"bufio"
+	"compress/gzip"
+	"cragspider-go/internal/core"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Format is a way of writing examples to a file.
+type Format string
+
+const (
+	// CSV writes a header row naming the columns, then a row per example: whether White is to move, the result,
+	// the score (empty if there isn't one), then the tensor's values.
+	CSV Format = "csv"
+	// Binary writes examples compactly, with the tensor packed a bit per square, for Reader to read back.
+	Binary Format = "bin"
+)
+
+// Writer writes examples to a file in one of the formats. Close must be called to finish the file, but doesn't
+// close the underlying writer.
+type Writer interface {
+	Write(example Example) error
+	Close() error
+}
+
+// NewWriter returns a writer of examples encoded by the encoder, in the given format.
+func NewWriter(format Format, w io.Writer, encoder *Encoder) (Writer, error) {
+	switch format {
+	case CSV:
+		return NewCSVWriter(w, encoder)
+	case Binary:
+		return NewBinaryWriter(w, encoder)
+	default:
+		return nil, fmt.Errorf("unknown training data format '%s'", format)
+	}
+}
+
+// CSVWriter writes examples as CSV.
+type CSVWriter struct {
+	w       *csv.Writer
+	encoder *Encoder
+	record  []string
+}
+
+// NewCSVWriter returns a writer of examples as CSV, and writes the header row.
+func NewCSVWriter(w io.Writer, encoder *Encoder) (*CSVWriter, error) {
+	header := []string{"white_to_move", "result", "score"}
+	for plane := range encoder.Planes() {
+		color, piece := encoder.Plane(plane)
+		for row := range encoder.Rows {
+			for column := range encoder.Columns {
+				header = append(header, fmt.Sprintf("%s_%s_%d_%d", color, piece, row, column))
+			}
+		}
+	}
+	cw := &CSVWriter{w: csv.NewWriter(w), encoder: encoder, record: make([]string, len(header))}
+	if err := cw.w.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write training data: %w", err)
+	}
+	return cw, nil
+}
+
+// Write writes one example as a row.
+func (cw *CSVWriter) Write(example Example) error {
+	if len(example.Board) != cw.encoder.Size() {
+		return fmt.Errorf("example has %d values, expected %d", len(example.Board), cw.encoder.Size())
+	}
+	cw.record[0] = boolString(example.ToMove == core.White)
+	cw.record[1] = strconv.FormatFloat(float64(example.Result), 'g', -1, 32)
+	cw.record[2] = ""
+	if example.HasScore {
+		cw.record[2] = strconv.FormatFloat(float64(example.Score), 'g', -1, 32)
+	}
+	for i, value := range example.Board {
+		cw.record[3+i] = strconv.Itoa(int(value))
+	}
+	if err := cw.w.Write(cw.record); err != nil {
+		return fmt.Errorf("failed to write training data: %w", err)
+	}
+	return nil
+}
+
+// Close flushes the rows written so far.
+func (cw *CSVWriter) Close() error {
+	cw.w.Flush()
+	if err := cw.w.Error(); err != nil {
+		return fmt.Errorf("failed to write training data: %w", err)
+	}
+	return nil
+}
+
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// A binary file is gzipped. Inside, it starts with a magic string and version, then the length of a JSON header
+// that gives the shape of the tensors, then the examples. Each example is a byte of flags, a byte for the result
+// in half points, the score as a little-endian float32, then the tensor a bit per value, lowest bit first.
+const (
+	magic         = "CRAGTD"
+	formatVersion = 1
+
+	blackToMove = 1 << 0
+	hasScore    = 1 << 1
+)
+
+type fileHeader struct {
+	Rows    int      `json:"rows"`
+	Columns int      `json:"columns"`
+	Pieces  []string `json:"pieces"`
+}
+
+// BinaryWriter writes examples in the compact binary format.
+type BinaryWriter struct {
+	zw      *gzip.Writer
+	bw      *bufio.Writer
+	encoder *Encoder
+	record  []byte
+}
+
+// NewBinaryWriter returns a writer of examples in the compact binary format, and writes the file's header.
+func NewBinaryWriter(w io.Writer, encoder *Encoder) (*BinaryWriter, error) {
+	data, err := json.Marshal(fileHeader{Rows: encoder.Rows, Columns: encoder.Columns, Pieces: encoder.Pieces})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal training data header: %w", err)
+	}
+	zw := gzip.NewWriter(w)
+	bw := bufio.NewWriter(zw)
+	header := append([]byte(magic), formatVersion)
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(data)))
+	if _, err := bw.Write(append(header, data...)); err != nil {
+		return nil, fmt.Errorf("failed to write training data: %w", err)
+	}
+	return &BinaryWriter{zw: zw, bw: bw, encoder: encoder, record: make([]byte, recordSize(encoder))}, nil
+}
+
+// recordSize returns the number of bytes each example takes up in a binary file.
+func recordSize(encoder *Encoder) int {
+	return 6 + (encoder.Size()+7)/8
+}
+
+// Write writes one example.
+func (bw *BinaryWriter) Write(example Example) error {
+	if len(example.Board) != bw.encoder.Size() {
+		return fmt.Errorf("example has %d values, expected %d", len(example.Board), bw.encoder.Size())
+	}
+	clear(bw.record)
+	if example.ToMove == core.Black {
+		bw.record[0] |= blackToMove
+	}
+	if example.HasScore {
+		bw.record[0] |= hasScore
+		binary.LittleEndian.PutUint32(bw.record[2:], math.Float32bits(example.Score))
+	}
+	bw.record[1] = byte(math.Round(float64(example.Result) * 2))
+	for i, value := range example.Board {
+		if value != 0 {
+			bw.record[6+i/8] |= 1 << (i % 8)
+		}
+	}
+	if _, err := bw.bw.Write(bw.record); err != nil {
+		return fmt.Errorf("failed to write training data: %w", err)
+	}
+	return nil
+}
+
+// Close flushes the examples written so far and finishes the file.
+func (bw *BinaryWriter) Close() error {
+	if err := bw.bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write training data: %w", err)
+	}
+	if err := bw.zw.Close(); err != nil {
+		return fmt.Errorf("failed to write training data: %w", err)
+	}
+	return nil
+}
+
+// Reader reads examples from a file in the binary format.
+type Reader struct {
+	Encoder *Encoder // Describes the shape of the file's tensors
+	zr      *gzip.Reader
+	br      *bufio.Reader
+	record  []byte
+}
+
+// NewReader returns a reader of the examples in a binary file, having read the file's header.
+func NewReader(r io.Reader) (*Reader, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a training data file: %w", err)
+	}
+	br := bufio.NewReader(zr)
+
+	prefix := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(br, prefix); err != nil || string(prefix[:len(magic)]) != magic {
+		return nil, fmt.Errorf("not a training data file")
+	}
+	if prefix[len(magic)] != formatVersion {
+		return nil, fmt.Errorf("unsupported training data version %d", prefix[len(magic)])
+	}
+	var length uint32
+	if err := binary.Read(br, binary.LittleEndian, &length); err != nil {
+		return nil, fmt.Errorf("failed to read training data header: %w", err)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(br, data); err != nil {
+		return nil, fmt.Errorf("failed to read training data header: %w", err)
+	}
+	var header fileHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal training data header: %w", err)
+	}
+	if header.Rows <= 0 || header.Columns <= 0 || len(header.Pieces) == 0 {
+		return nil, fmt.Errorf("invalid training data shape %dx%d with %d pieces",
+			header.Rows, header.Columns, len(header.Pieces))
+	}
+
+	encoder := newEncoder(header.Rows, header.Columns, header.Pieces)
+	return &Reader{Encoder: encoder, zr: zr, br: br, record: make([]byte, recordSize(encoder))}, nil
+}
+
+// Read returns the next example, or io.EOF when there are no more.
+func (r *Reader) Read() (Example, error) {
+	if _, err := io.ReadFull(r.br, r.record); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Example{}, io.EOF
+		}
+		return Example{}, fmt.Errorf("failed to read training data: %w", err)
+	}
+	example := Example{
+		Board:  make([]uint8, r.Encoder.Size()),
+		ToMove: core.White,
+		Result: float32(r.record[1]) / 2,
+	}
+	if r.record[0]&blackToMove != 0 {
+		example.ToMove = core.Black
+	}
+	if r.record[0]&hasScore != 0 {
+		example.Score = math.Float32frombits(binary.LittleEndian.Uint32(r.record[2:]))
+		example.HasScore = true
+	}
+	for i := range example.Board {
+		example.Board[i] = (r.record[6+i/8] >> (i % 8)) & 1
+	}
+	return example, nil
+}
+
+// ReadAll returns every example left in the file.
+func (r *Reader) ReadAll() ([]Example, error) {
+	var examples []Example
+	for {
+		example, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return examples, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		examples = append(examples, example)
+	}
+}
+
+// Close closes the reader, but not the underlying reader.
+func (r *Reader) Close() error {
+	return r.zr.Close()
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package training
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"io"
+	"testing"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testExamples returns examples on a 3x3 board with warriors and padwars.
+func testExamples(t *testing.T) (*Encoder, []Example) {
+	encoder := newEncoder(3, 3, []string{"warrior", "padwar"})
+	tensor := func(squares ...int) []uint8 {
+		board := make([]uint8, encoder.Size())
+		for _, i := range squares {
+			board[i] = 1
+		}
+		return board
+	}
+	return encoder, []Example{
+		{Board: tensor(0, 13, 35), ToMove: core.White, Result: 1, Score: 2.5, HasScore: true},
+		{Board: tensor(8, 9, 27), ToMove: core.Black, Result: 0.5},
+		{Board: tensor(), ToMove: core.Black, Result: 0, Score: -100000, HasScore: true},
+	}
+}
+
+func TestBinary_RoundTrip(t *testing.T) {
+	encoder, examples := testExamples(t)
+	var buf bytes.Buffer
+	w, err := NewWriter(Binary, &buf, encoder)
+	require.NoError(t, err)
+	for _, example := range examples {
+		require.NoError(t, w.Write(example))
+	}
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(&buf)
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, encoder.Rows, r.Encoder.Rows)
+	assert.Equal(t, encoder.Columns, r.Encoder.Columns)
+	assert.Equal(t, encoder.Pieces, r.Encoder.Pieces)
+	read, err := r.ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, examples, read)
+	_, err = r.Read()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestBinary_Errors(t *testing.T) {
+	encoder, _ := testExamples(t)
+	w, err := NewBinaryWriter(io.Discard, encoder)
+	require.NoError(t, err)
+	assert.Error(t, w.Write(Example{Board: make([]uint8, 3)}), "tensors must be the encoder's size")
+
+	_, err = NewReader(bytes.NewReader([]byte("not gzipped")))
+	assert.Error(t, err)
+
+	// A file with no examples, then one cut off partway through an example
+	var buf bytes.Buffer
+	w, err = NewBinaryWriter(&buf, encoder)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	r, err := NewReader(&buf)
+	require.NoError(t, err)
+	_, err = r.Read()
+	assert.ErrorIs(t, err, io.EOF, "an empty file has no examples")
+
+	_, examples := testExamples(t)
+	w, err = NewBinaryWriter(&buf, encoder)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(examples[0]))
+	require.NoError(t, w.Close())
+	zr, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	data, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	var truncated bytes.Buffer
+	zw := gzip.NewWriter(&truncated)
+	_, err = zw.Write(data[:len(data)-1])
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	r, err = NewReader(&truncated)
+	require.NoError(t, err)
+	_, err = r.Read()
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, io.EOF, "a cut off example is not the end of the file")
+}
+
+func TestCSV(t *testing.T) {
+	encoder, examples := testExamples(t)
+	var buf bytes.Buffer
+	w, err := NewWriter(CSV, &buf, encoder)
+	require.NoError(t, err)
+	for _, example := range examples {
+		require.NoError(t, w.Write(example))
+	}
+	require.NoError(t, w.Close())
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 1+len(examples))
+	assert.Equal(t, []string{"white_to_move", "result", "score", "white_warrior_0_0", "white_warrior_0_1"}, rows[0][:5])
+	assert.Equal(t, "black_padwar_2_2", rows[0][len(rows[0])-1])
+	assert.Equal(t, []string{"1", "1", "2.5", "1", "0"}, rows[1][:5])
+	assert.Equal(t, []string{"0", "0.5", ""}, rows[2][:3], "examples without a score leave it empty")
+	assert.Equal(t, "1", rows[1][3+13])
+	assert.Equal(t, "1", rows[1][3+35])
+
+	_, err = NewWriter("parquet", &buf, encoder)
+	assert.Error(t, err, "unknown formats are an error")
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package training
+
+import (
+	"cragspider-go/internal/core"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sync"
+)
+
+// Network is an evaluation function learned from examples. It scores a board from its tensor, either linearly or
+// through one hidden layer of rectified units, and gives its scores in the same terms as an AI player's
+// BoardScorer: positive when White is ahead and negative when Black is. Since tensors are mostly zeros, a board is
+// scored by adding up the weights of just the squares with pieces on them.
+type Network struct {
+	Rows    int      `json:"rows"`
+	Columns int      `json:"columns"`
+	Pieces  []string `json:"pieces"`
+	Scale   float32  `json:"scale"` // White expects about 0.73 points from a position scored Scale
+
+	// Hidden holds, for each value of a tensor, its weights into the hidden units. A linear network has none.
+	Hidden     [][]float32 `json:"hidden,omitempty"`
+	HiddenBias []float32   `json:"hidden_bias,omitempty"`
+	// Output holds the weights into the score from each hidden unit, or from each value of a tensor in a linear
+	// network.
+	Output []float32 `json:"output"`
+	Bias   float32   `json:"bias"`
+
+	encoder     *Encoder
+	encoderOnce sync.Once
+}
+
+// Encoder returns the encoder for the boards the network scores. It's built once, and since it's only read, it's
+// shared by searches scoring boards at the same time.
+func (n *Network) Encoder() *Encoder {
+	n.encoderOnce.Do(func() {
+		if n.encoder == nil {
+			n.encoder = newEncoder(n.Rows, n.Columns, n.Pieces)
+		}
+	})
+	return n.encoder
+}
+
+// Score returns the network's score for the board, positive when White is ahead. The weights of the squares with
+// pieces on them are added up as the board is walked, without encoding it as a tensor.
+func (n *Network) Score(board *core.Board) (float32, error) {
+	encoder := n.Encoder()
+	if board.Rows != encoder.Rows || board.Columns != encoder.Columns {
+		return 0, fmt.Errorf("cannot score a %dx%d board with a network for %dx%d boards",
+			board.Rows, board.Columns, encoder.Rows, encoder.Columns)
+	}
+	s := n.newSum()
+	for row := range board.Rows {
+		for column := range board.Columns {
+			pos := core.Position{row, column}
+			piece := board.GetPieceAt(pos)
+			if piece == nil {
+				continue
+			}
+			i, ok := encoder.Index(piece.Color, piece.Name, pos)
+			if !ok {
+				return 0, fmt.Errorf("cannot score unknown piece '%s' at %s", piece.Name, pos)
+			}
+			s.add(i)
+		}
+	}
+	return s.score(), nil
+}
+
+// Predict returns the network's score for a tensor.
+func (n *Network) Predict(tensor []uint8) float32 {
+	s := n.newSum()
+	for i, value := range tensor {
+		if value != 0 {
+			s.add(i)
+		}
+	}
+	return s.score()
+}
+
+// sum adds up a network's weights for the values of a tensor that are 1.
+type sum struct {
+	n      *Network
+	linear float32   // The score so far of a linear network
+	hidden []float32 // The hidden units' inputs so far, or nil for a linear network
+}
+
+func (n *Network) newSum() sum {
+	s := sum{n: n, linear: n.Bias}
+	if len(n.Hidden) > 0 {
+		s.hidden = slices.Clone(n.HiddenBias)
+	}
+	return s
+}
+
+// add adds the weights of the tensor's value i.
+func (s *sum) add(i int) {
+	if s.hidden == nil {
+		s.linear += s.n.Output[i]
+		return
+	}
+	for j, weight := range s.n.Hidden[i] {
+		s.hidden[j] += weight
+	}
+}
+
+// score returns the network's score from the weights added.
+func (s *sum) score() float32 {
+	if s.hidden == nil {
+		return s.linear
+	}
+	score := s.n.Bias
+	for j, h := range s.hidden {
+		score += max(h, 0) * s.n.Output[j]
+	}
+	return score
+}
+
+// Expectation returns the points White expects from a position the network scores score.
+func (n *Network) Expectation(score float32) float32 {
+	return sigmoid(score / n.Scale)
+}
+
+// validate checks that the network's weights fit its tensors.
+func (n *Network) validate() error {
+	size := n.Encoder().Size()
+	if size == 0 {
+		return fmt.Errorf("network has no inputs")
+	}
+	if n.Scale <= 0 {
+		return fmt.Errorf("network has scale %g, expected a positive scale", n.Scale)
+	}
+	if len(n.Hidden) == 0 {
+		if len(n.Output) != size {
+			return fmt.Errorf("linear network has %d weights, expected %d", len(n.Output), size)
+		}
+		return nil
+	}
+	if len(n.Hidden) != size {
+		return fmt.Errorf("network has hidden weights for %d inputs, expected %d", len(n.Hidden), size)
+	}
+	units := len(n.HiddenBias)
+	for i, weights := range n.Hidden {
+		if len(weights) != units {
+			return fmt.Errorf("network input %d has %d hidden weights, expected %d", i, len(weights), units)
+		}
+	}
+	if len(n.Output) != units {
+		return fmt.Errorf("network has %d output weights, expected %d", len(n.Output), units)
+	}
+	return nil
+}
+
+// LoadNetwork reads a network from the named file.
+func LoadNetwork(path string) (*Network, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network: %w", err)
+	}
+	var n Network
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal network: %w", err)
+	}
+	if err := n.validate(); err != nil {
+		return nil, fmt.Errorf("invalid network in %s: %w", path, err)
+	}
+	n.encoder = newEncoder(n.Rows, n.Columns, n.Pieces)
+	return &n, nil
+}
+
+// Save writes the network to the named file.
+func (n *Network) Save(path string) error {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal network: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write network: %w", err)
+	}
+	return nil
+}
+
+func sigmoid(x float32) float32 {
+	return float32(1 / (1 + math.Exp(-float64(x))))
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package training
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetwork_Score(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	encoder := NewEncoder(gameConfig)
+
+	// A linear network that counts White's warriors and Black's padwars against each other
+	network := &Network{Rows: 10, Columns: 10, Pieces: encoder.Pieces, Scale: 1, Output: make([]float32, encoder.Size())}
+	for _, square := range []struct {
+		color core.Color
+		piece string
+		value float32
+	}{{core.White, "warrior", 1}, {core.Black, "padwar", -2}} {
+		for row := range 10 {
+			for column := range 10 {
+				i, ok := encoder.Index(square.color, square.piece, core.Position{row, column})
+				require.True(t, ok)
+				network.Output[i] = square.value
+			}
+		}
+	}
+	network.Bias = 0.5
+
+	var warriors, padwars float32
+	for _, piece := range game.Board.GetPiecesByColor(core.White) {
+		if piece.Name == "warrior" {
+			warriors++
+		}
+	}
+	for _, piece := range game.Board.GetPiecesByColor(core.Black) {
+		if piece.Name == "padwar" {
+			padwars++
+		}
+	}
+	score, err := network.Score(game.Board)
+	require.NoError(t, err)
+	assert.Equal(t, 0.5+warriors-2*padwars, score)
+	assert.InDelta(t, 0.73, network.Expectation(1), 0.01)
+
+	// The same network with a hidden layer of one unit that passes the score through
+	hidden := &Network{
+		Rows: 10, Columns: 10, Pieces: encoder.Pieces, Scale: 1,
+		Hidden:     make([][]float32, encoder.Size()),
+		HiddenBias: []float32{100},
+		Output:     []float32{1},
+		Bias:       0.5 - 100,
+	}
+	for i := range hidden.Hidden {
+		hidden.Hidden[i] = []float32{network.Output[i]}
+	}
+	score, err = hidden.Score(game.Board)
+	require.NoError(t, err)
+	assert.Equal(t, 0.5+warriors-2*padwars, score)
+	tensor, err := encoder.Encode(game.Board)
+	require.NoError(t, err)
+	assert.Equal(t, hidden.Predict(tensor), score, "scoring a board adds up the same weights as its tensor")
+	assert.Same(t, hidden.Encoder(), hidden.Encoder(), "the encoder is built once")
+
+	small := &Network{Rows: 4, Columns: 4, Pieces: encoder.Pieces, Scale: 1, Output: make([]float32, 64)}
+	_, err = small.Score(game.Board)
+	assert.Error(t, err, "boards must be the network's size")
+}
+
+func TestLoadNetwork_Errors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := LoadNetwork(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err, "missing file")
+
+	garbage := filepath.Join(dir, "garbage.json")
+	require.NoError(t, os.WriteFile(garbage, []byte("{"), 0o600))
+	_, err = LoadNetwork(garbage)
+	assert.Error(t, err, "not JSON")
+
+	for name, network := range map[string]*Network{
+		"no inputs":            {Scale: 1},
+		"no scale":             {Rows: 2, Columns: 2, Pieces: []string{"warrior"}, Output: make([]float32, 8)},
+		"short linear weights": {Rows: 2, Columns: 2, Pieces: []string{"warrior"}, Scale: 1, Output: make([]float32, 7)},
+		"short hidden weights": {Rows: 1, Columns: 1, Pieces: []string{"warrior"}, Scale: 1,
+			Hidden: [][]float32{{1}, {1, 2}}, HiddenBias: []float32{0, 0}, Output: []float32{1, 1}},
+		"short output weights": {Rows: 1, Columns: 1, Pieces: []string{"warrior"}, Scale: 1,
+			Hidden: [][]float32{{1, 2}, {1, 2}}, HiddenBias: []float32{0, 0}, Output: []float32{1}},
+	} {
+		path := filepath.Join(dir, "network.json")
+		require.NoError(t, network.Save(path))
+		_, err := LoadNetwork(path)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package training
+
+import (
+	"cragspider-go/pkg/random"
+	"fmt"
+	"math"
+)
+
+const (
+	// DefaultEpochs is how many passes over the examples Train makes when the options don't say.
+	DefaultEpochs = 20
+	// DefaultBatchSize is how many examples Train averages each step over when the options don't say.
+	DefaultBatchSize = 256
+	// DefaultLearningRate is how big Train's steps are when the options don't say.
+	DefaultLearningRate = 0.01
+	// DefaultScale is the network's scale when the options don't say. It matches the scores of the personalities'
+	// scorers, where a warrior is worth about one.
+	DefaultScale = 4
+)
+
+// TrainOptions controls how a network is trained.
+type TrainOptions struct {
+	Hidden       int     // Hidden units; zero trains a linear network
+	Epochs       int     // Passes over the examples; zero means DefaultEpochs
+	BatchSize    int     // Examples averaged over for each step; zero means DefaultBatchSize
+	LearningRate float32 // Size of each step; zero means DefaultLearningRate
+	Scale        float32 // Score that gives White 0.73 expected points; zero means DefaultScale
+	Seed         int64   // Seed for the starting weights and the order examples are visited in
+
+	// ResultWeight is how much the game's result counts towards what an example is fitted to, from 0 to 1, against
+	// the expected points from its search score. Examples without a search score are fitted to their result alone.
+	ResultWeight float32
+
+	// Progress, if set, is called after each pass with the loss so far.
+	Progress func(epoch int, loss float64)
+}
+
+// TrainReport summarizes a training run.
+type TrainReport struct {
+	InitialLoss float64 // Mean log loss of the starting weights
+	FinalLoss   float64 // Mean log loss of the trained weights
+	Epochs      int     // Passes made over the examples
+}
+
+// Train fits a network to the examples, all encoded by the encoder. The network's expected points for each
+// example's board are fitted to the example's target with Adam, minimizing the log loss.
+func Train(examples []Example, encoder *Encoder, opts TrainOptions) (*Network, TrainReport, error) {
+	t, report, err := train(examples, encoder, opts)
+	if err != nil {
+		return nil, TrainReport{}, err
+	}
+	n := t.network(encoder)
+	if err := n.validate(); err != nil {
+		return nil, TrainReport{}, err
+	}
+	return n, report, nil
+}
+
+// train fits a trainer's weights to the examples as described for Train.
+func train(examples []Example, encoder *Encoder, opts TrainOptions) (*trainer, TrainReport, error) {
+	if len(examples) == 0 {
+		return nil, TrainReport{}, fmt.Errorf("no examples to train on")
+	}
+	if opts.Hidden < 0 || opts.ResultWeight < 0 || opts.ResultWeight > 1 {
+		return nil, TrainReport{}, fmt.Errorf("invalid training options: %d hidden units, result weight %g",
+			opts.Hidden, opts.ResultWeight)
+	}
+	epochs := opts.Epochs
+	if epochs <= 0 {
+		epochs = DefaultEpochs
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	rate := opts.LearningRate
+	if rate <= 0 {
+		rate = DefaultLearningRate
+	}
+	scale := opts.Scale
+	if scale <= 0 {
+		scale = DefaultScale
+	}
+
+	rng := random.New(opts.Seed)
+	t := newTrainer(encoder.Size(), opts.Hidden, scale, rng.Float32)
+	data := make([]sample, len(examples))
+	for i, example := range examples {
+		if len(example.Board) != encoder.Size() {
+			return nil, TrainReport{}, fmt.Errorf("example %d has %d values, expected %d",
+				i+1, len(example.Board), encoder.Size())
+		}
+		data[i] = sample{active: activeInputs(example.Board), target: example.Result}
+		if example.HasScore {
+			data[i].target = opts.ResultWeight*example.Result + (1-opts.ResultWeight)*sigmoid(example.Score/scale)
+		}
+	}
+
+	report := TrainReport{InitialLoss: t.loss(data), Epochs: epochs}
+	opt := newAdam(len(t.params), rate)
+	grad := make([]float32, len(t.params))
+	hidden := make([]float32, t.units)
+	order := rng.Perm(len(data))
+	for epoch := 1; epoch <= epochs; epoch++ {
+		rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+		for start := 0; start < len(order); start += batchSize {
+			batch := order[start:min(start+batchSize, len(order))]
+			clear(grad)
+			for _, i := range batch {
+				s := data[i]
+				p := sigmoid(t.forward(s.active, hidden) / scale)
+				// The log loss's slope with respect to the score
+				t.backward(s.active, hidden, (p-s.target)/scale/float32(len(batch)), grad)
+			}
+			opt.step(t.params, grad)
+		}
+		report.FinalLoss = t.loss(data)
+		if opts.Progress != nil {
+			opts.Progress(epoch, report.FinalLoss)
+		}
+	}
+	return t, report, nil
+}
+
+// sample is an example ready for training: the values set in its tensor and the expected points it's fitted to.
+type sample struct {
+	active []int
+	target float32
+}
+
+// activeInputs returns where the tensor's values are set.
+func activeInputs(tensor []uint8) []int {
+	var active []int
+	for i, value := range tensor {
+		if value != 0 {
+			active = append(active, i)
+		}
+	}
+	return active
+}
+
+// trainer holds a network's weights while it's trained, all in one slice so that they can be stepped together:
+// the hidden weights input by input, then the hidden biases, the output weights and the output bias.
+type trainer struct {
+	size, units int
+	scale       float32
+	params      []float32
+	hiddenBias  int // Where the hidden biases start in params
+	output      int // Where the output weights start in params
+	bias        int // Where the output bias is in params
+}
+
+// newTrainer returns a trainer for a network with size inputs and the given number of hidden units, its starting
+// weights drawn from uniform.
+func newTrainer(size, units int, scale float32, uniform func() float32) *trainer {
+	t := &trainer{size: size, units: units, scale: scale}
+	t.hiddenBias = size * units
+	t.output = t.hiddenBias + units
+	t.bias = t.output + units
+	if units == 0 {
+		t.bias = t.output + size
+	}
+	t.params = make([]float32, t.bias+1)
+	if units > 0 {
+		// Small hidden weights and positive biases so that every unit starts out active, and output weights
+		// spread enough for the units to learn different things
+		for i := range t.hiddenBias {
+			t.params[i] = 0.1 * (2*uniform() - 1)
+		}
+		for j := range units {
+			t.params[t.hiddenBias+j] = 0.1
+			t.params[t.output+j] = (2*uniform() - 1) / float32(math.Sqrt(float64(units)))
+		}
+	}
+	return t
+}
+
+// forward returns the score for the tensor with the given values set, leaving each hidden unit's activation in
+// hidden.
+func (t *trainer) forward(active []int, hidden []float32) float32 {
+	if t.units == 0 {
+		score := t.params[t.bias]
+		for _, i := range active {
+			score += t.params[t.output+i]
+		}
+		return score
+	}
+	copy(hidden, t.params[t.hiddenBias:t.output])
+	for _, i := range active {
+		weights := t.params[i*t.units : (i+1)*t.units]
+		for j := range hidden {
+			hidden[j] += weights[j]
+		}
+	}
+	score := t.params[t.bias]
+	for j := range hidden {
+		hidden[j] = max(hidden[j], 0)
+		score += hidden[j] * t.params[t.output+j]
+	}
+	return score
+}
+
+// backward adds to grad the slope of the loss with respect to every weight, given its slope with respect to the
+// score of the tensor last passed to forward.
+func (t *trainer) backward(active []int, hidden []float32, slope float32, grad []float32) {
+	grad[t.bias] += slope
+	if t.units == 0 {
+		for _, i := range active {
+			grad[t.output+i] += slope
+		}
+		return
+	}
+	for j, h := range hidden {
+		grad[t.output+j] += slope * h
+		if h <= 0 {
+			continue
+		}
+		unitSlope := slope * t.params[t.output+j]
+		grad[t.hiddenBias+j] += unitSlope
+		for _, i := range active {
+			grad[i*t.units+j] += unitSlope
+		}
+	}
+}
+
+// loss returns the mean log loss of the trainer's weights over the samples.
+func (t *trainer) loss(data []sample) float64 {
+	const epsilon = 1e-7
+	hidden := make([]float32, t.units)
+	var total float64
+	for _, s := range data {
+		p := min(max(float64(sigmoid(t.forward(s.active, hidden)/t.scale)), epsilon), 1-epsilon)
+		target := float64(s.target)
+		total -= target*math.Log(p) + (1-target)*math.Log(1-p)
+	}
+	return total / float64(len(data))
+}
+
+// network returns a network with the trainer's weights.
+func (t *trainer) network(encoder *Encoder) *Network {
+	n := &Network{
+		Rows:    encoder.Rows,
+		Columns: encoder.Columns,
+		Pieces:  encoder.Pieces,
+		Scale:   t.scale,
+		Output:  append([]float32(nil), t.params[t.output:t.bias]...),
+		Bias:    t.params[t.bias],
+		encoder: encoder,
+	}
+	if t.units > 0 {
+		n.Hidden = make([][]float32, t.size)
+		for i := range n.Hidden {
+			n.Hidden[i] = append([]float32(nil), t.params[i*t.units:(i+1)*t.units]...)
+		}
+		n.HiddenBias = append([]float32(nil), t.params[t.hiddenBias:t.output]...)
+	}
+	return n
+}
+
+// adam steps weights downhill with the Adam method, which keeps a running average of each weight's slope and of
+// its square so that every weight moves at a pace suited to it.
+type adam struct {
+	rate   float32
+	m, v   []float32
+	steps  int
+	beta1  float64
+	beta2  float64
+	margin float32
+}
+
+func newAdam(size int, rate float32) *adam {
+	return &adam{
+		rate:   rate,
+		m:      make([]float32, size),
+		v:      make([]float32, size),
+		beta1:  0.9,
+		beta2:  0.999,
+		margin: 1e-8,
+	}
+}
+
+// step moves the weights against their slopes.
+func (a *adam) step(params, grad []float32) {
+	a.steps++
+	b1, b2 := float32(a.beta1), float32(a.beta2)
+	// Early averages lean towards zero, so they're scaled up until there's enough history
+	correction1 := float32(1 - math.Pow(a.beta1, float64(a.steps)))
+	correction2 := float32(1 - math.Pow(a.beta2, float64(a.steps)))
+	for i, g := range grad {
+		a.m[i] = b1*a.m[i] + (1-b1)*g
+		a.v[i] = b2*a.v[i] + (1-b2)*g*g
+		mean := a.m[i] / correction1
+		variance := a.v[i] / correction2
+		params[i] -= a.rate * mean / (float32(math.Sqrt(float64(variance))) + a.margin)
+	}
+}
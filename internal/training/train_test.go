@@ -0,0 +1,141 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package training
+
+import (
+	"path/filepath"
+	"testing"
+
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/core/coretest"
+	"cragspider-go/pkg/random"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// materialExamples returns random positions on a 4x4 board, each won by whoever has more pieces and drawn when
+// they have the same, along with the boards and their encoder.
+func materialExamples(t *testing.T, count int) (*Encoder, []*core.Board, []Example) {
+	cfg := coretest.SmallConfig(t, 4, 4)
+	encoder := NewEncoder(cfg)
+	rng := random.New(1)
+	names := []string{"warrior", "padwar"}
+
+	var boards []*core.Board
+	var examples []Example
+	for range count {
+		squares := rng.Perm(16)
+		place := func(n int) []core.BoardPosition {
+			var placed []core.BoardPosition
+			for range n {
+				square := squares[0]
+				squares = squares[1:]
+				placed = append(placed, core.BoardPosition{
+					Name:     names[rng.Intn(len(names))],
+					Position: core.Position{square / 4, square % 4},
+				})
+			}
+			return placed
+		}
+		white, black := 1+rng.Intn(3), 1+rng.Intn(3)
+		cfg.Board.White, cfg.Board.Black = place(white), place(black)
+		game, err := core.NewGameWithConfig(cfg)
+		require.NoError(t, err)
+		tensor, err := encoder.Encode(game.Board)
+		require.NoError(t, err)
+
+		result := float32(0.5)
+		switch {
+		case white > black:
+			result = 1
+		case white < black:
+			result = 0
+		}
+		boards = append(boards, game.Board)
+		examples = append(examples, Example{Board: tensor, ToMove: core.White, Result: result})
+	}
+	return encoder, boards, examples
+}
+
+func TestTrain_InferenceMatchesTraining(t *testing.T) {
+	encoder, boards, examples := materialExamples(t, 200)
+	for _, hidden := range []int{0, 8} {
+		opts := TrainOptions{Hidden: hidden, Epochs: 5, BatchSize: 32, Seed: 3}
+		trained, _, err := train(examples, encoder, opts)
+		require.NoError(t, err)
+		network, _, err := Train(examples, encoder, opts)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "network.json")
+		require.NoError(t, network.Save(path))
+		loaded, err := LoadNetwork(path)
+		require.NoError(t, err)
+
+		scratch := make([]float32, hidden)
+		for i, example := range examples {
+			expected := trained.forward(activeInputs(example.Board), scratch)
+			assert.InDelta(t, expected, network.Predict(example.Board), 1e-4,
+				"%d hidden units: the network should score example %d as training did", hidden, i)
+			score, err := loaded.Score(boards[i])
+			require.NoError(t, err)
+			assert.InDelta(t, expected, score, 1e-4,
+				"%d hidden units: the saved network should score board %d as training did", hidden, i)
+		}
+	}
+}
+
+func TestTrain_LearnsMaterial(t *testing.T) {
+	encoder, _, examples := materialExamples(t, 1000)
+	for _, hidden := range []int{0, 8} {
+		var losses []float64
+		network, report, err := Train(examples, encoder, TrainOptions{
+			Hidden:   hidden,
+			Epochs:   30,
+			Seed:     1,
+			Progress: func(_ int, loss float64) { losses = append(losses, loss) },
+		})
+		require.NoError(t, err)
+		require.Len(t, losses, 30, "progress should be reported every epoch")
+		assert.Equal(t, report.FinalLoss, losses[len(losses)-1])
+		assert.Less(t, report.FinalLoss, report.InitialLoss, "%d hidden units: training should fit better", hidden)
+
+		// Nearly every position should be scored in favor of whoever has more pieces
+		var right, decided int
+		for _, example := range examples {
+			if example.Result == 0.5 {
+				continue
+			}
+			decided++
+			if (network.Predict(example.Board) > 0) == (example.Result == 1) {
+				right++
+			}
+		}
+		assert.Greater(t, float64(right)/float64(decided), 0.9, "%d hidden units: should learn who's ahead", hidden)
+	}
+}
+
+func TestTrain_SearchScores(t *testing.T) {
+	encoder, _, examples := materialExamples(t, 1)
+	// A lost game whose search thought White was well ahead
+	examples[0].Result, examples[0].Score, examples[0].HasScore = 0, 8, true
+
+	fit := func(resultWeight float32) float32 {
+		network, _, err := Train(examples, encoder, TrainOptions{Epochs: 200, BatchSize: 1, ResultWeight: resultWeight})
+		require.NoError(t, err)
+		return network.Expectation(network.Predict(examples[0].Board))
+	}
+	assert.InDelta(t, sigmoid(2), fit(0), 0.05, "should fit the search score alone")
+	assert.InDelta(t, 0.5*sigmoid(2), fit(0.5), 0.05, "should fit halfway between the result and the search score")
+}
+
+func TestTrain_Errors(t *testing.T) {
+	encoder, _, examples := materialExamples(t, 10)
+	_, _, err := Train(nil, encoder, TrainOptions{})
+	assert.Error(t, err, "no examples")
+	_, _, err = Train(examples, encoder, TrainOptions{Hidden: -1})
+	assert.Error(t, err, "negative hidden units")
+	_, _, err = Train(examples, encoder, TrainOptions{ResultWeight: 2})
+	assert.Error(t, err, "result weight above one")
+	_, _, err = Train([]Example{{Board: make([]uint8, 3)}}, encoder, TrainOptions{})
+	assert.Error(t, err, "tensors of the wrong size")
+}
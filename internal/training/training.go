@@ -0,0 +1,152 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Package training turns the games AI personalities play into examples for fitting evaluation functions offline.
+// Every position from a finished game becomes an example: the board as a tensor of piece planes, the side to move,
+// how the game ended, and the score the mover's search gave the position, if it searched.
+package training
+
+import (
+	"cragspider-go/internal/core"
+	"fmt"
+)
+
+// Encoder turns boards into tensors. A tensor has one plane per color and kind of piece, White's pieces first, in
+// the order of the game configuration's piece list. Each plane has a square for every square of the board, row by
+// row, that is 1 where a piece of its color and kind stands and 0 everywhere else.
+type Encoder struct {
+	Rows, Columns int
+	Pieces        []string // Kinds of piece in the order of their planes
+	index         map[string]int
+}
+
+// NewEncoder returns an encoder for boards played with the given game configuration.
+func NewEncoder(cfg *core.GameConfig) *Encoder {
+	pieces := make([]string, len(cfg.Pieces))
+	for i, piece := range cfg.Pieces {
+		pieces[i] = piece.Name
+	}
+	return newEncoder(cfg.Board.Rows, cfg.Board.Columns, pieces)
+}
+
+func newEncoder(rows, columns int, pieces []string) *Encoder {
+	index := make(map[string]int, len(pieces))
+	for i, name := range pieces {
+		index[name] = i
+	}
+	return &Encoder{Rows: rows, Columns: columns, Pieces: pieces, index: index}
+}
+
+// Planes returns the number of planes in a tensor.
+func (e *Encoder) Planes() int {
+	return 2 * len(e.Pieces)
+}
+
+// Size returns the number of values in a tensor.
+func (e *Encoder) Size() int {
+	return e.Planes() * e.Rows * e.Columns
+}
+
+// Plane returns the color and kind of piece of the numbered plane.
+func (e *Encoder) Plane(plane int) (core.Color, string) {
+	if plane < len(e.Pieces) {
+		return core.White, e.Pieces[plane]
+	}
+	return core.Black, e.Pieces[plane-len(e.Pieces)]
+}
+
+// Index returns where in a tensor the value for a piece of the specified color and kind on the given square is.
+func (e *Encoder) Index(color core.Color, piece string, pos core.Position) (int, bool) {
+	kind, ok := e.index[piece]
+	if !ok || pos[0] < 0 || pos[0] >= e.Rows || pos[1] < 0 || pos[1] >= e.Columns {
+		return 0, false
+	}
+	if color == core.Black {
+		kind += len(e.Pieces)
+	}
+	return (kind*e.Rows+pos[0])*e.Columns + pos[1], true
+}
+
+// Encode returns the board as a tensor.
+func (e *Encoder) Encode(board *core.Board) ([]uint8, error) {
+	if board.Rows != e.Rows || board.Columns != e.Columns {
+		return nil, fmt.Errorf("cannot encode a %dx%d board for %dx%d tensors",
+			board.Rows, board.Columns, e.Rows, e.Columns)
+	}
+	tensor := make([]uint8, e.Size())
+	for row := range board.Rows {
+		for column := range board.Columns {
+			pos := core.Position{row, column}
+			piece := board.GetPieceAt(pos)
+			if piece == nil {
+				continue
+			}
+			i, ok := e.Index(piece.Color, piece.Name, pos)
+			if !ok {
+				return nil, fmt.Errorf("cannot encode unknown piece '%s' at %s", piece.Name, pos)
+			}
+			tensor[i] = 1
+		}
+	}
+	return tensor, nil
+}
+
+// Example is one position from a finished game, labelled with how the game ended.
+type Example struct {
+	Board    []uint8 // The board as a tensor from an Encoder
+	ToMove   core.Color
+	Result   float32 // White's points from the game: 1 for a win, 0.5 for a draw, 0 for a loss
+	Score    float32 // The mover's search score for the position from White's point of view, if HasScore
+	HasScore bool
+}
+
+// Game is a finished game to take examples from.
+type Game struct {
+	Moves  []core.MoveRecord
+	Result core.Result
+	Scores []*float32 // The mover's search score before each move from White's point of view; nil if there wasn't one
+}
+
+// Examples replays the games and returns the position before each move as an example, skipping the first skip
+// moves of each game. Unfinished games are left out.
+func Examples(games []Game, gameConfig *core.GameConfig, encoder *Encoder, skip int) ([]Example, error) {
+	var examples []Example
+	for n, record := range games {
+		var points float32
+		switch record.Result {
+		case core.WhiteWins:
+			points = 1
+		case core.BlackWins:
+			points = 0
+		case core.Draw:
+			points = 0.5
+		default:
+			continue
+		}
+
+		game, err := core.NewGameWithConfig(gameConfig)
+		if err != nil {
+			return nil, err
+		}
+		for i, move := range record.Moves {
+			if i >= skip {
+				tensor, err := encoder.Encode(game.Board)
+				if err != nil {
+					return nil, fmt.Errorf("cannot encode game %d move %d: %w", n+1, i+1, err)
+				}
+				example := Example{Board: tensor, ToMove: game.ActiveColor, Result: points}
+				if i < len(record.Scores) && record.Scores[i] != nil {
+					example.Score, example.HasScore = *record.Scores[i], true
+				}
+				examples = append(examples, example)
+			}
+			piece := game.Board.GetPieceAt(move.From)
+			if piece == nil {
+				return nil, fmt.Errorf("cannot replay game %d move %d (%s): no piece at %s", n+1, i+1, move, move.From)
+			}
+			if err := game.Play(&core.Action{Piece: piece, Move: move.Move()}); err != nil {
+				return nil, fmt.Errorf("cannot replay game %d move %d (%s): %w", n+1, i+1, move, err)
+			}
+		}
+	}
+	return examples, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package training
+
+import (
+	"testing"
+
+	"cragspider-go/internal/core"
+	"cragspider-go/internal/core/coretest"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+	game, err := core.NewGame()
+	require.NoError(t, err)
+
+	encoder := NewEncoder(gameConfig)
+	assert.Equal(t, 2*len(gameConfig.Pieces), encoder.Planes())
+	assert.Equal(t, encoder.Planes()*100, encoder.Size())
+	tensor, err := encoder.Encode(game.Board)
+	require.NoError(t, err)
+	require.Len(t, tensor, encoder.Size())
+
+	var ones int
+	for _, value := range tensor {
+		ones += int(value)
+	}
+	assert.Equal(t, len(gameConfig.Board.White)+len(gameConfig.Board.Black), ones, "one value set per piece")
+	for _, placed := range gameConfig.Board.Black {
+		i, ok := encoder.Index(core.Black, placed.Name, placed.Position)
+		require.True(t, ok)
+		assert.Equal(t, uint8(1), tensor[i], "black %s at %s should be set", placed.Name, placed.Position)
+		color, piece := encoder.Plane(i / 100)
+		assert.Equal(t, core.Black, color)
+		assert.Equal(t, placed.Name, piece)
+	}
+
+	_, ok := encoder.Index(core.White, "dragon", core.Position{0, 0})
+	assert.False(t, ok, "unknown pieces have no index")
+	_, ok = encoder.Index(core.White, "warrior", core.Position{10, 0})
+	assert.False(t, ok, "squares off the board have no index")
+
+	_, err = NewEncoder(coretest.SmallConfig(t, 4, 4)).Encode(game.Board)
+	assert.Error(t, err, "boards must be the encoder's size")
+}
+
+func TestExamples(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+	game, err := core.NewGame()
+	require.NoError(t, err)
+	var scores []*float32
+	for i := range 10 {
+		actions := game.Board.ValidActions(game.ActiveColor)
+		require.NoError(t, game.Play(&actions[len(actions)/2]))
+		// Only White's moves were searched
+		scores = append(scores, lo.Ternary(i%2 == 0, lo.ToPtr(float32(i)), nil))
+	}
+	finished := Game{Moves: game.Moves, Result: core.WhiteWins, Scores: scores}
+	unfinished := Game{Moves: game.Moves, Result: core.InProgress}
+
+	encoder := NewEncoder(gameConfig)
+	examples, err := Examples([]Game{finished, unfinished}, gameConfig, encoder, 2)
+	require.NoError(t, err)
+	require.Len(t, examples, len(game.Moves)-2, "one example per move after the skipped ones")
+	for i, example := range examples {
+		assert.Equal(t, float32(1), example.Result)
+		assert.Equal(t, game.Moves[i+2].Color, example.ToMove)
+		assert.Equal(t, example.ToMove == core.White, example.HasScore, "only the searched moves have scores")
+		if example.HasScore {
+			assert.Equal(t, float32(i+2), example.Score)
+		}
+	}
+	// The first example is the board before the third move
+	i, ok := encoder.Index(game.Moves[2].Color, game.Moves[2].Piece, game.Moves[2].From)
+	require.True(t, ok)
+	assert.Equal(t, uint8(1), examples[0].Board[i])
+}
+
+func TestExamples_BadRecord(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+	games := []Game{{
+		Result: core.Draw,
+		Moves:  []core.MoveRecord{{Color: core.White, Piece: "warrior", From: core.Position{5, 5}, To: core.Position{4, 5}}},
+	}}
+
+	_, err = Examples(games, gameConfig, NewEncoder(gameConfig), 0)
+	assert.Error(t, err)
+}
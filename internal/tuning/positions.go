@@ -0,0 +1,56 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+// Package tuning adjusts an AI personality's scoring weights to fit the results of games it has played. It uses
+// Texel's method: every position from a set of finished games is labelled with how the game ended, and weights are
+// nudged one at a time for as long as that makes the scores better at predicting those results.
+package tuning
+
+import (
+	"cragspider-go/internal/arena"
+	"cragspider-go/internal/core"
+	"fmt"
+)
+
+// Position is a board from a finished game, labelled with how that game ended.
+type Position struct {
+	Board  *core.Board
+	Result float64 // White's points from the game: 1 for a win, 0.5 for a draw, 0 for a loss
+}
+
+// Positions replays every game in the results and returns the board before each move, skipping the first skip
+// moves of each game since openings say little about who will win. Unfinished games are left out.
+func Positions(results *arena.Results, gameConfig *core.GameConfig, skip int) ([]Position, error) {
+	var positions []Position
+	for _, record := range results.Games {
+		var points float64
+		switch record.Result {
+		case core.WhiteWins:
+			points = 1
+		case core.BlackWins:
+			points = 0
+		case core.Draw:
+			points = 0.5
+		default:
+			continue
+		}
+
+		game, err := core.NewGameWithConfig(gameConfig)
+		if err != nil {
+			return nil, err
+		}
+		for i, move := range record.Moves {
+			if i >= skip {
+				positions = append(positions, Position{Board: game.Board, Result: points})
+			}
+			piece := game.Board.GetPieceAt(move.From)
+			if piece == nil {
+				return nil, fmt.Errorf("cannot replay round %d move %d (%s): no piece at %s",
+					record.Round, i+1, move, move.From)
+			}
+			if err := game.Play(&core.Action{Piece: piece, Move: move.Move()}); err != nil {
+				return nil, fmt.Errorf("cannot replay round %d move %d (%s): %w", record.Round, i+1, move, err)
+			}
+		}
+	}
+	return positions, nil
+}
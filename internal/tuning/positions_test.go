@@ -0,0 +1,47 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package tuning
+
+import (
+	"context"
+	"testing"
+
+	"cragspider-go/internal/arena"
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositions(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+	game, err := arena.PlayGame(context.Background(), gameConfig, "doofus", "doofus", 3, 0)
+	require.NoError(t, err)
+	unfinished := game
+	unfinished.Result = core.InProgress
+	results := &arena.Results{Games: []arena.GameResult{game, unfinished}}
+
+	positions, err := Positions(results, gameConfig, 2)
+	require.NoError(t, err)
+	require.Len(t, positions, game.Length()-2, "one position per move after the skipped ones")
+
+	points := map[core.Result]float64{core.WhiteWins: 1, core.BlackWins: 0, core.Draw: 0.5}[game.Result]
+	for _, p := range positions {
+		assert.Equal(t, points, p.Result)
+	}
+	// The first position kept is the board before the third move
+	assert.Equal(t, game.Moves[2].Piece, positions[0].Board.GetPieceAt(game.Moves[2].From).Name)
+}
+
+func TestPositions_BadRecord(t *testing.T) {
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+	results := &arena.Results{Games: []arena.GameResult{{
+		Result: core.Draw,
+		Moves:  []core.MoveRecord{{Color: core.White, Piece: "warrior", From: core.Position{5, 5}, To: core.Position{4, 5}}},
+	}}}
+
+	_, err = Positions(results, gameConfig, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,266 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package tuning
+
+import (
+	"context"
+	"cragspider-go/internal/ai"
+	"fmt"
+	"maps"
+	"math"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+const (
+	// DefaultRounds is how many passes over the parameters Tune makes when the options don't say.
+	DefaultRounds = 20
+	// DefaultStep is how far Tune first nudges each parameter when the options don't say.
+	DefaultStep = 0.1
+	// minStep is the smallest nudge worth making; once steps get this small tuning stops.
+	minStep = 0.005
+	// precision is what tuned values are rounded to, keeping the written configuration readable.
+	precision = 1e-4
+)
+
+// Options controls a tuning run.
+type Options struct {
+	Rounds  int     // Passes over every parameter; zero means DefaultRounds
+	Step    float32 // First nudge to each parameter, halved whenever a pass finds nothing better; zero means DefaultStep
+	Workers int     // Positions scored at once; zero means one per CPU
+
+	// Progress, if set, is called after each pass with the error so far and the current step.
+	Progress func(round int, err float64, step float32)
+}
+
+// Report summarizes a tuning run.
+type Report struct {
+	K            float64 // Scale that turns a score into an expected result
+	InitialError float64 // Mean squared error of the starting weights
+	FinalError   float64 // Mean squared error of the tuned weights
+	Rounds       int     // Passes made over the parameters
+}
+
+// parameter is one value that tuning adjusts: a piece's value or an evaluation term's weight.
+type parameter struct {
+	piece string // Piece whose scoring value this is, if it is one
+	term  string // Evaluation term whose weight this is, if it is one
+}
+
+// String returns a nicely formatted string representation of the parameter.
+func (p parameter) String() string {
+	if p.piece != "" {
+		return "scoring." + p.piece
+	}
+	return "weights." + p.term
+}
+
+func (p parameter) get(cfg *ai.AIPlayerConfig) float32 {
+	if p.piece != "" {
+		return cfg.Scoring[p.piece]
+	}
+	return cfg.Weight(p.term)
+}
+
+func (p parameter) set(cfg *ai.AIPlayerConfig, value float32) {
+	value = float32(math.Round(float64(value)/precision) * precision)
+	if p.piece != "" {
+		cfg.Scoring[p.piece] = value
+		return
+	}
+	if value == 0 {
+		// Unlisted terms have no weight, which keeps the written configuration short
+		delete(cfg.Weights, p.term)
+		return
+	}
+	cfg.Weights[p.term] = value
+}
+
+// parameters returns what tuning adjusts for the configuration, in a fixed order: each piece's value, then each
+// term's weight. Material's weight isn't tuned since piece values already set its scale, and nor are square
+// tables.
+func parameters(cfg *ai.AIPlayerConfig) []parameter {
+	var params []parameter
+	for _, piece := range slices.Sorted(maps.Keys(cfg.Scoring)) {
+		params = append(params, parameter{piece: piece})
+	}
+	for _, term := range ai.Terms {
+		if term != ai.TermMaterial && term != ai.TermSquares {
+			params = append(params, parameter{term: term})
+		}
+	}
+	return params
+}
+
+// Tune returns a copy of the configuration with its piece values and term weights adjusted to predict the results of
+// the positions as well as it can, along with a report of how much better it got. Tuning works through the
+// parameters in a fixed order and scores positions in fixed batches, so the same positions and options always give
+// the same answer.
+func Tune(ctx context.Context, positions []Position, base *ai.AIPlayerConfig, opts Options) (*ai.AIPlayerConfig, Report, error) {
+	if len(positions) == 0 {
+		return nil, Report{}, fmt.Errorf("no positions to tune with")
+	}
+	rounds := opts.Rounds
+	if rounds <= 0 {
+		rounds = DefaultRounds
+	}
+	step := opts.Step
+	if step <= 0 {
+		step = DefaultStep
+	}
+	t := &tuner{positions: positions, workers: opts.Workers}
+	if t.workers <= 0 {
+		t.workers = runtime.NumCPU()
+	}
+
+	cfg := clone(base)
+	k, err := t.fitK(ctx, cfg)
+	if err != nil {
+		return nil, Report{}, err
+	}
+	t.k = k
+	best, err := t.error(ctx, cfg)
+	if err != nil {
+		return nil, Report{}, err
+	}
+	report := Report{K: k, InitialError: best}
+
+	params := parameters(cfg)
+	for report.Rounds < rounds && step >= minStep {
+		report.Rounds++
+		improved := false
+		for _, param := range params {
+			start := param.get(cfg)
+			for _, delta := range []float32{step, -step} {
+				param.set(cfg, start+delta)
+				e, err := t.error(ctx, cfg)
+				if err != nil {
+					return nil, Report{}, err
+				}
+				if e < best {
+					best, improved = e, true
+					break
+				}
+				param.set(cfg, start)
+			}
+		}
+		if !improved {
+			step /= 2
+		}
+		if opts.Progress != nil {
+			opts.Progress(report.Rounds, best, step)
+		}
+	}
+	report.FinalError = best
+	return cfg, report, nil
+}
+
+// tuner scores the positions with candidate configurations.
+type tuner struct {
+	positions []Position
+	workers   int
+	k         float64
+}
+
+// fitK returns the scale that makes the configuration's scores best predict the results. Tuning the weights with the
+// scale fixed keeps them from simply growing or shrinking together.
+func (t *tuner) fitK(ctx context.Context, cfg *ai.AIPlayerConfig) (float64, error) {
+	scores, err := t.scores(ctx, cfg)
+	if err != nil {
+		return 0, err
+	}
+	errorAt := func(k float64) float64 {
+		var sum float64
+		for i, p := range t.positions {
+			d := p.Result - sigmoid(k*scores[i])
+			sum += d * d
+		}
+		return sum
+	}
+
+	// The error is smooth with a single minimum in K, so a golden section search on a log scale finds it
+	lo, hi := math.Log(0.01), math.Log(100)
+	ratio := (math.Sqrt(5) - 1) / 2
+	for range 60 {
+		a := hi - ratio*(hi-lo)
+		b := lo + ratio*(hi-lo)
+		if errorAt(math.Exp(a)) < errorAt(math.Exp(b)) {
+			hi = b
+		} else {
+			lo = a
+		}
+	}
+	return math.Exp((lo + hi) / 2), nil
+}
+
+// error returns the mean squared difference between the positions' results and the results the configuration's
+// scores predict for them.
+func (t *tuner) error(ctx context.Context, cfg *ai.AIPlayerConfig) (float64, error) {
+	scores, err := t.scores(ctx, cfg)
+	if err != nil {
+		return 0, err
+	}
+	var sum float64
+	for i, p := range t.positions {
+		d := p.Result - sigmoid(t.k*scores[i])
+		sum += d * d
+	}
+	return sum / float64(len(t.positions)), nil
+}
+
+// scores returns the configuration's score for every position, shared out among the workers.
+func (t *tuner) scores(ctx context.Context, cfg *ai.AIPlayerConfig) ([]float64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	scorer := ai.NewBoardScorerWithConfig(cfg)
+	scores := make([]float64, len(t.positions))
+	errs := make([]error, t.workers)
+	batch := (len(t.positions) + t.workers - 1) / t.workers
+	var wg sync.WaitGroup
+	for w := range t.workers {
+		start, end := w*batch, min((w+1)*batch, len(t.positions))
+		if start >= end {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				score, err := scorer.Score(t.positions[i].Board)
+				if err != nil {
+					errs[w] = err
+					return
+				}
+				scores[i] = float64(score)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("cannot score position: %w", err)
+		}
+	}
+	return scores, nil
+}
+
+// sigmoid maps a scaled score to an expected result between 0 and 1.
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+// clone returns a copy of the configuration whose scoring and weights can be changed without touching the original.
+func clone(cfg *ai.AIPlayerConfig) *ai.AIPlayerConfig {
+	c := *cfg
+	c.Scoring = maps.Clone(cfg.Scoring)
+	if c.Scoring == nil {
+		c.Scoring = make(map[string]float32)
+	}
+	c.Weights = maps.Clone(cfg.Weights)
+	if c.Weights == nil {
+		c.Weights = make(map[string]float32)
+	}
+	return &c
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package tuning
+
+import (
+	"context"
+	"testing"
+
+	"cragspider-go/internal/ai"
+	"cragspider-go/internal/arena"
+	"cragspider-go/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPositions returns the positions from a few quick games between the random personality and itself.
+func testPositions(t *testing.T) []Position {
+	t.Helper()
+	gameConfig, err := core.GetConfig()
+	require.NoError(t, err)
+	results, err := arena.Run(context.Background(), arena.Config{
+		Players:  []string{"doofus", "doofus"},
+		Mode:     arena.RoundRobin,
+		Games:    6,
+		Workers:  2,
+		MaxMoves: 60,
+		Untimed:  true,
+		Seed:     11,
+	}, gameConfig)
+	require.NoError(t, err)
+	positions, err := Positions(results, gameConfig, 0)
+	require.NoError(t, err)
+	return positions
+}
+
+func TestTune(t *testing.T) {
+	positions := testPositions(t)
+	base := &ai.AIPlayerConfig{
+		Name:     "base",
+		Strategy: ai.AlphaBetaStrategy,
+		Scoring:  map[string]float32{"warrior": 1, "padwar": 2},
+		Weights:  map[string]float32{"mobility": 0.05},
+	}
+
+	var rounds []int
+	opts := Options{Rounds: 5, Workers: 3, Progress: func(round int, _ float64, _ float32) {
+		rounds = append(rounds, round)
+	}}
+	tuned, report, err := Tune(context.Background(), positions, base, opts)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, rounds)
+	assert.Equal(t, 5, report.Rounds)
+	assert.Greater(t, report.K, 0.0)
+	assert.LessOrEqual(t, report.FinalError, report.InitialError, "tuning never makes the fit worse")
+
+	assert.Equal(t, map[string]float32{"warrior": 1, "padwar": 2}, base.Scoring, "the base config is untouched")
+	assert.Equal(t, map[string]float32{"mobility": 0.05}, base.Weights, "the base config is untouched")
+	assert.Equal(t, "base", tuned.Name)
+
+	opts.Workers = 1
+	opts.Progress = nil
+	again, _, err := Tune(context.Background(), positions, base, opts)
+	require.NoError(t, err)
+	assert.Equal(t, tuned, again, "tuning is reproducible whatever the number of workers")
+}
+
+func TestTune_Errors(t *testing.T) {
+	base := &ai.AIPlayerConfig{Name: "base"}
+	_, _, err := Tune(context.Background(), nil, base, Options{})
+	assert.Error(t, err, "no positions")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = Tune(ctx, testPositions(t), base, Options{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestParameters(t *testing.T) {
+	cfg := &ai.AIPlayerConfig{Scoring: map[string]float32{"warrior": 1, "padwar": 2}}
+	var names []string
+	for _, p := range parameters(cfg) {
+		names = append(names, p.String())
+	}
+	assert.Equal(t, []string{"scoring.padwar", "scoring.warrior", "weights.mobility", "weights.hanging",
+		"weights.center", "weights.objectives"}, names)
+}
+
+func TestParameter_Set(t *testing.T) {
+	cfg := clone(&ai.AIPlayerConfig{})
+	hanging := parameter{term: ai.TermHanging}
+	hanging.set(cfg, 0.123456)
+	assert.Equal(t, float32(0.1235), hanging.get(cfg), "values are rounded")
+	hanging.set(cfg, 0)
+	assert.NotContains(t, cfg.Weights, ai.TermHanging, "zero weights are left out")
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package tuning
+
+import (
+	"bytes"
+	"cragspider-go/internal/ai"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WriteConfig writes the configuration to the named file in the same form as the AI configuration file, so that it
+// can be pasted into it. Each line of the header is written above it as a comment.
+func WriteConfig(path string, cfg *ai.AIPlayerConfig, header string) error {
+	var buf bytes.Buffer
+	for line := range strings.Lines(header) {
+		fmt.Fprintf(&buf, "# %s\n", strings.TrimRight(line, "\n"))
+	}
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(ai.AIConfig{Players: []ai.AIPlayerConfig{*cfg}}); err != nil {
+		return fmt.Errorf("failed to marshal AI config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to marshal AI config: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write AI config: %w", err)
+	}
+	return nil
+}
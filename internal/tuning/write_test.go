@@ -0,0 +1,40 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package tuning
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cragspider-go/internal/ai"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestWriteConfig(t *testing.T) {
+	cfg := &ai.AIPlayerConfig{
+		Name:      "tuned",
+		Strategy:  ai.AlphaBetaStrategy,
+		Search:    ai.SearchLimits{MaxDepth: 3},
+		ThinkTime: ai.ThinkTime{Min: 500 * time.Millisecond, Max: 2 * time.Second},
+		Scoring:   map[string]float32{"warrior": 1.25, "padwar": 2},
+		Weights:   map[string]float32{"mobility": 0.05},
+	}
+	path := filepath.Join(t.TempDir(), "tuned.yml")
+	require.NoError(t, WriteConfig(path, cfg, "first line\nsecond line"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# first line\n# second line\nplayers:\n")
+	assert.Contains(t, string(data), "min: 500ms")
+	assert.NotContains(t, string(data), "square_tables", "empty fields are left out")
+
+	var read ai.AIConfig
+	require.NoError(t, yaml.Unmarshal(data, &read))
+	require.Len(t, read.Players, 1)
+	assert.Equal(t, *cfg, read.Players[0])
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package graphics
+
+import (
+	"fmt"
+	"time"
+)
+
+// The names of the clips sprites usually have.
+const (
+	// Idle plays while a sprite is standing still.
+	Idle = "idle"
+	// Walk plays while a sprite is moving.
+	Walk = "walk"
+	// Attack plays while a sprite is attacking.
+	Attack = "attack"
+	// Die plays when a sprite is taken out.
+	Die = "die"
+)
+
+// LoopMode is what a clip does once it has shown its last frame.
+type LoopMode int
+
+const (
+	// Loop starts the clip over from its first frame.
+	Loop LoopMode = iota
+	// Once stops on the last frame.
+	Once
+	// PingPong plays the clip backwards to its first frame, then forwards again, and so on.
+	PingPong
+)
+
+// loopModeNames are what each loop mode is called in configuration files.
+var loopModeNames = map[LoopMode]string{Loop: "loop", Once: "once", PingPong: "ping_pong"}
+
+// String returns the loop mode's name.
+func (m LoopMode) String() string {
+	if name, ok := loopModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("LoopMode(%d)", int(m))
+}
+
+// UnmarshalText reads a loop mode from its name.
+func (m *LoopMode) UnmarshalText(text []byte) error {
+	for mode, name := range loopModeNames {
+		if name == string(text) {
+			*m = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown loop mode %q", text)
+}
+
+// MarshalText writes the loop mode's name.
+func (m LoopMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// Frame is one frame of a clip: where it is in the sprite sheet, and how long it's shown.
+type Frame struct {
+	Coords   FrameCoords   `yaml:"coords"`
+	Duration time.Duration `yaml:"duration,omitempty"` // The clip's frame time if zero
+}
+
+// Clip is a sequence of frames played one after another.
+type Clip struct {
+	Frames    []Frame       `yaml:"frames"`
+	FrameTime time.Duration `yaml:"frame_time,omitempty"` // How long frames without their own duration are shown
+	Mode      LoopMode      `yaml:"mode,omitempty"`
+}
+
+// frameTime returns how long the clip's i'th frame is shown. A frame with no time of its own or from the clip is
+// skipped.
+func (c Clip) frameTime(i int) time.Duration {
+	if d := c.Frames[i].Duration; d > 0 {
+		return d
+	}
+	return c.FrameTime
+}
+
+// Duration returns how long it takes to play the clip through once.
+func (c Clip) Duration() time.Duration {
+	var total time.Duration
+	for i := range c.Frames {
+		total += c.frameTime(i)
+	}
+	return total
+}
+
+// FrameAt returns the frame showing after the clip has been playing for the given time. A clip with no frames
+// shows the top left frame of its sheet.
+func (c Clip) FrameAt(elapsed time.Duration) FrameCoords {
+	if len(c.Frames) == 0 {
+		return FrameCoords{}
+	}
+	return c.Frames[c.frameIndex(elapsed)].Coords
+}
+
+// frameIndex returns which frame is showing after the clip has been playing for the given time.
+func (c Clip) frameIndex(elapsed time.Duration) int {
+	order := c.order()
+	var cycle time.Duration
+	for _, i := range order {
+		cycle += c.frameTime(i)
+	}
+	if elapsed <= 0 || cycle <= 0 {
+		return 0
+	}
+	if c.Mode == Once {
+		if elapsed >= cycle {
+			return order[len(order)-1]
+		}
+	} else {
+		elapsed %= cycle
+	}
+	for _, i := range order {
+		elapsed -= c.frameTime(i)
+		if elapsed < 0 {
+			return i
+		}
+	}
+	return order[len(order)-1]
+}
+
+// order returns the indexes of the frames in the order one cycle of the clip shows them. Ping-ponging back, the
+// end frames aren't shown twice in a row.
+func (c Clip) order() []int {
+	order := make([]int, 0, 2*len(c.Frames))
+	for i := range c.Frames {
+		order = append(order, i)
+	}
+	if c.Mode == PingPong {
+		for i := len(c.Frames) - 2; i > 0; i-- {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// Done returns true once a clip that plays once has shown its last frame for its full time. Looping clips are never
+// done.
+func (c Clip) Done(elapsed time.Duration) bool {
+	return c.Mode == Once && elapsed >= c.Duration()
+}
+
+// Animation is a sprite's named clips, like Idle and Walk.
+type Animation map[string]Clip
+
+// Clip returns the animation's clip with the name, or its Idle clip if it has none by that name.
+func (a Animation) Clip(name string) Clip {
+	if clip, ok := a[name]; ok {
+		return clip
+	}
+	return a[Idle]
+}
+
+// Player plays an animation's clips as time goes by, one at a time.
+type Player struct {
+	animation Animation
+	clip      string
+	start     time.Time
+}
+
+// NewPlayer returns a player that starts playing the animation's Idle clip at the given time.
+func NewPlayer(animation Animation, now time.Time) *Player {
+	return &Player{animation: animation, clip: Idle, start: now}
+}
+
+// Play switches to the named clip from its first frame at the given time. A clip that's already playing carries on.
+func (p *Player) Play(clip string, now time.Time) {
+	if clip == p.clip {
+		return
+	}
+	p.clip, p.start = clip, now
+}
+
+// Playing returns the name of the clip being played.
+func (p *Player) Playing() string {
+	return p.clip
+}
+
+// Frame returns the frame showing at the given time.
+func (p *Player) Frame(now time.Time) FrameCoords {
+	return p.animation.Clip(p.clip).FrameAt(now.Sub(p.start))
+}
+
+// Done returns true once a clip that plays once has finished by the given time.
+func (p *Player) Done(now time.Time) bool {
+	return p.animation.Clip(p.clip).Done(now.Sub(p.start))
+}
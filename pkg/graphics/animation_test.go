@@ -0,0 +1,104 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package graphics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClip returns a clip of three frames in a row of the sheet, the middle one shown twice as long.
+func testClip(mode LoopMode) Clip {
+	return Clip{
+		Frames: []Frame{
+			{Coords: FrameCoords{0, 0}},
+			{Coords: FrameCoords{0, 1}, Duration: 200 * time.Millisecond},
+			{Coords: FrameCoords{0, 2}},
+		},
+		FrameTime: 100 * time.Millisecond,
+		Mode:      mode,
+	}
+}
+
+func TestClip_FrameAt(t *testing.T) {
+	const ms = time.Millisecond
+	tests := []struct {
+		name    string
+		mode    LoopMode
+		elapsed time.Duration
+		want    FrameCoords
+		done    bool
+	}{
+		{"before it starts", Loop, -ms, FrameCoords{0, 0}, false},
+		{"first frame", Loop, 99 * ms, FrameCoords{0, 0}, false},
+		{"longer middle frame", Loop, 299 * ms, FrameCoords{0, 1}, false},
+		{"last frame", Loop, 300 * ms, FrameCoords{0, 2}, false},
+		{"loops back to the start", Loop, 400 * ms, FrameCoords{0, 0}, false},
+		{"loops again", Loop, 1100 * ms, FrameCoords{0, 2}, false},
+		{"once holds the last frame", Once, time.Second, FrameCoords{0, 2}, true},
+		{"once isn't done early", Once, 399 * ms, FrameCoords{0, 2}, false},
+		{"ping-pong forwards", PingPong, 150 * ms, FrameCoords{0, 1}, false},
+		{"ping-pong turns at the end", PingPong, 400 * ms, FrameCoords{0, 1}, false},
+		{"ping-pong back at the start", PingPong, 600 * ms, FrameCoords{0, 0}, false},
+		{"ping-pong forwards again", PingPong, 700 * ms, FrameCoords{0, 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clip := testClip(tt.mode)
+			assert.Equal(t, tt.want, clip.FrameAt(tt.elapsed))
+			assert.Equal(t, tt.done, clip.Done(tt.elapsed))
+		})
+	}
+
+	assert.Equal(t, 400*time.Millisecond, testClip(Loop).Duration())
+	assert.Equal(t, FrameCoords{}, Clip{}.FrameAt(time.Second), "a clip with no frames shows the first of the sheet")
+	still := Clip{Frames: []Frame{{Coords: FrameCoords{3, 4}}}}
+	assert.Equal(t, FrameCoords{3, 4}, still.FrameAt(time.Hour), "a clip with no frame time stays on its first frame")
+}
+
+func TestLoopMode_Text(t *testing.T) {
+	for _, mode := range []LoopMode{Loop, Once, PingPong} {
+		text, err := mode.MarshalText()
+		require.NoError(t, err)
+		var read LoopMode
+		require.NoError(t, read.UnmarshalText(text))
+		assert.Equal(t, mode, read)
+	}
+	assert.Equal(t, "ping_pong", PingPong.String())
+	var mode LoopMode
+	assert.Error(t, mode.UnmarshalText([]byte("backwards")))
+}
+
+func TestAnimation_Clip(t *testing.T) {
+	idle := Clip{Frames: []Frame{{Coords: FrameCoords{0, 0}}}}
+	walk := Clip{Frames: []Frame{{Coords: FrameCoords{1, 0}}}}
+	animation := Animation{Idle: idle, Walk: walk}
+	assert.Equal(t, walk, animation.Clip(Walk))
+	assert.Equal(t, idle, animation.Clip(Attack), "missing clips fall back to idling")
+	assert.Equal(t, Clip{}, Animation{}.Clip(Idle))
+}
+
+func TestPlayer(t *testing.T) {
+	start := time.Now()
+	animation := Animation{Idle: testClip(Loop), Die: testClip(Once)}
+	player := NewPlayer(animation, start)
+	assert.Equal(t, Idle, player.Playing())
+	assert.Equal(t, FrameCoords{0, 1}, player.Frame(start.Add(150*time.Millisecond)))
+
+	// Asking for the clip that's playing carries on with it
+	player.Play(Idle, start.Add(150*time.Millisecond))
+	assert.Equal(t, FrameCoords{0, 2}, player.Frame(start.Add(300*time.Millisecond)))
+	assert.False(t, player.Done(start.Add(time.Hour)), "looping clips never finish")
+
+	// A new clip starts from its first frame
+	dying := start.Add(300 * time.Millisecond)
+	player.Play(Die, dying)
+	assert.Equal(t, Die, player.Playing())
+	assert.Equal(t, FrameCoords{0, 0}, player.Frame(dying))
+	assert.False(t, player.Done(dying.Add(399*time.Millisecond)))
+	assert.True(t, player.Done(dying.Add(400*time.Millisecond)))
+	assert.Equal(t, FrameCoords{0, 2}, player.Frame(dying.Add(time.Hour)))
+}
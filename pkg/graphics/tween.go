@@ -0,0 +1,101 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package graphics
+
+import (
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Easing maps how far through an animation is, from 0 to 1, to how far along its motion is, also from 0 to 1.
+type Easing func(t float32) float32
+
+// Linear moves at a constant speed.
+func Linear(t float32) float32 {
+	return t
+}
+
+// EaseInOutQuad speeds up from a standstill and slows down again to a stop.
+func EaseInOutQuad(t float32) float32 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - 2*(1-t)*(1-t)
+}
+
+// EaseOutCubic starts quickly and slows down to a stop.
+func EaseOutCubic(t float32) float32 {
+	return 1 - (1-t)*(1-t)*(1-t)
+}
+
+// Tween moves between two points over a duration.
+type Tween struct {
+	From, To rl.Vector2
+	Duration time.Duration
+	Ease     Easing // Linear if nil
+}
+
+// At returns where the tween is after the given time has passed. It's at From before it starts and To once it's
+// over.
+func (t Tween) At(elapsed time.Duration) rl.Vector2 {
+	return rl.Vector2Lerp(t.From, t.To, ease(t.Ease, progress(elapsed, t.Duration)))
+}
+
+// Done returns true once the given time has passed the end of the tween.
+func (t Tween) Done(elapsed time.Duration) bool {
+	return elapsed >= t.Duration
+}
+
+// Path moves through a series of points, spending the same time on each leg and easing each one separately, so
+// that it steps from point to point.
+type Path struct {
+	Points  []rl.Vector2
+	LegTime time.Duration // How long each leg between two points takes
+	Ease    Easing        // Linear if nil
+}
+
+// Duration returns how long the whole path takes.
+func (p Path) Duration() time.Duration {
+	return time.Duration(max(len(p.Points)-1, 0)) * p.LegTime
+}
+
+// At returns where the path is after the given time has passed: at the first point before it starts and the last
+// once it's over.
+func (p Path) At(elapsed time.Duration) rl.Vector2 {
+	if len(p.Points) == 0 {
+		return rl.Vector2{}
+	}
+	legs := len(p.Points) - 1
+	if legs == 0 || elapsed >= p.Duration() {
+		return p.Points[legs]
+	}
+	if elapsed <= 0 {
+		return p.Points[0]
+	}
+	leg := int(elapsed / p.LegTime)
+	t := ease(p.Ease, progress(elapsed-time.Duration(leg)*p.LegTime, p.LegTime))
+	return rl.Vector2Lerp(p.Points[leg], p.Points[leg+1], t)
+}
+
+// Done returns true once the given time has passed the end of the path.
+func (p Path) Done(elapsed time.Duration) bool {
+	return elapsed >= p.Duration()
+}
+
+// progress returns how far through an animation of the given duration the elapsed time is, from 0 to 1. An
+// animation that takes no time is always over.
+func progress(elapsed, duration time.Duration) float32 {
+	if duration <= 0 {
+		return 1
+	}
+	return rl.Clamp(float32(elapsed)/float32(duration), 0, 1)
+}
+
+// ease applies the easing to t, moving linearly without one.
+func ease(easing Easing, t float32) float32 {
+	if easing == nil {
+		return t
+	}
+	return easing(t)
+}
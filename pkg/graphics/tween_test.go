@@ -0,0 +1,91 @@
+// Copyright 2025 Ideograph LLC. All rights reserved.
+
+package graphics
+
+import (
+	"testing"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEasing(t *testing.T) {
+	for name, easing := range map[string]Easing{
+		"linear":      Linear,
+		"in-out quad": EaseInOutQuad,
+		"out cubic":   EaseOutCubic,
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, 0, easing(0), 1e-6, "starts at the start")
+			assert.InDelta(t, 1, easing(1), 1e-6, "ends at the end")
+			for x := float32(0.1); x < 1; x += 0.1 {
+				assert.Greater(t, easing(x), easing(x-0.1), "always moves forward")
+			}
+		})
+	}
+	assert.InDelta(t, 0.5, EaseInOutQuad(0.5), 1e-6)
+	assert.Less(t, EaseInOutQuad(0.25), float32(0.25), "eases in")
+	assert.Greater(t, EaseOutCubic(0.25), float32(0.25), "starts quickly")
+}
+
+func TestTween_At(t *testing.T) {
+	tween := Tween{From: rl.Vector2{X: 0, Y: 10}, To: rl.Vector2{X: 100, Y: 30}, Duration: time.Second}
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    rl.Vector2
+		done    bool
+	}{
+		{"before it starts", -time.Second, rl.Vector2{X: 0, Y: 10}, false},
+		{"at the start", 0, rl.Vector2{X: 0, Y: 10}, false},
+		{"halfway", 500 * time.Millisecond, rl.Vector2{X: 50, Y: 20}, false},
+		{"at the end", time.Second, rl.Vector2{X: 100, Y: 30}, true},
+		{"after the end", 2 * time.Second, rl.Vector2{X: 100, Y: 30}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tween.At(tt.elapsed))
+			assert.Equal(t, tt.done, tween.Done(tt.elapsed))
+		})
+	}
+
+	instant := Tween{From: rl.Vector2{}, To: rl.Vector2{X: 1}}
+	assert.Equal(t, rl.Vector2{X: 1}, instant.At(0), "a tween that takes no time is already over")
+}
+
+func TestPath_At(t *testing.T) {
+	path := Path{
+		Points:  []rl.Vector2{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 100}},
+		LegTime: 100 * time.Millisecond,
+		Ease:    EaseInOutQuad,
+	}
+	assert.Equal(t, 200*time.Millisecond, path.Duration())
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    rl.Vector2
+	}{
+		{"before it starts", -time.Millisecond, rl.Vector2{X: 0, Y: 0}},
+		{"halfway through the first leg", 50 * time.Millisecond, rl.Vector2{X: 50, Y: 0}},
+		{"eased early in a leg", 25 * time.Millisecond, rl.Vector2{X: 12.5, Y: 0}},
+		{"at the corner", 100 * time.Millisecond, rl.Vector2{X: 100, Y: 0}},
+		{"halfway through the second leg", 150 * time.Millisecond, rl.Vector2{X: 100, Y: 50}},
+		{"at the end", 200 * time.Millisecond, rl.Vector2{X: 100, Y: 100}},
+		{"after the end", time.Second, rl.Vector2{X: 100, Y: 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := path.At(tt.elapsed)
+			assert.InDelta(t, tt.want.X, got.X, 1e-3)
+			assert.InDelta(t, tt.want.Y, got.Y, 1e-3)
+		})
+	}
+	assert.False(t, path.Done(199*time.Millisecond))
+	assert.True(t, path.Done(200*time.Millisecond))
+
+	still := Path{Points: []rl.Vector2{{X: 5, Y: 5}}, LegTime: time.Second}
+	assert.Zero(t, still.Duration())
+	assert.Equal(t, rl.Vector2{X: 5, Y: 5}, still.At(0))
+	assert.Equal(t, rl.Vector2{}, Path{}.At(0))
+}
@@ -2,6 +2,11 @@ package random
 
 import "math/rand"
 
+// New returns a new random number generator seeded with seed, for callers that need a repeatable sequence.
+func New(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed)) //nolint:gosec
+}
+
 // IntInRange returns a random int between min and max, inclusive. Panics if min >= max.
 func IntInRange(min, max int) int {
 	if min >= max {
@@ -26,3 +31,15 @@ func Choice[T any](items []T) T {
 	}
 	return items[rand.Intn(len(items))] //nolint:gosec
 }
+
+// ChoiceFrom returns a random element from the given slice using the given generator. If r is nil, the shared
+// generator is used, just like Choice. Panics if the slice is empty.
+func ChoiceFrom[T any](r *rand.Rand, items []T) T {
+	if r == nil {
+		return Choice(items)
+	}
+	if len(items) == 0 {
+		panic("cannot choose from empty slice")
+	}
+	return items[r.Intn(len(items))]
+}